@@ -43,8 +43,19 @@
 // trees, (http://github.com/petar/gollrb), an excellent and probably the most
 // widely used ordered tree implementation in the Go ecosystem currently.
 // Its functions, therefore, exactly mirror those of
-// llrb.LLRB where possible.  Unlike gollrb, though, we currently don't
-// support storing multiple equivalent values.
+// llrb.LLRB where possible.  Unlike gollrb, though, the trees created by New
+// and NewOf don't store multiple equivalent values, ReplaceOrInsert replaces
+// the existing one. Use NewMulti or NewMultiOf instead to store them, where
+// the equivalent values are kept in insertion order.
+//
+// New creates a btree whose items are interface{} values, while NewOf creates the
+// type-parameterized BTree[T], whose items are ordered by a func(a, b T) int.
+//
+// Besides the Ascend*/Descend* callbacks, the items can be ranged over using the
+// All*/Backward* iterators:
+//   for item := range t.AllRange(10, 20) {
+//       // do something with item
+//   }
 package btree
 
 // Notes from Benjamin Wang (Sept 4, 2021):
@@ -53,10 +64,9 @@ package btree
 // The related test file btree_test.go was refactored as well.
 
 import (
-	"fmt"
 	"io"
+	"iter"
 	"sort"
-	"strings"
 	"sync"
 
 	"github.com/ahrtr/gocontainer/collection"
@@ -114,6 +124,32 @@ type Interface interface {
 	// [last, first], until iterator returns false.
 	Descend(iterator ItemIterator)
 
+	// All returns an iterator over every value in the tree within the range
+	// [first, last] in ascending order.
+	All() iter.Seq[interface{}]
+	// AllRange returns an iterator over every value in the tree within the range
+	// [greaterOrEqual, lessThan) in ascending order.
+	AllRange(greaterOrEqual, lessThan interface{}) iter.Seq[interface{}]
+	// AllLessThan returns an iterator over every value in the tree within the range
+	// [first, pivot) in ascending order.
+	AllLessThan(pivot interface{}) iter.Seq[interface{}]
+	// AllGreaterOrEqual returns an iterator over every value in the tree within the range
+	// [pivot, last] in ascending order.
+	AllGreaterOrEqual(pivot interface{}) iter.Seq[interface{}]
+
+	// Backward returns an iterator over every value in the tree within the range
+	// [last, first] in descending order.
+	Backward() iter.Seq[interface{}]
+	// BackwardRange returns an iterator over every value in the tree within the range
+	// [lessOrEqual, greaterThan) in descending order.
+	BackwardRange(lessOrEqual, greaterThan interface{}) iter.Seq[interface{}]
+	// BackwardLessOrEqual returns an iterator over every value in the tree within the range
+	// [pivot, first] in descending order.
+	BackwardLessOrEqual(pivot interface{}) iter.Seq[interface{}]
+	// BackwardGreaterThan returns an iterator over every value in the tree within the range
+	// [last, pivot) in descending order.
+	BackwardGreaterThan(pivot interface{}) iter.Seq[interface{}]
+
 	// Get looks for the key item in the tree, returning it.  It returns nil if
 	// unable to find that item.
 	Get(key interface{}) interface{}
@@ -123,63 +159,164 @@ type Interface interface {
 	Max() interface{}
 	// Has returns true if the given key is in the tree.
 	Has(key interface{}) bool
+
+	// Select returns the k-th smallest item in the tree, where k is zero-based.
+	// It returns nil if k is out of the range [0, Size()).
+	Select(k int) interface{}
+	// Rank returns the number of items in the tree which are less than the given item.
+	// If the item is in the tree, it's the zero-based index of the item in ascending order.
+	Rank(item interface{}) int
+	// CountRange returns the number of items in the tree within the range [greaterOrEqual, lessThan).
+	// A nil bound means the range is unbounded on that side.
+	CountRange(greaterOrEqual, lessThan interface{}) int
+	// DeleteAt removes the k-th smallest item in the tree and returns it, where k is zero-based.
+	// It returns nil if k is out of the range [0, Size()).
+	DeleteAt(k int) interface{}
+
+	// Cursor returns a new cursor over the tree, which isn't positioned at any item
+	// until First, Last or Seek is called.
+	Cursor() *Cursor
+
+	// SplitAt splits the tree at the given key, and returns two new trees. The left one contains
+	// the items less than the key, and the right one contains the others. The tree itself is left unchanged.
+	SplitAt(key interface{}) (left, right Interface)
+	// Merge adds all the items in the other tree, which must be ordered by the same comparator, into this tree.
+	// If both trees contain an equal item, then conflict is called with the item in this tree and the item in
+	// the other tree, and the item it returns is kept. If conflict is nil, the item in the other tree is kept.
+	// The other tree is cloned lazily, so Merge should not be called concurrently with the other uses of it.
+	Merge(other Interface, conflict func(existing, incoming interface{}) interface{})
+	// DeleteRange removes all the items in the tree within the range [greaterOrEqual, lessThan), and returns
+	// the number of items removed. A nil bound means the range is unbounded on that side.
+	DeleteRange(greaterOrEqual, lessThan interface{}) int
+	// Filter removes all the items in the tree which don't satisfy the predicate, and returns the number of
+	// items removed.
+	Filter(pred func(item interface{}) bool) int
+	// ClearAndRecycle removes all items from the btree, and the nodes owned by the btree are added to
+	// its freelist, until the freelist is full.
+	ClearAndRecycle()
+
+	// Validate checks the invariants of the tree, and returns an error wrapping ErrInvalidTree which
+	// describes the first violation found, or nil if the tree is valid.
+	Validate() error
+	// Stats returns the statistics of the structure of the tree.
+	Stats() Stats
+	// WriteDOT writes the structure of the tree to w as a Graphviz DOT graph. opts can be nil.
+	WriteDOT(w io.Writer, opts *RenderOptions) error
+	// Print writes the structure of the tree to w as indented text, one node per line. opts can be nil.
+	Print(w io.Writer, opts *RenderOptions) error
 }
 
 const (
 	DefaultFreeListSize = 32
 )
 
-var (
-	nilItems    = make(items, 16)
-	nilChildren = make(children, 16)
-)
-
-// FreeList represents a free list of btree nodes. By default, each
-// BTree has its own FreeList, but multiple BTrees can share the same
-// FreeList.
+// FreeListOf represents a free list of btree nodes. By default, each
+// BTree has its own FreeListOf, but multiple BTrees can share the same
+// FreeListOf.
 // Two Btrees using the same freelist are safe for concurrent write access.
-type FreeList struct {
+type FreeListOf[T any] struct {
 	mu       sync.Mutex
-	freelist []*node
+	freelist []*node[T]
+	stats    FreeListStats
+}
+
+// FreeListStats describes the usage of a free list.
+type FreeListStats struct {
+	// Size is the number of nodes in the free list, and Capacity is the max number of nodes it can hold.
+	Size     int
+	Capacity int
+	// Reused is the number of nodes taken from the free list, and Allocated is the number of
+	// nodes allocated because the free list was empty.
+	Reused    uint64
+	Allocated uint64
+	// Recycled is the number of nodes added to the free list, and Discarded is the number of
+	// nodes left to the garbage collector because the free list was full.
+	Recycled  uint64
+	Discarded uint64
 }
 
+// FreeList represents a free list of btree nodes, which can be shared by the
+// btrees created by NewWithFreeList.
+type FreeList = FreeListOf[interface{}]
+
 // NewFreeList creates a new free list.
 // size is the maximum size of the returned free list.
 func NewFreeList(size int) *FreeList {
-	return &FreeList{freelist: make([]*node, 0, size)}
+	return NewFreeListOf[interface{}](size)
+}
+
+// NewFreeListOf creates a new free list for the btrees whose items are of type T.
+// size is the maximum size of the returned free list.
+func NewFreeListOf[T any](size int) *FreeListOf[T] {
+	return &FreeListOf[T]{freelist: make([]*node[T], 0, size)}
 }
 
-func (f *FreeList) newNode() (n *node) {
+func (f *FreeListOf[T]) newNode() (n *node[T]) {
 	f.mu.Lock()
 	index := len(f.freelist) - 1
 	if index < 0 {
+		f.stats.Allocated++
 		f.mu.Unlock()
-		return new(node)
+		return new(node[T])
 	}
 	n = f.freelist[index]
 	f.freelist[index] = nil
 	f.freelist = f.freelist[:index]
+	f.stats.Reused++
 	f.mu.Unlock()
 	return
 }
 
 // freeNode adds the given node to the list, returning true if it was added
 // and false if it was discarded.
-func (f *FreeList) freeNode(n *node) (out bool) {
+func (f *FreeListOf[T]) freeNode(n *node[T]) (out bool) {
 	f.mu.Lock()
 	if len(f.freelist) < cap(f.freelist) {
 		f.freelist = append(f.freelist, n)
+		f.stats.Recycled++
 		out = true
+	} else {
+		f.stats.Discarded++
 	}
 	f.mu.Unlock()
 	return
 }
 
+// Stats returns the usage of the free list.
+func (f *FreeListOf[T]) Stats() FreeListStats {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	s := f.stats
+	s.Size, s.Capacity = len(f.freelist), cap(f.freelist)
+	return s
+}
+
+// Shrink removes the nodes from the free list until at most size nodes are left, so that they
+// can be reclaimed by the garbage collector, and returns the number of nodes removed. The max
+// number of nodes the free list can hold is unchanged.
+func (f *FreeListOf[T]) Shrink(size int) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if size < 0 {
+		size = 0
+	}
+	if size >= len(f.freelist) {
+		return 0
+	}
+	removed := len(f.freelist) - size
+	clear(f.freelist[size:])
+	f.freelist = f.freelist[:size]
+	return removed
+}
+
 // ItemIterator allows callers of Ascend* to iterate in-order over portions of
 // the tree.  When this function returns false, iteration will stop and the
 // associated Ascend* function will immediately return.
 type ItemIterator func(i interface{}) bool
 
+// ItemIteratorOf is the same as ItemIterator, but it's used by BTree[T].
+type ItemIteratorOf[T any] func(item T) bool
+
 // New creates a new B-Tree with the given degree.
 //
 // New(2), for example, will create a 2-3-4 tree (each node contains 1-3 items
@@ -190,27 +327,48 @@ func New(degree int) Interface {
 
 // NewWithFreeList creates a new B-Tree that uses the given node free list.
 func NewWithFreeList(degree int, f *FreeList) Interface {
+	return &bTree{NewWithFreeListOf[interface{}](degree, nil, f)}
+}
+
+// NewOf creates a new B-Tree with the given degree, whose items are of type T.
+// The items are ordered by cmp, which returns a negative integer, zero, or a positive integer
+// as the first argument is less than, equal to, or greater than the second. If cmp is nil,
+// then the items are ordered according to their natural ordering.
+func NewOf[T any](degree int, cmp func(a, b T) int) *BTree[T] {
+	return NewWithFreeListOf(degree, cmp, NewFreeListOf[T](DefaultFreeListSize))
+}
+
+// NewWithFreeListOf creates a new B-Tree that uses the given node free list, whose items are of type T.
+func NewWithFreeListOf[T any](degree int, cmp func(a, b T) int, f *FreeListOf[T]) *BTree[T] {
 	if degree <= 1 {
 		panic("bad degree")
 	}
-	return &bTree{
+	t := &BTree[T]{
 		degree: degree,
-		cow:    &copyOnWriteContext{freelist: f},
+		cow:    &copyOnWriteContext[T]{freelist: f},
 	}
+	return t.WithComparator(cmp)
 }
 
-func (t *bTree) WithComparator(c utils.Comparator) Interface {
-	t.cmp = c
+// WithComparator sets the comparison function for the btree, which is used to impose a total
+// ordering on the items in the btree. The items are ordered according to their natural ordering if cmp is nil.
+// It should be called before any item is added into the btree.
+func (t *BTree[T]) WithComparator(cmp func(a, b T) int) *BTree[T] {
+	if cmp == nil {
+		cmp = utils.CompareFunc[T](nil)
+	}
+	t.cmp = cmp
 	return t
 }
 
 // items stores items in a node.
-type items []interface{}
+type items[T any] []T
 
 // insertAt inserts a value into the given index, pushing all subsequent values
 // forward.
-func (s *items) insertAt(index int, item interface{}) {
-	*s = append(*s, nil)
+func (s *items[T]) insertAt(index int, item T) {
+	var zero T
+	*s = append(*s, zero)
 	if index < len(*s) {
 		copy((*s)[index+1:], (*s)[index:])
 	}
@@ -219,37 +377,37 @@ func (s *items) insertAt(index int, item interface{}) {
 
 // removeAt removes a value at a given index, pulling all subsequent values
 // back.
-func (s *items) removeAt(index int) interface{} {
+func (s *items[T]) removeAt(index int) T {
+	var zero T
 	item := (*s)[index]
 	copy((*s)[index:], (*s)[index+1:])
-	(*s)[len(*s)-1] = nil
+	(*s)[len(*s)-1] = zero
 	*s = (*s)[:len(*s)-1]
 	return item
 }
 
 // pop removes and returns the last element in the list.
-func (s *items) pop() (out interface{}) {
+func (s *items[T]) pop() (out T) {
+	var zero T
 	index := len(*s) - 1
 	out = (*s)[index]
-	(*s)[index] = nil
+	(*s)[index] = zero
 	*s = (*s)[:index]
 	return
 }
 
 // truncate truncates this instance at index so that it contains only the
 // first index items. index must be less than or equal to length.
-func (s *items) truncate(index int) {
-	var toClear items
+func (s *items[T]) truncate(index int) {
+	var toClear items[T]
 	*s, toClear = (*s)[:index], (*s)[index:]
-	for len(toClear) > 0 {
-		toClear = toClear[copy(toClear, nilItems):]
-	}
+	clear(toClear)
 }
 
 // find returns the index where the given item should be inserted into this
 // list.  'found' is true if the item already exists in the list at the given
 // index.
-func (s items) find(item interface{}, cmp utils.Comparator) (index int, found bool) {
+func (s items[T]) find(item T, cmp func(a, b T) int) (index int, found bool) {
 	i := sort.Search(len(s), func(i int) bool {
 		return lessThan(item, s[i], cmp)
 	})
@@ -262,11 +420,11 @@ func (s items) find(item interface{}, cmp utils.Comparator) (index int, found bo
 }
 
 // children stores child nodes in a node.
-type children []*node
+type children[T any] []*node[T]
 
 // insertAt inserts a value into the given index, pushing all subsequent values
 // forward.
-func (s *children) insertAt(index int, n *node) {
+func (s *children[T]) insertAt(index int, n *node[T]) {
 	*s = append(*s, nil)
 	if index < len(*s) {
 		copy((*s)[index+1:], (*s)[index:])
@@ -276,7 +434,7 @@ func (s *children) insertAt(index int, n *node) {
 
 // removeAt removes a value at a given index, pulling all subsequent values
 // back.
-func (s *children) removeAt(index int) *node {
+func (s *children[T]) removeAt(index int) *node[T] {
 	n := (*s)[index]
 	copy((*s)[index:], (*s)[index+1:])
 	(*s)[len(*s)-1] = nil
@@ -285,7 +443,7 @@ func (s *children) removeAt(index int) *node {
 }
 
 // pop removes and returns the last element in the list.
-func (s *children) pop() (out *node) {
+func (s *children[T]) pop() (out *node[T]) {
 	index := len(*s) - 1
 	out = (*s)[index]
 	(*s)[index] = nil
@@ -295,12 +453,10 @@ func (s *children) pop() (out *node) {
 
 // truncate truncates this instance at index so that it contains only the
 // first index children. index must be less than or equal to length.
-func (s *children) truncate(index int) {
-	var toClear children
+func (s *children[T]) truncate(index int) {
+	var toClear children[T]
 	*s, toClear = (*s)[:index], (*s)[index:]
-	for len(toClear) > 0 {
-		toClear = toClear[copy(toClear, nilChildren):]
-	}
+	clear(toClear)
 }
 
 // node is an internal node in a tree.
@@ -308,13 +464,17 @@ func (s *children) truncate(index int) {
 // It must at all times maintain the invariant that either
 //   * len(children) == 0, len(items) unconstrained
 //   * len(children) == len(items) + 1
-type node struct {
-	items    items
-	children children
-	cow      *copyOnWriteContext
+//
+// size is the number of items in the subtree rooted at the node, which makes
+// the order-statistic operations (Select, Rank, etc.) O(log n).
+type node[T any] struct {
+	items    items[T]
+	children children[T]
+	size     int
+	cow      *copyOnWriteContext[T]
 }
 
-func (n *node) mutableFor(cow *copyOnWriteContext) *node {
+func (n *node[T]) mutableFor(cow *copyOnWriteContext[T]) *node[T] {
 	if n.cow == cow {
 		return n
 	}
@@ -322,20 +482,21 @@ func (n *node) mutableFor(cow *copyOnWriteContext) *node {
 	if cap(out.items) >= len(n.items) {
 		out.items = out.items[:len(n.items)]
 	} else {
-		out.items = make(items, len(n.items), cap(n.items))
+		out.items = make(items[T], len(n.items), cap(n.items))
 	}
 	copy(out.items, n.items)
 	// Copy children
 	if cap(out.children) >= len(n.children) {
 		out.children = out.children[:len(n.children)]
 	} else {
-		out.children = make(children, len(n.children), cap(n.children))
+		out.children = make(children[T], len(n.children), cap(n.children))
 	}
 	copy(out.children, n.children)
+	out.size = n.size
 	return out
 }
 
-func (n *node) mutableChild(i int) *node {
+func (n *node[T]) mutableChild(i int) *node[T] {
 	c := n.children[i].mutableFor(n.cow)
 	n.children[i] = c
 	return c
@@ -344,7 +505,7 @@ func (n *node) mutableChild(i int) *node {
 // split splits the given node at the given index.  The current node shrinks,
 // and this function returns the item that existed at that index and a new node
 // containing all items/children after it.
-func (n *node) split(i int) (interface{}, *node) {
+func (n *node[T]) split(i int) (T, *node[T]) {
 	item := n.items[i]
 	next := n.cow.newNode()
 	next.items = append(next.items, n.items[i+1:]...)
@@ -353,12 +514,23 @@ func (n *node) split(i int) (interface{}, *node) {
 		next.children = append(next.children, n.children[i+1:]...)
 		n.children.truncate(i + 1)
 	}
+	next.recount()
+	n.size -= next.size + 1
 	return item, next
 }
 
+// recount recomputes the size of the subtree rooted at the node from its children.
+func (n *node[T]) recount() {
+	size := len(n.items)
+	for _, c := range n.children {
+		size += c.size
+	}
+	n.size = size
+}
+
 // maybeSplitChild checks if a child should be split, and if so splits it.
 // Returns whether or not a split occurred.
-func (n *node) maybeSplitChild(i, maxItems int) bool {
+func (n *node[T]) maybeSplitChild(i, maxItems int) bool {
 	if len(n.children[i].items) < maxItems {
 		return false
 	}
@@ -372,16 +544,17 @@ func (n *node) maybeSplitChild(i, maxItems int) bool {
 // insert inserts an item into the subtree rooted at this node, making sure
 // no nodes in the subtree exceed maxItems items.  Should an equivalent item be
 // be found/replaced by insert, it will be returned.
-func (n *node) insert(item interface{}, maxItems int, cmp utils.Comparator) interface{} {
+func (n *node[T]) insert(item T, maxItems int, cmp func(a, b T) int) (_ T, _ bool) {
 	i, found := n.items.find(item, cmp)
 	if found {
 		out := n.items[i]
 		n.items[i] = item
-		return out
+		return out, true
 	}
 	if len(n.children) == 0 {
 		n.items.insertAt(i, item)
-		return nil
+		n.size++
+		return
 	}
 	if n.maybeSplitChild(i, maxItems) {
 		inTree := n.items[i]
@@ -393,49 +566,53 @@ func (n *node) insert(item interface{}, maxItems int, cmp utils.Comparator) inte
 		default:
 			out := n.items[i]
 			n.items[i] = item
-			return out
+			return out, true
 		}
 	}
-	return n.mutableChild(i).insert(item, maxItems, cmp)
+	out, found := n.mutableChild(i).insert(item, maxItems, cmp)
+	if !found {
+		n.size++
+	}
+	return out, found
 }
 
 // get finds the given key in the subtree and returns it.
-func (n *node) get(key interface{}, cmp utils.Comparator) interface{} {
+func (n *node[T]) get(key T, cmp func(a, b T) int) (_ T, _ bool) {
 	i, found := n.items.find(key, cmp)
 	if found {
-		return n.items[i]
+		return n.items[i], true
 	} else if len(n.children) > 0 {
 		return n.children[i].get(key, cmp)
 	}
-	return nil
+	return
 }
 
 // min returns the first item in the subtree.
-func min(n *node) interface{} {
+func min[T any](n *node[T]) (_ T, found bool) {
 	if n == nil {
-		return nil
+		return
 	}
 	for len(n.children) > 0 {
 		n = n.children[0]
 	}
 	if len(n.items) == 0 {
-		return nil
+		return
 	}
-	return n.items[0]
+	return n.items[0], true
 }
 
 // max returns the last item in the subtree.
-func max(n *node) interface{} {
+func max[T any](n *node[T]) (_ T, found bool) {
 	if n == nil {
-		return nil
+		return
 	}
 	for len(n.children) > 0 {
 		n = n.children[len(n.children)-1]
 	}
 	if len(n.items) == 0 {
-		return nil
+		return
 	}
-	return n.items[len(n.items)-1]
+	return n.items[len(n.items)-1], true
 }
 
 // toRemove details what item to remove in a node.remove call.
@@ -448,27 +625,30 @@ const (
 )
 
 // remove removes an item from the subtree rooted at this node.
-func (n *node) remove(item interface{}, minItems int, typ toRemove, cmp utils.Comparator) interface{} {
+func (n *node[T]) remove(item T, minItems int, typ toRemove, cmp func(a, b T) int) (_ T, _ bool) {
 	var i int
 	var found bool
 	switch typ {
 	case removeMax:
 		if len(n.children) == 0 {
-			return n.items.pop()
+			n.size--
+			return n.items.pop(), true
 		}
 		i = len(n.items)
 	case removeMin:
 		if len(n.children) == 0 {
-			return n.items.removeAt(0)
+			n.size--
+			return n.items.removeAt(0), true
 		}
 		i = 0
 	case removeItem:
 		i, found = n.items.find(item, cmp)
 		if len(n.children) == 0 {
 			if found {
-				return n.items.removeAt(i)
+				n.size--
+				return n.items.removeAt(i), true
 			}
-			return nil
+			return
 		}
 	default:
 		panic("invalid type")
@@ -488,12 +668,18 @@ func (n *node) remove(item interface{}, minItems int, typ toRemove, cmp utils.Co
 		// We use our special-case 'remove' call with typ=maxItem to pull the
 		// predecessor of item i (the rightmost leaf of our immediate left child)
 		// and set it into where we pulled the item from.
-		n.items[i] = child.remove(nil, minItems, removeMax, cmp)
-		return out
+		var zero T
+		n.items[i], _ = child.remove(zero, minItems, removeMax, cmp)
+		n.size--
+		return out, true
 	}
 	// Final recursive call.  Once we're here, we know that the item isn't in this
 	// node and that the child is big enough to remove from.
-	return child.remove(item, minItems, typ, cmp)
+	out, ok := child.remove(item, minItems, typ, cmp)
+	if ok {
+		n.size--
+	}
+	return out, ok
 }
 
 // growChildAndRemove grows child 'i' to make sure it's possible to remove an
@@ -515,7 +701,7 @@ func (n *node) remove(item interface{}, minItems int, typ toRemove, cmp utils.Co
 // We then simply redo our remove call, and the second time (regardless of
 // whether we're in case 1 or 2), we'll have enough items and can guarantee
 // that we hit case A.
-func (n *node) growChildAndRemove(i int, item interface{}, minItems int, typ toRemove, cmp utils.Comparator) interface{} {
+func (n *node[T]) growChildAndRemove(i int, item T, minItems int, typ toRemove, cmp func(a, b T) int) (T, bool) {
 	if i > 0 && len(n.children[i-1].items) > minItems {
 		// Steal from left child
 		child := n.mutableChild(i)
@@ -523,9 +709,14 @@ func (n *node) growChildAndRemove(i int, item interface{}, minItems int, typ toR
 		stolenItem := stealFrom.items.pop()
 		child.items.insertAt(0, n.items[i-1])
 		n.items[i-1] = stolenItem
+		moved := 1
 		if len(stealFrom.children) > 0 {
-			child.children.insertAt(0, stealFrom.children.pop())
+			stolenChild := stealFrom.children.pop()
+			child.children.insertAt(0, stolenChild)
+			moved += stolenChild.size
 		}
+		child.size += moved
+		stealFrom.size -= moved
 	} else if i < len(n.items) && len(n.children[i+1].items) > minItems {
 		// steal from right child
 		child := n.mutableChild(i)
@@ -533,9 +724,14 @@ func (n *node) growChildAndRemove(i int, item interface{}, minItems int, typ toR
 		stolenItem := stealFrom.items.removeAt(0)
 		child.items = append(child.items, n.items[i])
 		n.items[i] = stolenItem
+		moved := 1
 		if len(stealFrom.children) > 0 {
-			child.children = append(child.children, stealFrom.children.removeAt(0))
+			stolenChild := stealFrom.children.removeAt(0)
+			child.children = append(child.children, stolenChild)
+			moved += stolenChild.size
 		}
+		child.size += moved
+		stealFrom.size -= moved
 	} else {
 		if i >= len(n.items) {
 			i--
@@ -547,6 +743,7 @@ func (n *node) growChildAndRemove(i int, item interface{}, minItems int, typ toR
 		child.items = append(child.items, mergeItem)
 		child.items = append(child.items, mergeChild.items...)
 		child.children = append(child.children, mergeChild.children...)
+		child.size += 1 + mergeChild.size
 		n.cow.freeNode(mergeChild)
 	}
 	return n.remove(item, minItems, typ, cmp)
@@ -559,6 +756,21 @@ const (
 	ascend  = direction(+1)
 )
 
+// optionalItem is an item which may be absent. An absent start or stop item
+// means the iteration is unbounded on that side.
+type optionalItem[T any] struct {
+	item  T
+	valid bool
+}
+
+func optional[T any](item T) optionalItem[T] {
+	return optionalItem[T]{item: item, valid: true}
+}
+
+func empty[T any]() optionalItem[T] {
+	return optionalItem[T]{}
+}
+
 // iterate provides a simple method for iterating over elements in the tree.
 //
 // When ascending, the 'start' should be less than 'stop' and when descending,
@@ -566,13 +778,13 @@ const (
 // will force the iterator to include the first item when it equals 'start',
 // thus creating a "greaterOrEqual" or "lessThanEqual" rather than just a
 // "greaterThan" or "lessThan" queries.
-func (n *node) iterate(dir direction, start, stop interface{}, includeStart bool, hit bool, iter ItemIterator, cmp utils.Comparator) (bool, bool) {
+func (n *node[T]) iterate(dir direction, start, stop optionalItem[T], includeStart bool, hit bool, iter ItemIteratorOf[T], cmp func(a, b T) int) (bool, bool) {
 	var ok, found bool
 	var index int
 	switch dir {
 	case ascend:
-		if start != nil {
-			index, _ = n.items.find(start, cmp)
+		if start.valid {
+			index, _ = n.items.find(start.item, cmp)
 		}
 		for i := index; i < len(n.items); i++ {
 			if len(n.children) > 0 {
@@ -580,12 +792,12 @@ func (n *node) iterate(dir direction, start, stop interface{}, includeStart bool
 					return hit, false
 				}
 			}
-			if !includeStart && !hit && start != nil && !lessThan(start, n.items[i], cmp) {
+			if !includeStart && !hit && start.valid && !lessThan(start.item, n.items[i], cmp) {
 				hit = true
 				continue
 			}
 			hit = true
-			if stop != nil && !lessThan(n.items[i], stop, cmp) {
+			if stop.valid && !lessThan(n.items[i], stop.item, cmp) {
 				return hit, false
 			}
 			if !iter(n.items[i]) {
@@ -598,8 +810,8 @@ func (n *node) iterate(dir direction, start, stop interface{}, includeStart bool
 			}
 		}
 	case descend:
-		if start != nil {
-			index, found = n.items.find(start, cmp)
+		if start.valid {
+			index, found = n.items.find(start.item, cmp)
 			if !found {
 				index = index - 1
 			}
@@ -607,8 +819,8 @@ func (n *node) iterate(dir direction, start, stop interface{}, includeStart bool
 			index = len(n.items) - 1
 		}
 		for i := index; i >= 0; i-- {
-			if start != nil && !lessThan(n.items[i], start, cmp) {
-				if !includeStart || hit || lessThan(start, n.items[i], cmp) {
+			if start.valid && !lessThan(n.items[i], start.item, cmp) {
+				if !includeStart || hit || lessThan(start.item, n.items[i], cmp) {
 					continue
 				}
 			}
@@ -617,7 +829,7 @@ func (n *node) iterate(dir direction, start, stop interface{}, includeStart bool
 					return hit, false
 				}
 			}
-			if stop != nil && !lessThan(stop, n.items[i], cmp) {
+			if stop.valid && !lessThan(stop.item, n.items[i], cmp) {
 				return hit, false //	continue
 			}
 			hit = true
@@ -634,28 +846,27 @@ func (n *node) iterate(dir direction, start, stop interface{}, includeStart bool
 	return hit, true
 }
 
-// Used for testing/debugging purposes.
-//nolint
-func (n *node) print(w io.Writer, level int) {
-	fmt.Fprintf(w, "%sNODE:%v\n", strings.Repeat("  ", level), n.items)
-	for _, c := range n.children {
-		c.print(w, level+1)
-	}
-}
-
-// bTree is an implementation of a B-Tree.
+// BTree is an implementation of a B-Tree, whose items are of type T.
 //
-// bTree stores Item instances in an ordered structure, allowing easy insertion,
+// BTree stores items in an ordered structure, allowing easy insertion,
 // removal, and iteration.
 //
 // Write operations are not safe for concurrent mutation by multiple
 // goroutines, but Read operations are.
-type bTree struct {
+type BTree[T any] struct {
 	degree int
 	length int
-	root   *node
-	cmp    utils.Comparator
-	cow    *copyOnWriteContext
+	root   *node[T]
+	cmp    func(a, b T) int
+	cow    *copyOnWriteContext[T]
+	// version is increased by every write operation, so that a cursor knows
+	// whether the path to its current item is still valid.
+	version int
+}
+
+// bTree is the btree returned by New and NewWithFreeList, it implements the Interface.
+type bTree struct {
+	*BTree[interface{}]
 }
 
 // copyOnWriteContext pointers determine node ownership... a tree with a write
@@ -672,8 +883,8 @@ type bTree struct {
 // tree's context, that node is modifiable in place.  Children of that node may
 // not share context, but before we descend into them, we'll make a mutable
 // copy.
-type copyOnWriteContext struct {
-	freelist *FreeList
+type copyOnWriteContext[T any] struct {
+	freelist *FreeListOf[T]
 }
 
 // Clone clones the btree, lazily.  Clone should not be called concurrently,
@@ -687,7 +898,7 @@ type copyOnWriteContext struct {
 // will initially experience minor slow-downs caused by additional allocs and
 // copies due to the aforementioned copy-on-write logic, but should converge to
 // the original performance characteristics of the original tree.
-func (t *bTree) Clone() Interface {
+func (t *BTree[T]) Clone() *BTree[T] {
 	// Create two entirely new copy-on-write contexts.
 	// This operation effectively creates three trees:
 	//   the original, shared nodes (old b.cow)
@@ -701,17 +912,17 @@ func (t *bTree) Clone() Interface {
 }
 
 // maxItems returns the max number of items to allow per node.
-func (t *bTree) maxItems() int {
+func (t *BTree[T]) maxItems() int {
 	return t.degree*2 - 1
 }
 
 // minItems returns the min number of items to allow per node (ignored for the
 // root node).
-func (t *bTree) minItems() int {
+func (t *BTree[T]) minItems() int {
 	return t.degree - 1
 }
 
-func (c *copyOnWriteContext) newNode() (n *node) {
+func (c *copyOnWriteContext[T]) newNode() (n *node[T]) {
 	n = c.freelist.newNode()
 	n.cow = c
 	return
@@ -728,11 +939,12 @@ const (
 // freeNode frees a node within a given COW context, if it's owned by that
 // context.  It returns what happened to the node (see freeType const
 // documentation).
-func (c *copyOnWriteContext) freeNode(n *node) freeType {
+func (c *copyOnWriteContext[T]) freeNode(n *node[T]) freeType {
 	if n.cow == c {
 		// clear to allow GC
 		n.items.truncate(0)
 		n.children.truncate(0)
+		n.size = 0
 		n.cow = nil
 		if c.freelist.freeNode(n) {
 			return ftStored
@@ -743,19 +955,16 @@ func (c *copyOnWriteContext) freeNode(n *node) freeType {
 }
 
 // ReplaceOrInsert adds the given item to the tree.  If an item in the tree
-// already equals the given one, it is removed from the tree and returned.
-// Otherwise, nil is returned.
-//
-// nil cannot be added to the tree (will panic).
-func (t *bTree) ReplaceOrInsert(item interface{}) interface{} {
-	if item == nil {
-		panic("nil item being added to BTree")
-	}
+// already equals the given one, it is removed from the tree and returned,
+// and the second return value is true.  Otherwise, (zeroValue, false).
+func (t *BTree[T]) ReplaceOrInsert(item T) (_ T, _ bool) {
+	t.version++
 	if t.root == nil {
 		t.root = t.cow.newNode()
 		t.root.items = append(t.root.items, item)
+		t.root.size = 1
 		t.length++
-		return nil
+		return
 	}
 
 	t.root = t.root.mutableFor(t.cow)
@@ -765,160 +974,212 @@ func (t *bTree) ReplaceOrInsert(item interface{}) interface{} {
 		t.root = t.cow.newNode()
 		t.root.items = append(t.root.items, item2)
 		t.root.children = append(t.root.children, oldRoot, second)
+		t.root.recount()
 	}
 
-	out := t.root.insert(item, t.maxItems(), t.cmp)
-	if out == nil {
+	out, outOk := t.root.insert(item, t.maxItems(), t.cmp)
+	if !outOk {
 		t.length++
 	}
-	return out
+	return out, outOk
 }
 
 // Delete removes an item equal to the passed in item from the tree, returning
-// it.  If no such item exists, returns nil.
-func (t *bTree) Delete(item interface{}) interface{} {
-	return t.deleteItem(item, removeItem, t.cmp)
+// it.  If no such item exists, returns (zeroValue, false).
+func (t *BTree[T]) Delete(item T) (T, bool) {
+	return t.deleteItem(item, removeItem)
 }
 
 // DeleteMin removes the smallest item in the tree and returns it.
-// If no such item exists, returns nil.
-func (t *bTree) DeleteMin() interface{} {
-	return t.deleteItem(nil, removeMin, t.cmp)
+// If no such item exists, returns (zeroValue, false).
+func (t *BTree[T]) DeleteMin() (T, bool) {
+	var zero T
+	return t.deleteItem(zero, removeMin)
 }
 
 // DeleteMax removes the largest item in the tree and returns it.
-// If no such item exists, returns nil.
-func (t *bTree) DeleteMax() interface{} {
-	return t.deleteItem(nil, removeMax, t.cmp)
+// If no such item exists, returns (zeroValue, false).
+func (t *BTree[T]) DeleteMax() (T, bool) {
+	var zero T
+	return t.deleteItem(zero, removeMax)
 }
 
-func (t *bTree) deleteItem(item interface{}, typ toRemove, cmp utils.Comparator) interface{} {
+func (t *BTree[T]) deleteItem(item T, typ toRemove) (_ T, _ bool) {
 	if t.root == nil || len(t.root.items) == 0 {
-		return nil
+		return
 	}
+	t.version++
 	t.root = t.root.mutableFor(t.cow)
-	out := t.root.remove(item, t.minItems(), typ, cmp)
+	out, outOk := t.root.remove(item, t.minItems(), typ, t.cmp)
 	if len(t.root.items) == 0 && len(t.root.children) > 0 {
 		oldroot := t.root
 		t.root = t.root.children[0]
 		t.cow.freeNode(oldroot)
 	}
-	if out != nil {
+	if outOk {
 		t.length--
 	}
-	return out
+	return out, outOk
 }
 
-// AscendRange calls the iterator for every value in the tree within the range
-// [greaterOrEqual, lessThan), until iterator returns false.
-func (t *bTree) AscendRange(greaterOrEqual, lessThan interface{}, iterator ItemIterator) {
+// iterate calls the iterator for every value in the tree within the range
+// between start and stop, until iterator returns false.
+func (t *BTree[T]) iterate(dir direction, start, stop optionalItem[T], includeStart bool, iterator ItemIteratorOf[T]) {
 	if t.root == nil {
 		return
 	}
-	t.root.iterate(ascend, greaterOrEqual, lessThan, true, false, iterator, t.cmp)
+	t.root.iterate(dir, start, stop, includeStart, false, iterator, t.cmp)
+}
+
+// AscendRange calls the iterator for every value in the tree within the range
+// [greaterOrEqual, lessThan), until iterator returns false.
+func (t *BTree[T]) AscendRange(greaterOrEqual, lessThan T, iterator ItemIteratorOf[T]) {
+	t.iterate(ascend, optional(greaterOrEqual), optional(lessThan), true, iterator)
 }
 
 // AscendLessThan calls the iterator for every value in the tree within the range
 // [first, pivot), until iterator returns false.
-func (t *bTree) AscendLessThan(pivot interface{}, iterator ItemIterator) {
-	if t.root == nil {
-		return
-	}
-	t.root.iterate(ascend, nil, pivot, false, false, iterator, t.cmp)
+func (t *BTree[T]) AscendLessThan(pivot T, iterator ItemIteratorOf[T]) {
+	t.iterate(ascend, empty[T](), optional(pivot), false, iterator)
 }
 
 // AscendGreaterOrEqual calls the iterator for every value in the tree within
 // the range [pivot, last], until iterator returns false.
-func (t *bTree) AscendGreaterOrEqual(pivot interface{}, iterator ItemIterator) {
-	if t.root == nil {
-		return
-	}
-	t.root.iterate(ascend, pivot, nil, true, false, iterator, t.cmp)
+func (t *BTree[T]) AscendGreaterOrEqual(pivot T, iterator ItemIteratorOf[T]) {
+	t.iterate(ascend, optional(pivot), empty[T](), true, iterator)
 }
 
 // Ascend calls the iterator for every value in the tree within the range
 // [first, last], until iterator returns false.
-func (t *bTree) Ascend(iterator ItemIterator) {
-	if t.root == nil {
-		return
-	}
-	t.root.iterate(ascend, nil, nil, false, false, iterator, t.cmp)
+func (t *BTree[T]) Ascend(iterator ItemIteratorOf[T]) {
+	t.iterate(ascend, empty[T](), empty[T](), false, iterator)
 }
 
 // DescendRange calls the iterator for every value in the tree within the range
 // [lessOrEqual, greaterThan), until iterator returns false.
-func (t *bTree) DescendRange(lessOrEqual, greaterThan interface{}, iterator ItemIterator) {
-	if t.root == nil {
-		return
-	}
-	t.root.iterate(descend, lessOrEqual, greaterThan, true, false, iterator, t.cmp)
+func (t *BTree[T]) DescendRange(lessOrEqual, greaterThan T, iterator ItemIteratorOf[T]) {
+	t.iterate(descend, optional(lessOrEqual), optional(greaterThan), true, iterator)
 }
 
 // DescendLessOrEqual calls the iterator for every value in the tree within the range
 // [pivot, first], until iterator returns false.
-func (t *bTree) DescendLessOrEqual(pivot interface{}, iterator ItemIterator) {
-	if t.root == nil {
-		return
-	}
-	t.root.iterate(descend, pivot, nil, true, false, iterator, t.cmp)
+func (t *BTree[T]) DescendLessOrEqual(pivot T, iterator ItemIteratorOf[T]) {
+	t.iterate(descend, optional(pivot), empty[T](), true, iterator)
 }
 
 // DescendGreaterThan calls the iterator for every value in the tree within
 // the range [last, pivot), until iterator returns false.
-func (t *bTree) DescendGreaterThan(pivot interface{}, iterator ItemIterator) {
-	if t.root == nil {
-		return
-	}
-	t.root.iterate(descend, nil, pivot, false, false, iterator, t.cmp)
+func (t *BTree[T]) DescendGreaterThan(pivot T, iterator ItemIteratorOf[T]) {
+	t.iterate(descend, empty[T](), optional(pivot), false, iterator)
 }
 
 // Descend calls the iterator for every value in the tree within the range
 // [last, first], until iterator returns false.
-func (t *bTree) Descend(iterator ItemIterator) {
-	if t.root == nil {
-		return
+func (t *BTree[T]) Descend(iterator ItemIteratorOf[T]) {
+	t.iterate(descend, empty[T](), empty[T](), false, iterator)
+}
+
+// seq returns an iterator over every value in the tree within the range between start and stop.
+// The tree must not be modified during the iteration.
+func (t *BTree[T]) seq(dir direction, start, stop optionalItem[T], includeStart bool) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		t.iterate(dir, start, stop, includeStart, yield)
 	}
-	t.root.iterate(descend, nil, nil, false, false, iterator, t.cmp)
 }
 
-// Get looks for the key item in the tree, returning it.  It returns nil if
-// unable to find that item.
-func (t *bTree) Get(key interface{}) interface{} {
+// All returns an iterator over every value in the tree within the range
+// [first, last] in ascending order.
+func (t *BTree[T]) All() iter.Seq[T] {
+	return t.seq(ascend, empty[T](), empty[T](), false)
+}
+
+// AllRange returns an iterator over every value in the tree within the range
+// [greaterOrEqual, lessThan) in ascending order.
+func (t *BTree[T]) AllRange(greaterOrEqual, lessThan T) iter.Seq[T] {
+	return t.seq(ascend, optional(greaterOrEqual), optional(lessThan), true)
+}
+
+// AllLessThan returns an iterator over every value in the tree within the range
+// [first, pivot) in ascending order.
+func (t *BTree[T]) AllLessThan(pivot T) iter.Seq[T] {
+	return t.seq(ascend, empty[T](), optional(pivot), false)
+}
+
+// AllGreaterOrEqual returns an iterator over every value in the tree within the range
+// [pivot, last] in ascending order.
+func (t *BTree[T]) AllGreaterOrEqual(pivot T) iter.Seq[T] {
+	return t.seq(ascend, optional(pivot), empty[T](), true)
+}
+
+// Backward returns an iterator over every value in the tree within the range
+// [last, first] in descending order.
+func (t *BTree[T]) Backward() iter.Seq[T] {
+	return t.seq(descend, empty[T](), empty[T](), false)
+}
+
+// BackwardRange returns an iterator over every value in the tree within the range
+// [lessOrEqual, greaterThan) in descending order.
+func (t *BTree[T]) BackwardRange(lessOrEqual, greaterThan T) iter.Seq[T] {
+	return t.seq(descend, optional(lessOrEqual), optional(greaterThan), true)
+}
+
+// BackwardLessOrEqual returns an iterator over every value in the tree within the range
+// [pivot, first] in descending order.
+func (t *BTree[T]) BackwardLessOrEqual(pivot T) iter.Seq[T] {
+	return t.seq(descend, optional(pivot), empty[T](), true)
+}
+
+// BackwardGreaterThan returns an iterator over every value in the tree within the range
+// [last, pivot) in descending order.
+func (t *BTree[T]) BackwardGreaterThan(pivot T) iter.Seq[T] {
+	return t.seq(descend, empty[T](), optional(pivot), false)
+}
+
+// Get looks for the key item in the tree, returning it.  It returns
+// (zeroValue, false) if unable to find that item.
+func (t *BTree[T]) Get(key T) (_ T, _ bool) {
 	if t.root == nil {
-		return nil
+		return
 	}
 	return t.root.get(key, t.cmp)
 }
 
-// Min returns the smallest item in the tree, or nil if the tree is empty.
-func (t *bTree) Min() interface{} {
+// Min returns the smallest item in the tree, or (zeroValue, false) if the tree is empty.
+func (t *BTree[T]) Min() (T, bool) {
 	return min(t.root)
 }
 
-// Max returns the largest item in the tree, or nil if the tree is empty.
-func (t *bTree) Max() interface{} {
+// Max returns the largest item in the tree, or (zeroValue, false) if the tree is empty.
+func (t *BTree[T]) Max() (T, bool) {
 	return max(t.root)
 }
 
 // Has returns true if the given key is in the tree.
-func (t *bTree) Has(key interface{}) bool {
-	return t.Get(key) != nil
+func (t *BTree[T]) Has(key T) bool {
+	_, ok := t.Get(key)
+	return ok
 }
 
 // Size returns the number of items currently in the tree.
-func (t *bTree) Size() int {
+func (t *BTree[T]) Size() int {
 	return t.length
 }
 
 // IsEmpty returns true if the tree doesn't have any items.
-func (t *bTree) IsEmpty() bool {
+func (t *BTree[T]) IsEmpty() bool {
 	return t.Size() == 0
 }
 
-// Clear removes all items from the btree.  If addNodesToFreelist is true,
-// t's nodes are added to its freelist as part of this call, until the freelist
-// is full.  Otherwise, the root node is simply dereferenced and the subtree
-// left to Go's normal GC processes.
+// Clear removes all items from the btree.  The root node is simply
+// dereferenced and the subtree left to Go's normal GC processes, so this is
+// a single operation.  Use ClearAndRecycle to reclaim the nodes into the
+// freelist instead.
+func (t *BTree[T]) Clear() {
+	t.clear(false)
+}
+
+// ClearAndRecycle removes all items from the btree, and t's nodes are added
+// to its freelist as part of this call, until the freelist is full.
 //
 // This can be much faster
 // than calling Delete on all elements, because that requires finding/removing
@@ -928,22 +1189,31 @@ func (t *bTree) IsEmpty() bool {
 // one, instead of being lost to the garbage collector.
 //
 // This call takes:
-//   O(1): when addNodesToFreelist is false, this is a single operation.
 //   O(1): when the freelist is already full, it breaks out immediately
 //   O(freelist size):  when the freelist is empty and the nodes are all owned
 //       by this tree, nodes are added to the freelist until full.
 //   O(tree size):  when all nodes are owned by another tree, all nodes are
 //       iterated over looking for nodes to add to the freelist, and due to
 //       ownership, none are.
-func (t *bTree) Clear() {
+func (t *BTree[T]) ClearAndRecycle() {
+	t.clear(true)
+}
+
+// clear removes all items from the btree.  If addNodesToFreelist is true,
+// t's nodes are added to its freelist as part of this call, until the freelist
+// is full.
+func (t *BTree[T]) clear(addNodesToFreelist bool) {
+	if t.root != nil && addNodesToFreelist {
+		t.root.reset(t.cow)
+	}
 	t.root, t.length = nil, 0
+	t.version++
 }
 
 // reset returns a subtree to the freelist.  It breaks out immediately if the
 // freelist is full, since the only benefit of iterating is to fill that
 // freelist up.  Returns true if parent reset call should continue.
-//nolint
-func (n *node) reset(c *copyOnWriteContext) bool {
+func (n *node[T]) reset(c *copyOnWriteContext[T]) bool {
 	for _, child := range n.children {
 		if !child.reset(c) {
 			return false
@@ -952,10 +1222,126 @@ func (n *node) reset(c *copyOnWriteContext) bool {
 	return c.freeNode(n) != ftFreelistFull
 }
 
-func lessThan(item1, item2 interface{}, cmp utils.Comparator) bool {
-	if cmpRet, err := utils.Compare(item1, item2, cmp); err != nil {
-		panic(err)
-	} else {
-		return cmpRet < 0
+func lessThan[T any](item1, item2 T, cmp func(a, b T) int) bool {
+	return cmp(item1, item2) < 0
+}
+
+// optionalOrEmpty converts a nil bound of the Interface methods into an absent item.
+func optionalOrEmpty(item interface{}) optionalItem[interface{}] {
+	if item == nil {
+		return empty[interface{}]()
+	}
+	return optional(item)
+}
+
+func (t *bTree) WithComparator(c utils.Comparator) Interface {
+	t.BTree.WithComparator(utils.CompareFunc[interface{}](c))
+	return t
+}
+
+func (t *bTree) Clone() Interface {
+	return &bTree{t.BTree.Clone()}
+}
+
+// ReplaceOrInsert adds the given item to the tree.  If an item in the tree
+// already equals the given one, it is removed from the tree and returned.
+// Otherwise, nil is returned.
+//
+// nil cannot be added to the tree (will panic).
+func (t *bTree) ReplaceOrInsert(item interface{}) interface{} {
+	if item == nil {
+		panic("nil item being added to BTree")
 	}
+	out, _ := t.BTree.ReplaceOrInsert(item)
+	return out
+}
+
+func (t *bTree) Delete(item interface{}) interface{} {
+	out, _ := t.BTree.Delete(item)
+	return out
+}
+
+func (t *bTree) DeleteMin() interface{} {
+	out, _ := t.BTree.DeleteMin()
+	return out
+}
+
+func (t *bTree) DeleteMax() interface{} {
+	out, _ := t.BTree.DeleteMax()
+	return out
+}
+
+func (t *bTree) AscendRange(greaterOrEqual, lessThan interface{}, iterator ItemIterator) {
+	t.iterate(ascend, optionalOrEmpty(greaterOrEqual), optionalOrEmpty(lessThan), true, ItemIteratorOf[interface{}](iterator))
+}
+
+func (t *bTree) AscendLessThan(pivot interface{}, iterator ItemIterator) {
+	t.iterate(ascend, empty[interface{}](), optionalOrEmpty(pivot), false, ItemIteratorOf[interface{}](iterator))
+}
+
+func (t *bTree) AscendGreaterOrEqual(pivot interface{}, iterator ItemIterator) {
+	t.iterate(ascend, optionalOrEmpty(pivot), empty[interface{}](), true, ItemIteratorOf[interface{}](iterator))
+}
+
+func (t *bTree) Ascend(iterator ItemIterator) {
+	t.BTree.Ascend(ItemIteratorOf[interface{}](iterator))
+}
+
+func (t *bTree) DescendRange(lessOrEqual, greaterThan interface{}, iterator ItemIterator) {
+	t.iterate(descend, optionalOrEmpty(lessOrEqual), optionalOrEmpty(greaterThan), true, ItemIteratorOf[interface{}](iterator))
+}
+
+func (t *bTree) DescendLessOrEqual(pivot interface{}, iterator ItemIterator) {
+	t.iterate(descend, optionalOrEmpty(pivot), empty[interface{}](), true, ItemIteratorOf[interface{}](iterator))
+}
+
+func (t *bTree) DescendGreaterThan(pivot interface{}, iterator ItemIterator) {
+	t.iterate(descend, empty[interface{}](), optionalOrEmpty(pivot), false, ItemIteratorOf[interface{}](iterator))
+}
+
+func (t *bTree) Descend(iterator ItemIterator) {
+	t.BTree.Descend(ItemIteratorOf[interface{}](iterator))
+}
+
+func (t *bTree) AllRange(greaterOrEqual, lessThan interface{}) iter.Seq[interface{}] {
+	return t.seq(ascend, optionalOrEmpty(greaterOrEqual), optionalOrEmpty(lessThan), true)
+}
+
+func (t *bTree) AllLessThan(pivot interface{}) iter.Seq[interface{}] {
+	return t.seq(ascend, empty[interface{}](), optionalOrEmpty(pivot), false)
+}
+
+func (t *bTree) AllGreaterOrEqual(pivot interface{}) iter.Seq[interface{}] {
+	return t.seq(ascend, optionalOrEmpty(pivot), empty[interface{}](), true)
+}
+
+func (t *bTree) BackwardRange(lessOrEqual, greaterThan interface{}) iter.Seq[interface{}] {
+	return t.seq(descend, optionalOrEmpty(lessOrEqual), optionalOrEmpty(greaterThan), true)
+}
+
+func (t *bTree) BackwardLessOrEqual(pivot interface{}) iter.Seq[interface{}] {
+	return t.seq(descend, optionalOrEmpty(pivot), empty[interface{}](), true)
+}
+
+func (t *bTree) BackwardGreaterThan(pivot interface{}) iter.Seq[interface{}] {
+	return t.seq(descend, empty[interface{}](), optionalOrEmpty(pivot), false)
+}
+
+func (t *bTree) Get(key interface{}) interface{} {
+	out, _ := t.BTree.Get(key)
+	return out
+}
+
+func (t *bTree) Min() interface{} {
+	out, _ := t.BTree.Min()
+	return out
+}
+
+func (t *bTree) Max() interface{} {
+	out, _ := t.BTree.Max()
+	return out
+}
+
+func (t *bTree) Has(key interface{}) bool {
+	return t.BTree.Has(key)
 }
@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"math/rand"
 	"reflect"
+	"slices"
 	"sort"
 	"sync"
 	"testing"
@@ -159,6 +160,106 @@ func ExampleBTree() {
 	// len:        8
 }
 
+func TestBTreeOf(t *testing.T) {
+	tr := btree.NewOf[int](*btreeDegree, func(a, b int) int { return a - b })
+	const treeSize = 10000
+	for i := 0; i < 10; i++ {
+		if _, ok := tr.Min(); ok {
+			t.Fatal("empty min should not be found")
+		}
+		if _, ok := tr.Max(); ok {
+			t.Fatal("empty max should not be found")
+		}
+		for _, item := range rand.Perm(treeSize) {
+			if _, ok := tr.ReplaceOrInsert(item); ok {
+				t.Fatal("insert found item", item)
+			}
+		}
+		for _, item := range rand.Perm(treeSize) {
+			if _, ok := tr.ReplaceOrInsert(item); !ok {
+				t.Fatal("insert didn't find item", item)
+			}
+		}
+		if min, _ := tr.Min(); min != 0 {
+			t.Fatalf("min: want 0, got %d", min)
+		}
+		if max, _ := tr.Max(); max != treeSize-1 {
+			t.Fatalf("max: want %d, got %d", treeSize-1, max)
+		}
+		want := 0
+		tr.Ascend(func(item int) bool {
+			if item != want {
+				t.Fatalf("mismatch: got %d, want %d", item, want)
+			}
+			want++
+			return true
+		})
+		// 0 is a valid item, it must be distinguished from a missing one.
+		if !tr.Has(0) {
+			t.Fatal("the item 0 should be found")
+		}
+		for _, item := range rand.Perm(treeSize) {
+			if _, ok := tr.Delete(item); !ok {
+				t.Fatalf("didn't find %v", item)
+			}
+		}
+		if tr.Size() != 0 {
+			t.Fatalf("some left!: %d", tr.Size())
+		}
+	}
+}
+
+func TestAll(t *testing.T) {
+	tr := btree.New(2)
+	for _, v := range perm(100) {
+		tr.ReplaceOrInsert(v)
+	}
+
+	if got := slices.Collect(tr.All()); !reflect.DeepEqual(got, rang(100)) {
+		t.Fatalf("ascending mismatch:\n got: %v\nwant: %v", got, rang(100))
+	}
+	if got := slices.Collect(tr.Backward()); !reflect.DeepEqual(got, rangrev(100)) {
+		t.Fatalf("descending mismatch:\n got: %v\nwant: %v", got, rangrev(100))
+	}
+
+	got := []interface{}{}
+	for item := range tr.AllRange(40, 60) {
+		got = append(got, item)
+		if item == 45 {
+			break
+		}
+	}
+	if want := rang(100)[40:46]; !reflect.DeepEqual(got, want) {
+		t.Fatalf("range mismatch:\n got: %v\nwant: %v", got, want)
+	}
+
+	tests := []struct {
+		name string
+		seq  func() []interface{}
+		want []interface{}
+	}{
+		{"AllRange with nil bounds", func() []interface{} { return slices.Collect(tr.AllRange(nil, nil)) }, rang(100)},
+		{"AllLessThan", func() []interface{} { return slices.Collect(tr.AllLessThan(3)) }, []interface{}{0, 1, 2}},
+		{"AllGreaterOrEqual", func() []interface{} { return slices.Collect(tr.AllGreaterOrEqual(97)) }, []interface{}{97, 98, 99}},
+		{"BackwardRange", func() []interface{} { return slices.Collect(tr.BackwardRange(60, 57)) }, []interface{}{60, 59, 58}},
+		{"BackwardLessOrEqual", func() []interface{} { return slices.Collect(tr.BackwardLessOrEqual(2)) }, []interface{}{2, 1, 0}},
+		{"BackwardGreaterThan", func() []interface{} { return slices.Collect(tr.BackwardGreaterThan(96)) }, []interface{}{99, 98, 97}},
+	}
+	for _, tt := range tests {
+		if got := tt.seq(); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s mismatch:\n got: %v\nwant: %v", tt.name, got, tt.want)
+		}
+	}
+
+	trOf := btree.NewOf[int](2, nil)
+	for _, v := range rand.Perm(100) {
+		trOf.ReplaceOrInsert(v)
+	}
+	if got := slices.Collect(trOf.AllRange(0, 3)); !slices.Equal(got, []int{0, 1, 2}) {
+		t.Fatalf("range mismatch:\n got: %v\nwant: [0 1 2]", got)
+	}
+}
+
 func TestDeleteMin(t *testing.T) {
 	tr := btree.New(3)
 	for _, v := range perm(100) {
@@ -773,6 +874,21 @@ func BenchmarkDeleteAndRestore(b *testing.B) {
 			}
 		}
 	})
+	b.Run(`ClearAndRecycleBigFreelist`, func(b *testing.B) {
+		fl := btree.NewFreeList(16392)
+		tr := btree.NewWithFreeList(*btreeDegree, fl)
+		for _, v := range items {
+			tr.ReplaceOrInsert(v)
+		}
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			tr.ClearAndRecycle()
+			for _, v := range items {
+				tr.ReplaceOrInsert(v)
+			}
+		}
+	})
 	b.Run(`Clear`, func(b *testing.B) {
 		tr := btree.New(*btreeDegree)
 		for _, v := range items {
@@ -0,0 +1,232 @@
+// Copyright (c) 2019, Benjamin Wang (benjamin_wang@aliyun.com). All rights reserved.
+// Licensed under the MIT license that can be found in the LICENSE file.
+
+package btree
+
+import (
+	"errors"
+	"iter"
+
+	"github.com/ahrtr/gocontainer/utils"
+)
+
+// ErrUnsorted is returned when the items being bulk loaded into a btree aren't in strictly ascending order.
+var ErrUnsorted = errors.New("btree: items aren't in strictly ascending order")
+
+// BuilderOf bulk loads items, which must be added in strictly ascending order, into a new BTree[T].
+// It constructs the tree bottom-up with packed nodes, which takes O(n) time instead of the
+// O(n log n) taken by calling ReplaceOrInsert repeatedly.
+type BuilderOf[T any] struct {
+	degree   int
+	cmp      func(a, b T) int
+	freelist *FreeListOf[T]
+	validate bool
+	items    []T
+}
+
+// NewBuilderOf creates a builder for a btree with the given degree, whose items are of type T and
+// ordered by cmp. If cmp is nil, then the items are ordered according to their natural ordering.
+// By default, the builder validates the ordering of the items, and the btree uses its own free list.
+func NewBuilderOf[T any](degree int, cmp func(a, b T) int) *BuilderOf[T] {
+	if degree <= 1 {
+		panic("bad degree")
+	}
+	if cmp == nil {
+		cmp = utils.CompareFunc[T](nil)
+	}
+	return &BuilderOf[T]{
+		degree:   degree,
+		cmp:      cmp,
+		validate: true,
+	}
+}
+
+// WithFreeList sets the node free list used by the btree, so that the nodes of the
+// btree are taken from the free list if any.
+func (b *BuilderOf[T]) WithFreeList(f *FreeListOf[T]) *BuilderOf[T] {
+	b.freelist = f
+	return b
+}
+
+// WithValidation sets whether the builder validates the ordering of the items. If the
+// ordering isn't validated, it's the caller's responsibility to add the items in strictly
+// ascending order, otherwise the btree is corrupted.
+func (b *BuilderOf[T]) WithValidation(validate bool) *BuilderOf[T] {
+	b.validate = validate
+	return b
+}
+
+// Add adds the items to the builder. If the ordering is validated, then it returns ErrUnsorted
+// on the first item which isn't greater than the previous one, and the item and those after it are discarded.
+func (b *BuilderOf[T]) Add(items ...T) error {
+	for _, item := range items {
+		if err := b.add(item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AddSeq adds all the items produced by seq to the builder. If the ordering is validated, then it stops
+// and returns ErrUnsorted on the first item which isn't greater than the previous one.
+func (b *BuilderOf[T]) AddSeq(seq iter.Seq[T]) error {
+	for item := range seq {
+		if err := b.add(item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (b *BuilderOf[T]) add(item T) error {
+	if b.validate && len(b.items) > 0 && b.cmp(b.items[len(b.items)-1], item) >= 0 {
+		return ErrUnsorted
+	}
+	b.items = append(b.items, item)
+	return nil
+}
+
+// Size returns the number of items added to the builder.
+func (b *BuilderOf[T]) Size() int {
+	return len(b.items)
+}
+
+// Build returns a btree containing all the items added to the builder, and resets the builder
+// so that it can be used to build another btree.
+func (b *BuilderOf[T]) Build() *BTree[T] {
+	items := b.items
+	b.items = nil
+	return b.build(items)
+}
+
+// build constructs the btree from the sorted items.
+func (b *BuilderOf[T]) build(items []T) *BTree[T] {
+	f := b.freelist
+	if f == nil {
+		f = NewFreeListOf[T](DefaultFreeListSize)
+	}
+	t := NewWithFreeListOf(b.degree, b.cmp, f)
+	t.root, t.length = t.build(items).root, len(items)
+	return t
+}
+
+// build constructs a subtree from the sorted items, whose nodes are owned by t.
+func (t *BTree[T]) build(items []T) subtree[T] {
+	if len(items) == 0 {
+		return subtree[T]{}
+	}
+	// Find the minimal height of the tree. A full subtree whose height is h
+	// (the height of a leaf is 0) contains (2*degree)^(h+1) - 1 items.
+	fanout := 2 * t.degree
+	height, capacity := 0, fanout
+	for capacity-1 < len(items) {
+		height++
+		capacity *= fanout
+	}
+	return subtree[T]{t.buildNode(items, height, capacity/fanout, 2), height}
+}
+
+// buildNode constructs a subtree of the given height containing all the given items. capacity is
+// (2*degree)^height, which is the max number of items in a child subtree plus one, and minChildren
+// is the min number of children of the node if it isn't a leaf.
+//
+// The items are evenly distributed among the minimal number of children, so that the nodes are packed
+// while none of them contains fewer than minItems items.
+func (t *BTree[T]) buildNode(items []T, height, capacity, minChildren int) *node[T] {
+	n := t.cow.newNode()
+	n.size = len(items)
+	if height == 0 {
+		n.items = append(n.items, items...)
+		return n
+	}
+
+	count := (len(items) + capacity) / capacity // ceil((len(items)+1) / capacity)
+	if count < minChildren {
+		count = minChildren
+	}
+	childItems := len(items) + 1 - count
+	start := 0
+	for i := 0; i < count; i++ {
+		k := childItems / count
+		if i < childItems%count {
+			k++
+		}
+		n.children = append(n.children, t.buildNode(items[start:start+k], height-1, capacity/(2*t.degree), t.degree))
+		start += k
+		if i < count-1 {
+			n.items = append(n.items, items[start])
+			start++
+		}
+	}
+	return n
+}
+
+// BuildFromSortedOf creates a btree with the given degree containing the items, which must be in strictly
+// ascending order according to cmp. If cmp is nil, then the items are ordered according to their natural ordering.
+// It returns ErrUnsorted if the items aren't in strictly ascending order.
+func BuildFromSortedOf[T any](degree int, items []T, cmp func(a, b T) int) (*BTree[T], error) {
+	b := NewBuilderOf(degree, cmp)
+	for i := 1; i < len(items); i++ {
+		if b.cmp(items[i-1], items[i]) >= 0 {
+			return nil, ErrUnsorted
+		}
+	}
+	return b.build(items), nil
+}
+
+// BuildFromSeqOf creates a btree with the given degree containing all the items produced by seq, which must
+// be in strictly ascending order according to cmp. If cmp is nil, then the items are ordered according to
+// their natural ordering. It returns ErrUnsorted if the items aren't in strictly ascending order.
+func BuildFromSeqOf[T any](degree int, seq iter.Seq[T], cmp func(a, b T) int) (*BTree[T], error) {
+	b := NewBuilderOf(degree, cmp)
+	if err := b.AddSeq(seq); err != nil {
+		return nil, err
+	}
+	return b.Build(), nil
+}
+
+// BuildFromSorted creates a btree with the given degree containing the items, which must be in strictly
+// ascending order according to the comparator c. If c is nil, then the items are ordered according to their
+// natural ordering. It returns ErrUnsorted if the items aren't in strictly ascending order.
+//
+// nil cannot be added to the tree (will panic).
+func BuildFromSorted(degree int, items []interface{}, c utils.Comparator) (Interface, error) {
+	return BuildFromSortedWithFreeList(degree, items, c, NewFreeList(DefaultFreeListSize))
+}
+
+// BuildFromSortedWithFreeList is the same as BuildFromSorted, but the btree uses the given node free list.
+func BuildFromSortedWithFreeList(degree int, items []interface{}, c utils.Comparator, f *FreeList) (Interface, error) {
+	b := NewBuilderOf(degree, utils.CompareFunc[interface{}](c)).WithFreeList(f)
+	for i, item := range items {
+		if item == nil {
+			panic("nil item being added to BTree")
+		}
+		if i > 0 && b.cmp(items[i-1], item) >= 0 {
+			return nil, ErrUnsorted
+		}
+	}
+	return &bTree{b.build(items)}, nil
+}
+
+// BuildFromSeq creates a btree with the given degree containing all the items produced by seq, which must be
+// in strictly ascending order according to the comparator c. If c is nil, then the items are ordered according
+// to their natural ordering. It returns ErrUnsorted if the items aren't in strictly ascending order.
+//
+// nil cannot be added to the tree (will panic).
+func BuildFromSeq(degree int, seq iter.Seq[interface{}], c utils.Comparator) (Interface, error) {
+	return BuildFromSeqWithFreeList(degree, seq, c, NewFreeList(DefaultFreeListSize))
+}
+
+// BuildFromSeqWithFreeList is the same as BuildFromSeq, but the btree uses the given node free list.
+func BuildFromSeqWithFreeList(degree int, seq iter.Seq[interface{}], c utils.Comparator, f *FreeList) (Interface, error) {
+	b := NewBuilderOf(degree, utils.CompareFunc[interface{}](c)).WithFreeList(f)
+	for item := range seq {
+		if item == nil {
+			panic("nil item being added to BTree")
+		}
+		if err := b.add(item); err != nil {
+			return nil, err
+		}
+	}
+	return &bTree{b.Build()}, nil
+}
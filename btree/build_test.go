@@ -0,0 +1,194 @@
+// Copyright (c) 2019, Benjamin Wang (benjamin_wang@aliyun.com). All rights reserved.
+// Licensed under the MIT license that can be found in the LICENSE file.
+
+package btree_test
+
+import (
+	"errors"
+	"math/rand"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/ahrtr/gocontainer/btree"
+)
+
+func TestBuildFromSortedOf(t *testing.T) {
+	for _, degree := range []int{2, 3, 4, *btreeDegree} {
+		for _, n := range []int{0, 1, 2, 3, 4, 5, 7, 8, 15, 16, 17, 63, 64, 65, 100, 255, 256, 1000, 4097} {
+			var items []int
+			for i := 0; i < n; i++ {
+				items = append(items, 2*i)
+			}
+			tr, err := btree.BuildFromSortedOf(degree, items, nil)
+			if err != nil {
+				t.Fatalf("degree %d, n %d: unexpected error: %v", degree, n, err)
+			}
+			checkOrder(t, tr, items)
+			if got := slices.Collect(tr.Backward()); !slices.Equal(got, reverse(items)) {
+				t.Fatalf("degree %d, n %d: backward mismatch: %v", degree, n, got)
+			}
+
+			// The built tree must be usable as usual.
+			for i := 0; i < n; i++ {
+				tr.ReplaceOrInsert(2*i + 1)
+			}
+			for i := 0; i < 2*n; i++ {
+				if v, ok := tr.Select(i); !ok || v != i {
+					t.Fatalf("degree %d, n %d: Select(%d) after insertion: got (%d, %t)", degree, n, i, v, ok)
+				}
+			}
+			for _, v := range rand.Perm(2 * n) {
+				if _, ok := tr.Delete(v); !ok {
+					t.Fatalf("degree %d, n %d: failed to delete %d", degree, n, v)
+				}
+			}
+			if tr.Size() != 0 {
+				t.Fatalf("degree %d, n %d: size after deleting all: %d", degree, n, tr.Size())
+			}
+		}
+	}
+}
+
+func TestBuildFromSortedOfUnsorted(t *testing.T) {
+	for _, items := range [][]int{{1, 3, 2}, {1, 2, 2, 3}, {3, 2}} {
+		if _, err := btree.BuildFromSortedOf(2, items, nil); !errors.Is(err, btree.ErrUnsorted) {
+			t.Fatalf("%v: want ErrUnsorted, got %v", items, err)
+		}
+	}
+	// Descending items are sorted according to a reversed comparator.
+	tr, err := btree.BuildFromSortedOf(2, []int{5, 4, 3, 2, 1}, func(a, b int) int { return b - a })
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := slices.Collect(tr.All()); !reflect.DeepEqual(got, []int{5, 4, 3, 2, 1}) {
+		t.Fatalf("unexpected items: %v", got)
+	}
+}
+
+func TestBuildFromSeqOf(t *testing.T) {
+	src := btree.NewOf[int](3, nil)
+	for _, v := range rand.Perm(500) {
+		src.ReplaceOrInsert(v)
+	}
+	tr, err := btree.BuildFromSeqOf(4, src.All(), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	checkOrder(t, tr, slices.Collect(src.All()))
+
+	if _, err := btree.BuildFromSeqOf(4, src.Backward(), nil); !errors.Is(err, btree.ErrUnsorted) {
+		t.Fatalf("want ErrUnsorted, got %v", err)
+	}
+}
+
+func TestBuilderOf(t *testing.T) {
+	f := btree.NewFreeListOf[int](btree.DefaultFreeListSize)
+	b := btree.NewBuilderOf[int](2, nil).WithFreeList(f)
+	if err := b.Add(1, 2, 3); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := b.Add(3); !errors.Is(err, btree.ErrUnsorted) {
+		t.Fatalf("want ErrUnsorted, got %v", err)
+	}
+	if err := b.Add(4, 5); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if b.Size() != 5 {
+		t.Fatalf("expected 5 items, got %d", b.Size())
+	}
+	tr := b.Build()
+	checkOrder(t, tr, []int{1, 2, 3, 4, 5})
+	if b.Size() != 0 {
+		t.Fatalf("the builder should be reset by Build, got %d items", b.Size())
+	}
+
+	// The new tree shares the free list, so that it can reuse the nodes freed by the old one.
+	for tr.Size() > 0 {
+		tr.DeleteMin()
+	}
+	if err := b.AddSeq(slices.Values([]int{10, 20, 30})); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	checkOrder(t, b.Build(), []int{10, 20, 30})
+
+	// Without validation, the ordering is the caller's responsibility.
+	b.WithValidation(false)
+	if err := b.Add(3, 2, 1); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if b.Size() != 3 {
+		t.Fatalf("expected 3 items, got %d", b.Size())
+	}
+}
+
+func TestBuildFromSorted(t *testing.T) {
+	tr, err := btree.BuildFromSorted(*btreeDegree, rang(1000), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := all(tr); !reflect.DeepEqual(got, rang(1000)) {
+		t.Fatalf("mismatch:\n got: %v\nwant: %v", got, rang(1000))
+	}
+	tr.ReplaceOrInsert(1000)
+	if tr.Size() != 1001 || tr.Max() != 1000 {
+		t.Fatalf("unexpected tree after insertion: size %d, max %v", tr.Size(), tr.Max())
+	}
+
+	if _, err := btree.BuildFromSorted(*btreeDegree, rangrev(10), nil); !errors.Is(err, btree.ErrUnsorted) {
+		t.Fatalf("want ErrUnsorted, got %v", err)
+	}
+
+	tr, err = btree.BuildFromSeq(2, slices.Values(rang(100)), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := all(tr); !reflect.DeepEqual(got, rang(100)) {
+		t.Fatalf("mismatch:\n got: %v\nwant: %v", got, rang(100))
+	}
+}
+
+func TestBuildWithFreeList(t *testing.T) {
+	f := btree.NewFreeList(btree.DefaultFreeListSize)
+	tr := btree.NewWithFreeList(*btreeDegree, f)
+	for _, v := range perm(1000) {
+		tr.ReplaceOrInsert(v)
+	}
+	tr.ClearAndRecycle()
+	recycled := f.Stats().Size
+
+	// The built trees take their nodes from the free list which the old tree recycled its nodes into.
+	tr, err := btree.BuildFromSortedWithFreeList(*btreeDegree, rang(1000), nil, f)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := all(tr); !reflect.DeepEqual(got, rang(1000)) {
+		t.Fatalf("mismatch:\n got: %v\nwant: %v", got, rang(1000))
+	}
+	if stats := f.Stats(); stats.Reused == 0 || stats.Size >= recycled {
+		t.Fatalf("expected the nodes to be reused from the free list, got %+v", stats)
+	}
+
+	tr.ClearAndRecycle()
+	reused := f.Stats().Reused
+	tr, err = btree.BuildFromSeqWithFreeList(*btreeDegree, slices.Values(rang(1000)), nil, f)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := all(tr); !reflect.DeepEqual(got, rang(1000)) {
+		t.Fatalf("mismatch:\n got: %v\nwant: %v", got, rang(1000))
+	}
+	if f.Stats().Reused == reused {
+		t.Fatalf("expected the nodes to be reused from the free list, got %+v", f.Stats())
+	}
+
+	if _, err := btree.BuildFromSortedWithFreeList(*btreeDegree, rangrev(10), nil, f); !errors.Is(err, btree.ErrUnsorted) {
+		t.Fatalf("want ErrUnsorted, got %v", err)
+	}
+}
+
+func reverse(items []int) []int {
+	out := slices.Clone(items)
+	slices.Reverse(out)
+	return out
+}
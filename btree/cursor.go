@@ -0,0 +1,241 @@
+// Copyright (c) 2019, Benjamin Wang (benjamin_wang@aliyun.com). All rights reserved.
+// Licensed under the MIT license that can be found in the LICENSE file.
+
+package btree
+
+import "errors"
+
+// ErrReadOnlyCursor is used as the panic value when Delete is called on a read-only cursor.
+var ErrReadOnlyCursor = errors.New("btree: read-only cursor")
+
+// CursorOf is a cursor over a BTree[T], which can be moved in both directions, and paused and resumed
+// at any time. It's created by BTree.Cursor, and isn't positioned at any item until First, Last or Seek is called.
+// Once the cursor moves past either end of the tree, it becomes invalid and needs to be repositioned.
+//
+// The cursor remembers the path from the root to its current item, so moving to the adjacent item is
+// amortized O(1). The cursor is bound to the tree which created it. If the tree is modified other than
+// through the cursor, the cursor stays at the same position in the key space: Item still returns the item
+// at which the cursor was positioned, and Next and Prev move to the items after and before it in the
+// modified tree, which takes O(log n) for the first move. Clone doesn't affect the cursor, and neither do the
+// modifications to the clone, since the original tree and the clone don't share any modifications.
+//
+// Like the tree, a cursor isn't safe for concurrent use if the tree is being modified.
+type CursorOf[T any] struct {
+	t     *BTree[T]
+	stack []cursorFrame[T]
+	item  T
+	valid bool
+	// version is the version of the tree when the stack was built.
+	version int
+	// readOnly is true if the cursor can't be used to modify the tree.
+	readOnly bool
+}
+
+// Cursor is a cursor over the btrees created by New and NewWithFreeList.
+type Cursor = CursorOf[interface{}]
+
+// cursorFrame is a node on the path from the root to the current item of a cursor. For the last frame,
+// i is the index of the current item in the node. For the other frames, it's the index of the child
+// which the path descends into.
+type cursorFrame[T any] struct {
+	n *node[T]
+	i int
+}
+
+// Cursor returns a new cursor over the tree, which isn't positioned at any item
+// until First, Last or Seek is called.
+func (t *BTree[T]) Cursor() *CursorOf[T] {
+	return &CursorOf[T]{t: t}
+}
+
+// ReadOnly makes the cursor read-only, so that Delete panics with ErrReadOnlyCursor, and returns the cursor.
+// It's useful when the tree is a snapshot, whose modifications would be silently lost.
+func (c *CursorOf[T]) ReadOnly() *CursorOf[T] {
+	c.readOnly = true
+	return c
+}
+
+// Valid returns true if the cursor is positioned at an item.
+func (c *CursorOf[T]) Valid() bool {
+	return c.valid
+}
+
+// Item returns the item at which the cursor is positioned, or the zero value if the cursor is invalid.
+func (c *CursorOf[T]) Item() (_ T) {
+	if !c.valid {
+		return
+	}
+	return c.item
+}
+
+// First moves the cursor to the smallest item in the tree. It returns false if the tree is empty.
+func (c *CursorOf[T]) First() bool {
+	c.stack = c.stack[:0]
+	if c.t.root != nil {
+		c.pushFirst(c.t.root)
+	}
+	return c.settle(c.forward())
+}
+
+// Last moves the cursor to the largest item in the tree. It returns false if the tree is empty.
+func (c *CursorOf[T]) Last() bool {
+	c.stack = c.stack[:0]
+	if c.t.root != nil {
+		c.pushLast(c.t.root)
+	}
+	return c.settle(c.backward())
+}
+
+// Seek moves the cursor to the smallest item in the tree which is greater than or equal to the key.
+// It returns false if there isn't such an item.
+func (c *CursorOf[T]) Seek(key T) bool {
+	c.seek(key)
+	return c.settle(c.forward())
+}
+
+// Next moves the cursor to the next item in ascending order. It returns false if there isn't
+// a next item, and the cursor becomes invalid.
+func (c *CursorOf[T]) Next() bool {
+	if !c.valid {
+		return false
+	}
+	if c.version != c.t.version && !c.seek(c.item) {
+		// The current item has been removed, and the cursor is now at the next item, if any.
+		return c.settle(c.forward())
+	}
+	top := &c.stack[len(c.stack)-1]
+	top.i++
+	if len(top.n.children) > 0 {
+		c.pushFirst(top.n.children[top.i])
+	}
+	return c.settle(c.forward())
+}
+
+// Prev moves the cursor to the previous item in ascending order. It returns false if there isn't
+// a previous item, and the cursor becomes invalid.
+func (c *CursorOf[T]) Prev() bool {
+	if !c.valid {
+		return false
+	}
+	if c.version != c.t.version && !c.seek(c.item) && !c.forward() {
+		// All the items are less than the current item.
+		return c.Last()
+	}
+	top := &c.stack[len(c.stack)-1]
+	if len(top.n.children) > 0 {
+		c.pushLast(top.n.children[top.i])
+	} else {
+		top.i--
+	}
+	return c.settle(c.backward())
+}
+
+// Delete removes the item at which the cursor is positioned from the tree, and moves the cursor to
+// the next item in ascending order, so that the iteration can continue without calling Next:
+//   for c.First(); c.Valid(); {
+//       if shouldDelete(c.Item()) {
+//           c.Delete()
+//       } else {
+//           c.Next()
+//       }
+//   }
+// It returns false if the cursor is invalid or the item has already been removed from the tree.
+// It panics with ErrReadOnlyCursor if the cursor is read-only.
+func (c *CursorOf[T]) Delete() bool {
+	if c.readOnly {
+		panic(ErrReadOnlyCursor)
+	}
+	if !c.valid {
+		return false
+	}
+	_, ok := c.t.Delete(c.item)
+	c.seek(c.item)
+	c.settle(c.forward())
+	return ok
+}
+
+// seek rebuilds the path to the smallest item which is greater than or equal to the key, and returns
+// true if the item equals the key. The last frame may be past the end of a leaf, see forward.
+func (c *CursorOf[T]) seek(key T) bool {
+	c.stack = c.stack[:0]
+	n := c.t.root
+	for n != nil {
+		i, found := n.items.find(key, c.t.cmp)
+		c.stack = append(c.stack, cursorFrame[T]{n, i})
+		if found {
+			return true
+		}
+		if len(n.children) == 0 {
+			break
+		}
+		n = n.children[i]
+	}
+	return false
+}
+
+// pushFirst pushes the path from n to the smallest item in the subtree rooted at n.
+func (c *CursorOf[T]) pushFirst(n *node[T]) {
+	for {
+		c.stack = append(c.stack, cursorFrame[T]{n, 0})
+		if len(n.children) == 0 {
+			return
+		}
+		n = n.children[0]
+	}
+}
+
+// pushLast pushes the path from n to the largest item in the subtree rooted at n.
+func (c *CursorOf[T]) pushLast(n *node[T]) {
+	for {
+		if len(n.children) == 0 {
+			c.stack = append(c.stack, cursorFrame[T]{n, len(n.items) - 1})
+			return
+		}
+		c.stack = append(c.stack, cursorFrame[T]{n, len(n.items)})
+		n = n.children[len(n.items)]
+	}
+}
+
+// forward is called when the last frame may be past the end of its node, it pops the frames until
+// the path reaches the next item in ascending order, and returns false if there isn't such an item.
+func (c *CursorOf[T]) forward() bool {
+	for len(c.stack) > 0 {
+		top := c.stack[len(c.stack)-1]
+		if top.i < len(top.n.items) {
+			return true
+		}
+		c.stack = c.stack[:len(c.stack)-1]
+	}
+	return false
+}
+
+// backward is called when the last frame may be before the beginning of its node, it pops the frames
+// until the path reaches the previous item in ascending order, and returns false if there isn't such an item.
+func (c *CursorOf[T]) backward() bool {
+	for len(c.stack) > 0 {
+		if c.stack[len(c.stack)-1].i >= 0 {
+			return true
+		}
+		c.stack = c.stack[:len(c.stack)-1]
+		if len(c.stack) > 0 {
+			// The parent's item before the child which the path descended into.
+			c.stack[len(c.stack)-1].i--
+		}
+	}
+	return false
+}
+
+// settle updates the state of the cursor after the path has been moved.
+func (c *CursorOf[T]) settle(valid bool) bool {
+	c.valid = valid
+	if !valid {
+		var zero T
+		c.item = zero
+		c.stack = c.stack[:0]
+		return false
+	}
+	top := c.stack[len(c.stack)-1]
+	c.item = top.n.items[top.i]
+	c.version = c.t.version
+	return true
+}
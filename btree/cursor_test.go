@@ -0,0 +1,257 @@
+// Copyright (c) 2019, Benjamin Wang (benjamin_wang@aliyun.com). All rights reserved.
+// Licensed under the MIT license that can be found in the LICENSE file.
+
+package btree_test
+
+import (
+	"math/rand"
+	"reflect"
+	"slices"
+	"sort"
+	"testing"
+
+	"github.com/ahrtr/gocontainer/btree"
+)
+
+func TestCursor(t *testing.T) {
+	for _, degree := range []int{2, 3, *btreeDegree} {
+		tr := btree.NewOf[int](degree, nil)
+		c := tr.Cursor()
+		if c.Valid() || c.First() || c.Last() || c.Seek(0) || c.Next() || c.Prev() || c.Delete() {
+			t.Fatal("a cursor over an empty tree should be invalid")
+		}
+
+		var sorted []int
+		for _, v := range rand.Perm(1000) {
+			tr.ReplaceOrInsert(2 * v)
+		}
+		for v := 0; v < 1000; v++ {
+			sorted = append(sorted, 2*v)
+		}
+
+		var got []int
+		for ok := c.First(); ok; ok = c.Next() {
+			got = append(got, c.Item())
+		}
+		if !reflect.DeepEqual(got, sorted) {
+			t.Fatalf("degree %d: forward mismatch:\n got: %v\nwant: %v", degree, got, sorted)
+		}
+		if c.Valid() || c.Next() || c.Prev() || c.Item() != 0 {
+			t.Fatal("the cursor should be invalid after moving past the end")
+		}
+
+		got = got[:0]
+		for ok := c.Last(); ok; ok = c.Prev() {
+			got = append(got, c.Item())
+		}
+		if !reflect.DeepEqual(got, reverse(sorted)) {
+			t.Fatalf("degree %d: backward mismatch:\n got: %v", degree, got)
+		}
+
+		// Seek to the existing and missing keys.
+		for _, key := range []int{-1, 0, 1, 100, 101, 1997, 1998, 1999} {
+			i := sort.SearchInts(sorted, key)
+			if ok := c.Seek(key); ok != (i < len(sorted)) {
+				t.Fatalf("Seek(%d): unexpected result %t", key, ok)
+			}
+			if i < len(sorted) && c.Item() != sorted[i] {
+				t.Fatalf("Seek(%d): want %d, got %d", key, sorted[i], c.Item())
+			}
+		}
+
+		// Random walk in both directions.
+		i := rand.Intn(len(sorted))
+		c.Seek(sorted[i])
+		for step := 0; step < 5000; step++ {
+			if rand.Intn(2) == 0 {
+				if ok := c.Next(); ok != (i+1 < len(sorted)) {
+					t.Fatalf("degree %d: Next at %d: unexpected result %t", degree, sorted[i], ok)
+				}
+				i++
+			} else {
+				if ok := c.Prev(); ok != (i > 0) {
+					t.Fatalf("degree %d: Prev at %d: unexpected result %t", degree, sorted[i], ok)
+				}
+				i--
+			}
+			if i < 0 || i >= len(sorted) {
+				i = rand.Intn(len(sorted))
+				c.Seek(sorted[i])
+			}
+			if c.Item() != sorted[i] {
+				t.Fatalf("degree %d: want %d, got %d", degree, sorted[i], c.Item())
+			}
+		}
+	}
+}
+
+func TestCursorDelete(t *testing.T) {
+	tr := btree.NewOf[int](2, nil)
+	for _, v := range rand.Perm(1000) {
+		tr.ReplaceOrInsert(v)
+	}
+
+	// Remove the multiples of 3 while iterating.
+	c := tr.Cursor()
+	var visited []int
+	for c.First(); c.Valid(); {
+		visited = append(visited, c.Item())
+		if c.Item()%3 == 0 {
+			if !c.Delete() {
+				t.Fatalf("failed to delete %d", c.Item())
+			}
+		} else {
+			c.Next()
+		}
+	}
+	if len(visited) != 1000 {
+		t.Fatalf("expected to visit 1000 items, got %d", len(visited))
+	}
+	var want []int
+	for v := 0; v < 1000; v++ {
+		if v%3 != 0 {
+			want = append(want, v)
+		}
+	}
+	if got := slices.Collect(tr.All()); !reflect.DeepEqual(got, want) {
+		t.Fatalf("mismatch:\n got: %v\nwant: %v", got, want)
+	}
+
+	// Delete the last item.
+	c.Last()
+	if !c.Delete() || c.Valid() {
+		t.Fatal("the cursor should be invalid after deleting the last item")
+	}
+	if v, _ := tr.Max(); v != 997 {
+		t.Fatalf("expected max 997, got %d", v)
+	}
+}
+
+func TestCursorModifiedTree(t *testing.T) {
+	tr := btree.NewOf[int](2, nil)
+	for v := 0; v < 100; v += 10 {
+		tr.ReplaceOrInsert(v)
+	}
+	c := tr.Cursor()
+	c.Seek(50)
+
+	// Items inserted around the cursor are visible to it.
+	tr.ReplaceOrInsert(55)
+	tr.ReplaceOrInsert(45)
+	if !c.Next() || c.Item() != 55 {
+		t.Fatalf("expected 55, got %d", c.Item())
+	}
+	if !c.Prev() || c.Item() != 50 {
+		t.Fatalf("expected 50, got %d", c.Item())
+	}
+
+	// The current item is removed from the tree.
+	tr.Delete(50)
+	if c.Item() != 50 {
+		t.Fatalf("expected the removed item 50, got %d", c.Item())
+	}
+	if !c.Prev() || c.Item() != 45 {
+		t.Fatalf("expected 45, got %d", c.Item())
+	}
+	tr.Delete(45)
+	if !c.Next() || c.Item() != 55 {
+		t.Fatalf("expected 55, got %d", c.Item())
+	}
+	// 55 is removed, and the cursor moves to 60.
+	if !c.Delete() || c.Item() != 60 {
+		t.Fatalf("expected 60, got %d", c.Item())
+	}
+	tr.Delete(60)
+	if c.Delete() {
+		t.Fatal("60 has already been removed")
+	}
+	if c.Item() != 70 {
+		t.Fatalf("expected 70, got %d", c.Item())
+	}
+
+	// All the items after the cursor are removed.
+	tr.Delete(70)
+	tr.Delete(80)
+	tr.Delete(90)
+	if c.Next() {
+		t.Fatalf("unexpected item %d", c.Item())
+	}
+	c.Seek(40)
+	tr.Delete(40)
+	tr.Delete(30)
+	if !c.Prev() || c.Item() != 20 {
+		t.Fatalf("expected 20, got %d", c.Item())
+	}
+	c.Seek(20)
+	tr.Clear()
+	if c.Next() || c.Valid() {
+		t.Fatal("the cursor should be invalid after the tree is cleared")
+	}
+
+	// Prev from a position after all the items moves to the last item.
+	for v := 0; v < 10; v++ {
+		tr.ReplaceOrInsert(v)
+	}
+	c.Last()
+	tr.Delete(9)
+	if !c.Prev() || c.Item() != 8 {
+		t.Fatalf("expected 8, got %d", c.Item())
+	}
+}
+
+func TestCursorClone(t *testing.T) {
+	tr := btree.NewOf[int](2, nil)
+	for v := 0; v < 100; v++ {
+		tr.ReplaceOrInsert(v)
+	}
+	c := tr.Cursor()
+	c.Seek(50)
+	clone := tr.Clone()
+
+	// The modifications to the clone aren't visible to the cursor.
+	clone.Delete(51)
+	clone.ReplaceOrInsert(1000)
+	if !c.Next() || c.Item() != 51 {
+		t.Fatalf("expected 51, got %d", c.Item())
+	}
+
+	// Delete of a cursor over the clone doesn't affect the original tree.
+	cc := clone.Cursor()
+	for cc.First(); cc.Valid(); {
+		cc.Delete()
+	}
+	if clone.Size() != 0 || tr.Size() != 100 {
+		t.Fatalf("unexpected sizes: clone %d, tree %d", clone.Size(), tr.Size())
+	}
+	var got []int
+	for ok := c.Next(); ok; ok = c.Next() {
+		got = append(got, c.Item())
+	}
+	if len(got) != 48 || got[0] != 52 || got[47] != 99 {
+		t.Fatalf("unexpected items: %v", got)
+	}
+}
+
+func TestCursorInterface(t *testing.T) {
+	tr := btree.New(*btreeDegree)
+	for _, v := range perm(100) {
+		tr.ReplaceOrInsert(v)
+	}
+	// Pagination: the next 10 items after 42.
+	c := tr.Cursor()
+	var page []interface{}
+	for ok := c.Seek(43); ok && len(page) < 10; ok = c.Next() {
+		page = append(page, c.Item())
+	}
+	if want := rang(53)[43:]; !reflect.DeepEqual(page, want) {
+		t.Fatalf("mismatch:\n got: %v\nwant: %v", page, want)
+	}
+	c.Last()
+	if c.Item() != 99 {
+		t.Fatalf("expected 99, got %v", c.Item())
+	}
+	c.Next()
+	if c.Item() != nil {
+		t.Fatalf("expected nil, got %v", c.Item())
+	}
+}
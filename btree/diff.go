@@ -0,0 +1,184 @@
+// Copyright (c) 2019, Benjamin Wang (benjamin_wang@aliyun.com). All rights reserved.
+// Licensed under the MIT license that can be found in the LICENSE file.
+
+package btree
+
+import (
+	"reflect"
+
+	"github.com/ahrtr/gocontainer/utils"
+)
+
+// DiffOf compares the tree a with the tree b, which must be ordered by the same comparison function, and
+// reports how to turn a into b: onRemove is called with the items only in a, onAdd is called with the items
+// only in b, and onChange is called with the equal items of a and b whose values differ according to equal.
+// If equal is nil, reflect.DeepEqual is used. Any of the callbacks can be nil. The items are reported in
+// ascending order, and the trees must not be modified before DiffOf returns.
+//
+// The subtrees shared by both trees, which is the case for the nodes of a tree and its clone which haven't
+// been modified since Clone was called, are skipped without visiting their items. So comparing a snapshot
+// with the tree it's cloned from takes time proportional to the modifications, instead of the tree size.
+func DiffOf[T any](a, b *BTree[T], equal func(x, y T) bool, onAdd, onRemove func(item T), onChange func(old, new T)) {
+	if equal == nil {
+		equal = func(x, y T) bool { return reflect.DeepEqual(x, y) }
+	}
+	if onAdd == nil {
+		onAdd = func(T) {}
+	}
+	if onRemove == nil {
+		onRemove = func(T) {}
+	}
+	if onChange == nil {
+		onChange = func(T, T) {}
+	}
+
+	var sa, sb diffStack[T]
+	var cmp func(a, b T) int
+	if a != nil {
+		sa.push(a.root, heightOf(a.root))
+		cmp = a.cmp
+	}
+	if b != nil {
+		sb.push(b.root, heightOf(b.root))
+		cmp = b.cmp
+	}
+
+	for len(sa) > 0 && len(sb) > 0 {
+		fa, fb := sa[len(sa)-1], sb[len(sb)-1]
+		switch {
+		case fa.n != nil && fa.n == fb.n:
+			// The subtree is shared by both trees.
+			sa.pop()
+			sb.pop()
+		case fa.n != nil || fb.n != nil:
+			// Expand the higher subtree, or both if they are of the same height, so that a
+			// shared subtree is at the top of both stacks at the same time.
+			if fa.height() >= fb.height() {
+				sa.expand()
+			}
+			if fb.height() >= fa.height() {
+				sb.expand()
+			}
+		default:
+			switch c := cmp(fa.item, fb.item); {
+			case c < 0:
+				onRemove(fa.item)
+				sa.pop()
+			case c > 0:
+				onAdd(fb.item)
+				sb.pop()
+			default:
+				if !equal(fa.item, fb.item) {
+					onChange(fa.item, fb.item)
+				}
+				sa.pop()
+				sb.pop()
+			}
+		}
+	}
+	for item, ok := sa.next(); ok; item, ok = sa.next() {
+		onRemove(item)
+	}
+	for item, ok := sb.next(); ok; item, ok = sb.next() {
+		onAdd(item)
+	}
+}
+
+// diffFrame is either a subtree rooted at n whose height is h, or a single item if n is nil.
+type diffFrame[T any] struct {
+	n    *node[T]
+	h    int
+	item T
+}
+
+// height returns the height of the subtree, or -1 for an item.
+func (f diffFrame[T]) height() int {
+	if f.n == nil {
+		return -1
+	}
+	return f.h
+}
+
+// diffStack contains the subtrees and items of a tree which haven't been compared yet,
+// the top of the stack is the smallest one.
+type diffStack[T any] []diffFrame[T]
+
+func (s *diffStack[T]) push(n *node[T], h int) {
+	if n != nil {
+		*s = append(*s, diffFrame[T]{n: n, h: h})
+	}
+}
+
+func (s *diffStack[T]) pop() {
+	*s = (*s)[:len(*s)-1]
+}
+
+// expand replaces the subtree at the top of the stack with its children and items.
+func (s *diffStack[T]) expand() {
+	f := (*s)[len(*s)-1]
+	s.pop()
+	n := f.n
+	for i := len(n.items) - 1; i >= 0; i-- {
+		if len(n.children) > 0 {
+			s.push(n.children[i+1], f.h-1)
+		}
+		*s = append(*s, diffFrame[T]{item: n.items[i]})
+	}
+	if len(n.children) > 0 {
+		s.push(n.children[0], f.h-1)
+	}
+}
+
+// next removes the smallest item from the stack and returns it, or returns false if the stack is empty.
+func (s *diffStack[T]) next() (_ T, _ bool) {
+	for len(*s) > 0 {
+		f := (*s)[len(*s)-1]
+		if f.n != nil {
+			s.expand()
+			continue
+		}
+		s.pop()
+		return f.item, true
+	}
+	return
+}
+
+// Diff compares the btree a with the btree b, and reports how to turn a into b: onRemove is called with the
+// items only in a, onAdd is called with the items only in b, and onChange is called with the equal items of
+// a and b which aren't reflect.DeepEqual. Any of the callbacks can be nil. See DiffOf for more details.
+//
+// The subtrees shared by a btree and its clones are skipped only if both a and b are created by New,
+// NewWithFreeList or Clone of them. Otherwise, e.g. for a thread-safe wrapper of a btree, all the items of
+// the wrapper are compared, using the comparator of the other btree, or their natural ordering if neither
+// btree is created by New or NewWithFreeList. Call Diff within the Do method of the wrapper to avoid that.
+func Diff(a, b Interface, onAdd, onRemove func(item interface{}), onChange func(old, new interface{})) {
+	ta, okA := a.(*bTree)
+	tb, okB := b.(*bTree)
+	var degree int
+	var cmp func(a, b interface{}) int
+	switch {
+	case okA:
+		degree, cmp = ta.degree, ta.cmp
+	case okB:
+		degree, cmp = tb.degree, tb.cmp
+	default:
+		degree, cmp = defaultDegree, utils.CompareFunc[interface{}](nil)
+	}
+	if !okA {
+		ta = copyOf(a, degree, cmp)
+	}
+	if !okB {
+		tb = copyOf(b, degree, cmp)
+	}
+	DiffOf(ta.BTree, tb.BTree, nil, onAdd, onRemove, onChange)
+}
+
+// defaultDegree is used when the degree of a btree isn't known, e.g. a wrapper of a btree.
+const defaultDegree = 32
+
+// copyOf copies the items of the btree which isn't created by New, which may be a wrapper of a btree.
+func copyOf(t Interface, degree int, cmp func(a, b interface{}) int) *bTree {
+	b := NewBuilderOf(degree, cmp).WithValidation(false)
+	_ = b.AddSeq(t.All())
+	return &bTree{b.Build()}
+}
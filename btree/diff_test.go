@@ -0,0 +1,137 @@
+// Copyright (c) 2019, Benjamin Wang (benjamin_wang@aliyun.com). All rights reserved.
+// Licensed under the MIT license that can be found in the LICENSE file.
+
+package btree_test
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/ahrtr/gocontainer/btree"
+)
+
+// diffResult records the callbacks of Diff.
+type diffResult struct {
+	added, removed []int
+	changed        [][2]int
+}
+
+func diffOf(a, b *btree.BTree[kv]) diffResult {
+	var r diffResult
+	btree.DiffOf(a, b, nil,
+		func(item kv) { r.added = append(r.added, item.k) },
+		func(item kv) { r.removed = append(r.removed, item.k) },
+		func(old, new kv) { r.changed = append(r.changed, [2]int{old.v, new.v}) })
+	return r
+}
+
+func TestDiff(t *testing.T) {
+	cmp := func(a, b kv) int { return a.k - b.k }
+	for _, degree := range []int{2, 3, *btreeDegree} {
+		a := btree.NewOf(degree, cmp)
+		for _, k := range rand.Perm(2000) {
+			a.ReplaceOrInsert(kv{k, 0})
+		}
+		b := a.Clone()
+		if r := diffOf(a, b); !reflect.DeepEqual(r, diffResult{}) {
+			t.Fatalf("degree %d: unexpected diff between clones: %v", degree, r)
+		}
+
+		// Modify both trees randomly, and compare the diff with the expected one.
+		for _, k := range rand.Perm(3000)[:500] {
+			switch rand.Intn(4) {
+			case 0:
+				a.ReplaceOrInsert(kv{k, 0})
+			case 1:
+				b.ReplaceOrInsert(kv{k, 0})
+			case 2:
+				b.Delete(kv{k: k})
+			default:
+				b.ReplaceOrInsert(kv{k, 1})
+			}
+		}
+		var want diffResult
+		for k := 0; k < 3000; k++ {
+			x, inA := a.Get(kv{k: k})
+			y, inB := b.Get(kv{k: k})
+			switch {
+			case inA && !inB:
+				want.removed = append(want.removed, k)
+			case !inA && inB:
+				want.added = append(want.added, k)
+			case inA && inB && x != y:
+				want.changed = append(want.changed, [2]int{x.v, y.v})
+			}
+		}
+		if r := diffOf(a, b); !reflect.DeepEqual(r, want) {
+			t.Fatalf("degree %d: mismatch:\n got: %v\nwant: %v", degree, r, want)
+		}
+		// The opposite direction.
+		if r := diffOf(b, a); !reflect.DeepEqual(r.added, want.removed) || !reflect.DeepEqual(r.removed, want.added) {
+			t.Fatalf("degree %d: mismatch in the opposite direction", degree)
+		}
+	}
+}
+
+func TestDiffSkipsSharedSubtrees(t *testing.T) {
+	var compared int
+	a := btree.NewOf(*btreeDegree, func(a, b int) int {
+		compared++
+		return a - b
+	})
+	for v := 0; v < 100000; v++ {
+		a.ReplaceOrInsert(v)
+	}
+	b := a.Clone()
+	b.Delete(500)
+	b.ReplaceOrInsert(-1)
+	b.ReplaceOrInsert(100000)
+
+	compared = 0
+	var added, removed []int
+	btree.DiffOf(a, b, nil, func(item int) { added = append(added, item) }, func(item int) { removed = append(removed, item) }, nil)
+	if !reflect.DeepEqual(added, []int{-1, 100000}) || !reflect.DeepEqual(removed, []int{500}) {
+		t.Fatalf("unexpected diff, added: %v, removed: %v", added, removed)
+	}
+	if compared > 1000 {
+		t.Fatalf("expected the shared subtrees to be skipped, but compared %d times", compared)
+	}
+
+	// Nothing is compared between a tree and itself.
+	compared = 0
+	btree.DiffOf(a, a, nil, nil, nil, nil)
+	if compared != 0 {
+		t.Fatalf("expected no comparison, got %d", compared)
+	}
+}
+
+func TestDiffInterface(t *testing.T) {
+	a := btree.New(*btreeDegree)
+	for _, v := range perm(100) {
+		a.ReplaceOrInsert(v)
+	}
+	b := a.Clone()
+	b.Delete(10)
+	b.ReplaceOrInsert(100)
+
+	var added, removed []interface{}
+	onAdd := func(item interface{}) { added = append(added, item) }
+	onRemove := func(item interface{}) { removed = append(removed, item) }
+	btree.Diff(a, b, onAdd, onRemove, nil)
+	if !reflect.DeepEqual(added, []interface{}{100}) || !reflect.DeepEqual(removed, []interface{}{10}) {
+		t.Fatalf("unexpected diff, added: %v, removed: %v", added, removed)
+	}
+
+	// A btree which isn't created by New is copied.
+	added, removed = nil, nil
+	btree.Diff(a, wrapper{b}, onAdd, onRemove, nil)
+	if !reflect.DeepEqual(added, []interface{}{100}) || !reflect.DeepEqual(removed, []interface{}{10}) {
+		t.Fatalf("unexpected diff, added: %v, removed: %v", added, removed)
+	}
+}
+
+// wrapper is a btree which isn't created by New.
+type wrapper struct {
+	btree.Interface
+}
@@ -0,0 +1,85 @@
+// Copyright (c) 2019, Benjamin Wang (benjamin_wang@aliyun.com). All rights reserved.
+// Licensed under the MIT license that can be found in the LICENSE file.
+
+package btree_test
+
+import (
+	"testing"
+
+	"github.com/ahrtr/gocontainer/btree"
+)
+
+func TestClearAndRecycle(t *testing.T) {
+	fl := btree.NewFreeList(1000)
+	tr := btree.NewWithFreeList(2, fl)
+	for _, v := range perm(100) {
+		tr.ReplaceOrInsert(v)
+	}
+
+	// Clear leaves the nodes to the garbage collector.
+	clone := tr.Clone()
+	tr.Clear()
+	if !tr.IsEmpty() || fl.Stats().Size != 0 {
+		t.Fatalf("unexpected free list size %d", fl.Stats().Size)
+	}
+
+	// The nodes shared with another tree aren't recycled.
+	clone2 := clone.Clone()
+	clone2.ClearAndRecycle()
+	if fl.Stats().Size != 0 || clone.Size() != 100 {
+		t.Fatalf("unexpected free list size %d", fl.Stats().Size)
+	}
+	if err := clone.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	tr = btree.NewWithFreeList(2, fl)
+	for v := 0; v < 100; v++ {
+		tr.ReplaceOrInsert(v)
+	}
+	nodes := tr.Stats().Nodes
+	tr.ClearAndRecycle()
+	if s := fl.Stats(); !tr.IsEmpty() || s.Size != nodes || s.Recycled != uint64(nodes) {
+		t.Fatalf("expected %d nodes to be recycled, got %+v", nodes, s)
+	}
+
+	// The recycled nodes are reused.
+	before := fl.Stats()
+	for v := 0; v < 100; v++ {
+		tr.ReplaceOrInsert(v)
+	}
+	after := fl.Stats()
+	if after.Reused-before.Reused != uint64(nodes) || after.Allocated != before.Allocated {
+		t.Fatalf("expected %d nodes to be reused, before: %+v, after: %+v", nodes, before, after)
+	}
+}
+
+func TestFreeListFull(t *testing.T) {
+	fl := btree.NewFreeListOf[int](4)
+	tr := btree.NewWithFreeListOf(2, nil, fl)
+	for v := 0; v < 100; v++ {
+		tr.ReplaceOrInsert(v)
+	}
+	tr.ClearAndRecycle()
+	if s := fl.Stats(); s.Size != 4 || s.Capacity != 4 || s.Recycled != 4 || s.Discarded != 1 {
+		t.Fatalf("unexpected stats: %+v", s)
+	}
+}
+
+func TestFreeListShrink(t *testing.T) {
+	fl := btree.NewFreeListOf[int](32)
+	tr := btree.NewWithFreeListOf(2, nil, fl)
+	for v := 0; v < 100; v++ {
+		tr.ReplaceOrInsert(v)
+	}
+	tr.ClearAndRecycle()
+	if n := fl.Shrink(40); n != 0 || fl.Stats().Size != 32 {
+		t.Fatalf("unexpected result of Shrink: %d, %+v", n, fl.Stats())
+	}
+	if n := fl.Shrink(10); n != 22 || fl.Stats().Size != 10 {
+		t.Fatalf("unexpected result of Shrink: %d, %+v", n, fl.Stats())
+	}
+	if n := fl.Shrink(-1); n != 10 || fl.Stats().Size != 0 || fl.Stats().Capacity != 32 {
+		t.Fatalf("unexpected result of Shrink: %d, %+v", n, fl.Stats())
+	}
+}
@@ -0,0 +1,240 @@
+// Copyright (c) 2019, Benjamin Wang (benjamin_wang@aliyun.com). All rights reserved.
+// Licensed under the MIT license that can be found in the LICENSE file.
+
+package btree
+
+// This file implements the structural operations on subtrees, which SplitAt, Merge, DeleteRange, etc.
+// are built on. Splitting a subtree or joining two subtrees only touches the nodes on a single path,
+// so it takes O(log n) time no matter how many items are moved.
+
+// subtree is a tree used while splitting and joining btrees. root is nil if the subtree is empty,
+// otherwise the root has at least one item. height is the number of levels below the root.
+type subtree[T any] struct {
+	root   *node[T]
+	height int
+}
+
+func (s subtree[T]) empty() bool {
+	return s.root == nil
+}
+
+func (s subtree[T]) size() int {
+	if s.root == nil {
+		return 0
+	}
+	return s.root.size
+}
+
+// makeSubtree returns the subtree rooted at n, whose height is h. The roots without any item are removed.
+func makeSubtree[T any](n *node[T], h int) subtree[T] {
+	for n != nil && len(n.items) == 0 {
+		if len(n.children) == 0 {
+			return subtree[T]{}
+		}
+		n, h = n.children[0], h-1
+	}
+	return subtree[T]{n, h}
+}
+
+// heightOf returns the height of the subtree rooted at n.
+func heightOf[T any](n *node[T]) int {
+	h := 0
+	for n != nil && len(n.children) > 0 {
+		n = n.children[0]
+		h++
+	}
+	return h
+}
+
+// subtree returns the whole tree as a subtree.
+func (t *BTree[T]) subtree() subtree[T] {
+	return makeSubtree(t.root, heightOf(t.root))
+}
+
+// setSubtree replaces the content of the tree with the subtree.
+func (t *BTree[T]) setSubtree(s subtree[T]) {
+	t.root, t.length = s.root, s.size()
+	t.version++
+}
+
+// view returns a tree sharing the nodes of the subtree and the copy-on-write context of t, so that the
+// ordinary operations can be applied on the subtree. Its length isn't maintained.
+func (t *BTree[T]) view(s subtree[T]) *BTree[T] {
+	return &BTree[T]{
+		degree: t.degree,
+		root:   s.root,
+		cmp:    t.cmp,
+		cow:    t.cow,
+	}
+}
+
+// newNodeOf returns a new node owned by t, containing the given items and children.
+func (t *BTree[T]) newNodeOf(items []T, children []*node[T]) *node[T] {
+	n := t.cow.newNode()
+	n.items = append(n.items, items...)
+	n.children = append(n.children, children...)
+	n.recount()
+	return n
+}
+
+// part returns the subtree made of the items and children of a node whose height is h,
+// where len(children) == len(items)+1, or children is empty for a leaf.
+func (t *BTree[T]) part(items []T, children []*node[T], h int) subtree[T] {
+	if len(items) == 0 {
+		if len(children) == 0 {
+			return subtree[T]{}
+		}
+		return makeSubtree(children[0], h-1)
+	}
+	return subtree[T]{t.newNodeOf(items, children), h}
+}
+
+// insertInto adds the item into the subtree.
+func (t *BTree[T]) insertInto(s subtree[T], item T) subtree[T] {
+	v := t.view(s)
+	v.ReplaceOrInsert(item)
+	return makeSubtree(v.root, heightOf(v.root))
+}
+
+// splitSubtree splits the subtree into two subtrees, the left one contains the items less than
+// the key, and the right one contains the others. The nodes on the path to the key are replaced.
+func (t *BTree[T]) splitSubtree(s subtree[T], key T) (left, right subtree[T]) {
+	if s.empty() {
+		return
+	}
+	n, h := s.root, s.height
+	i, found := n.items.find(key, t.cmp)
+	switch {
+	case len(n.children) == 0:
+		left = t.part(n.items[:i], nil, 0)
+		right = t.part(n.items[i:], nil, 0)
+	case found:
+		left = t.part(n.items[:i], n.children[:i+1], h)
+		right = t.insertInto(t.part(n.items[i+1:], n.children[i+1:], h), n.items[i])
+	default:
+		left, right = t.splitSubtree(makeSubtree(n.children[i], h-1), key)
+		if i > 0 {
+			left = t.join(t.part(n.items[:i-1], n.children[:i], h), n.items[i-1], left)
+		}
+		if i < len(n.items) {
+			right = t.join(right, n.items[i], t.part(n.items[i+1:], n.children[i+1:], h))
+		}
+	}
+	t.cow.freeNode(n)
+	return
+}
+
+// join returns a subtree containing the items in l, sep and the items in r, where all the
+// items in l are less than sep, and all the items in r are greater than sep.
+func (t *BTree[T]) join(l subtree[T], sep T, r subtree[T]) subtree[T] {
+	switch {
+	case l.empty():
+		return t.insertInto(r, sep)
+	case r.empty():
+		return t.insertInto(l, sep)
+	case l.height == r.height:
+		root := t.newNodeOf([]T{sep}, []*node[T]{l.root, r.root})
+		t.rebalance(root, 0)
+		return makeSubtree(root, l.height+1)
+	case l.height > r.height:
+		root := l.root.mutableFor(t.cow)
+		if item, second := t.joinRight(root, l.height, sep, r); second != nil {
+			return subtree[T]{t.newNodeOf([]T{item}, []*node[T]{root, second}), l.height + 1}
+		}
+		return subtree[T]{root, l.height}
+	default:
+		root := r.root.mutableFor(t.cow)
+		if item, second := t.joinLeft(root, r.height, l, sep); second != nil {
+			return subtree[T]{t.newNodeOf([]T{item}, []*node[T]{root, second}), r.height + 1}
+		}
+		return subtree[T]{root, r.height}
+	}
+}
+
+// joinRight appends sep and the lower subtree r to the rightmost path of the subtree rooted at n, whose
+// height is h. If n overflows, it's split, and the item and the new node after it are returned.
+func (t *BTree[T]) joinRight(n *node[T], h int, sep T, r subtree[T]) (_ T, _ *node[T]) {
+	if h == r.height+1 {
+		n.items = append(n.items, sep)
+		n.children = append(n.children, r.root)
+		t.rebalance(n, len(n.items)-1)
+	} else if item, second := t.joinRight(n.mutableChild(len(n.children)-1), h-1, sep, r); second != nil {
+		n.items = append(n.items, item)
+		n.children = append(n.children, second)
+	}
+	n.recount()
+	if len(n.items) > t.maxItems() {
+		return n.split(len(n.items) / 2)
+	}
+	return
+}
+
+// joinLeft prepends the lower subtree l and sep to the leftmost path of the subtree rooted at n, whose
+// height is h. If n overflows, it's split, and the item and the new node after it are returned.
+func (t *BTree[T]) joinLeft(n *node[T], h int, l subtree[T], sep T) (_ T, _ *node[T]) {
+	if h == l.height+1 {
+		n.items.insertAt(0, sep)
+		n.children.insertAt(0, l.root)
+		t.rebalance(n, 0)
+	} else if item, second := t.joinLeft(n.mutableChild(0), h-1, l, sep); second != nil {
+		n.items.insertAt(0, item)
+		n.children.insertAt(1, second)
+	}
+	n.recount()
+	if len(n.items) > t.maxItems() {
+		return n.split(len(n.items) / 2)
+	}
+	return
+}
+
+// rebalance makes sure that neither child i nor child i+1 of n has fewer than minItems items, by merging
+// them if all their items fit into one node, or moving the items between them otherwise. n must be mutable.
+func (t *BTree[T]) rebalance(n *node[T], i int) {
+	if len(n.children[i].items) >= t.minItems() && len(n.children[i+1].items) >= t.minItems() {
+		return
+	}
+	left, right := n.mutableChild(i), n.children[i+1]
+	if len(left.items)+1+len(right.items) <= t.maxItems() {
+		left.items = append(left.items, n.items.removeAt(i))
+		left.items = append(left.items, right.items...)
+		left.children = append(left.children, right.children...)
+		left.size += 1 + right.size
+		n.children.removeAt(i + 1)
+		t.cow.freeNode(right)
+		return
+	}
+
+	right = n.mutableChild(i + 1)
+	allItems := make(items[T], 0, len(left.items)+1+len(right.items))
+	allItems = append(append(append(allItems, left.items...), n.items[i]), right.items...)
+	var allChildren children[T]
+	if len(left.children) > 0 {
+		allChildren = make(children[T], 0, len(left.children)+len(right.children))
+		allChildren = append(append(allChildren, left.children...), right.children...)
+	}
+	m := len(allItems) / 2
+	left.items = append(left.items[:0], allItems[:m]...)
+	n.items[i] = allItems[m]
+	right.items = append(right.items[:0], allItems[m+1:]...)
+	if len(allChildren) > 0 {
+		left.children = append(left.children[:0], allChildren[:m+1]...)
+		right.children = append(right.children[:0], allChildren[m+1:]...)
+	}
+	left.recount()
+	right.recount()
+}
+
+// concat returns a subtree containing the items in both l and r, where all the items
+// in l are less than those in r.
+func (t *BTree[T]) concat(l, r subtree[T]) subtree[T] {
+	if l.empty() {
+		return r
+	}
+	if r.empty() {
+		return l
+	}
+	// The smallest item in r is used to join the subtrees.
+	v := t.view(r)
+	sep, _ := v.DeleteMin()
+	return t.join(l, sep, makeSubtree(v.root, heightOf(v.root)))
+}
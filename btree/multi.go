@@ -0,0 +1,356 @@
+// Copyright (c) 2019, Benjamin Wang (benjamin_wang@aliyun.com). All rights reserved.
+// Licensed under the MIT license that can be found in the LICENSE file.
+
+package btree
+
+import (
+	"iter"
+	"math"
+
+	"github.com/ahrtr/gocontainer/collection"
+	"github.com/ahrtr/gocontainer/utils"
+)
+
+// MultiInterface is a type of btree which can store multiple equal items, and multiBTree implements this interface.
+// The equal items are kept in insertion order.
+type MultiInterface interface {
+	collection.Interface
+
+	// WithComparator sets an utils.Comparator instance for the btree.
+	// It's used to impose a total ordering on the elements in the btree.
+	WithComparator(c utils.Comparator) MultiInterface
+	// Clone clones the btree, lazily.
+	Clone() MultiInterface
+
+	// Insert adds the given item to the tree. It never replaces any item, the item is added
+	// after all the items equal to it.
+	Insert(item interface{})
+	// DeleteOne removes the earliest inserted item equal to the given key from the tree, and returns it.
+	// If no such item exists, returns nil.
+	DeleteOne(key interface{}) interface{}
+	// DeleteAll removes all the items equal to the given key from the tree, and returns the number of items removed.
+	DeleteAll(key interface{}) int
+	// DeleteMin removes the smallest item in the tree and returns it.
+	// If no such item exists, returns nil.
+	DeleteMin() interface{}
+	// DeleteMax removes the largest item in the tree and returns it.
+	// If no such item exists, returns nil.
+	DeleteMax() interface{}
+
+	// Get returns the earliest inserted item equal to the given key, or nil if unable to find that item.
+	Get(key interface{}) interface{}
+	// Has returns true if the given key is in the tree.
+	Has(key interface{}) bool
+	// Count returns the number of items equal to the given key.
+	Count(key interface{}) int
+	// Min returns the smallest item in the tree, or nil if the tree is empty.
+	Min() interface{}
+	// Max returns the largest item in the tree, or nil if the tree is empty.
+	Max() interface{}
+
+	// All returns an iterator over every value in the tree in ascending order.
+	All() iter.Seq[interface{}]
+	// AllEqual returns an iterator over all the items equal to the given key in insertion order.
+	AllEqual(key interface{}) iter.Seq[interface{}]
+	// AllRange returns an iterator over every value in the tree within the range
+	// [greaterOrEqual, lessThan) in ascending order. A nil bound means the range is unbounded on that side.
+	AllRange(greaterOrEqual, lessThan interface{}) iter.Seq[interface{}]
+	// Backward returns an iterator over every value in the tree in descending order.
+	Backward() iter.Seq[interface{}]
+	// BackwardRange returns an iterator over every value in the tree within the range
+	// [lessOrEqual, greaterThan) in descending order. A nil bound means the range is unbounded on that side.
+	BackwardRange(lessOrEqual, greaterThan interface{}) iter.Seq[interface{}]
+}
+
+// MultiOf is a btree which can store multiple equal items of type T. The equal items are kept in insertion
+// order, ascending iterations yield them in insertion order, and descending iterations in the reverse order.
+type MultiOf[T any] struct {
+	t   *BTree[multiItem[T]]
+	cmp func(a, b T) int
+	seq uint64
+}
+
+// multiItem is an item stored in the underlying btree of a MultiOf, the equal items are ordered by seq.
+type multiItem[T any] struct {
+	item T
+	seq  uint64
+}
+
+// Every inserted item gets a seq in the range [1, math.MaxUint64), so that the bounds with
+// minSeq and maxSeq enclose all the items equal to a key.
+const (
+	minSeq uint64 = 0
+	maxSeq uint64 = math.MaxUint64
+)
+
+// multiBTree is the btree returned by NewMulti, it implements the MultiInterface.
+type multiBTree struct {
+	*MultiOf[interface{}]
+}
+
+// NewMulti creates a new B-Tree with the given degree, which can store multiple equal items.
+func NewMulti(degree int) MultiInterface {
+	return &multiBTree{NewMultiOf[interface{}](degree, nil)}
+}
+
+// NewMultiOf creates a new B-Tree with the given degree, which can store multiple equal items of type T.
+// The items are ordered by cmp. If cmp is nil, then the items are ordered according to their natural ordering.
+func NewMultiOf[T any](degree int, cmp func(a, b T) int) *MultiOf[T] {
+	m := &MultiOf[T]{t: NewOf[multiItem[T]](degree, nil)}
+	return m.WithComparator(cmp)
+}
+
+// WithComparator sets the comparison function for the btree. The items are ordered according to their
+// natural ordering if cmp is nil. It should be called before any item is added into the btree.
+func (m *MultiOf[T]) WithComparator(cmp func(a, b T) int) *MultiOf[T] {
+	if cmp == nil {
+		cmp = utils.CompareFunc[T](nil)
+	}
+	m.cmp = cmp
+	m.t.WithComparator(func(a, b multiItem[T]) int {
+		if c := cmp(a.item, b.item); c != 0 {
+			return c
+		}
+		switch {
+		case a.seq < b.seq:
+			return -1
+		case a.seq > b.seq:
+			return 1
+		}
+		return 0
+	})
+	return m
+}
+
+// Clone clones the btree, lazily.
+func (m *MultiOf[T]) Clone() *MultiOf[T] {
+	return &MultiOf[T]{t: m.t.Clone(), cmp: m.cmp, seq: m.seq}
+}
+
+// Size returns the number of items currently in the tree.
+func (m *MultiOf[T]) Size() int {
+	return m.t.Size()
+}
+
+// IsEmpty returns true if the tree doesn't have any items.
+func (m *MultiOf[T]) IsEmpty() bool {
+	return m.t.IsEmpty()
+}
+
+// Clear removes all items from the btree.
+func (m *MultiOf[T]) Clear() {
+	m.t.Clear()
+}
+
+// Insert adds the given item to the tree. It never replaces any item, the item is added
+// after all the items equal to it.
+func (m *MultiOf[T]) Insert(item T) {
+	m.seq++
+	m.t.ReplaceOrInsert(multiItem[T]{item, m.seq})
+}
+
+// DeleteOne removes the earliest inserted item equal to the given key from the tree, and returns it.
+// If no such item exists, returns (zeroValue, false).
+func (m *MultiOf[T]) DeleteOne(key T) (_ T, _ bool) {
+	e, ok := m.first(key)
+	if !ok {
+		return
+	}
+	m.t.Delete(e)
+	return e.item, true
+}
+
+// DeleteAll removes all the items equal to the given key from the tree, and returns the number of items removed.
+func (m *MultiOf[T]) DeleteAll(key T) int {
+	return m.t.DeleteRange(multiItem[T]{key, minSeq}, multiItem[T]{key, maxSeq})
+}
+
+// DeleteMin removes the smallest item in the tree and returns it.
+// If no such item exists, returns (zeroValue, false).
+func (m *MultiOf[T]) DeleteMin() (T, bool) {
+	e, ok := m.t.DeleteMin()
+	return e.item, ok
+}
+
+// DeleteMax removes the largest item in the tree and returns it.
+// If no such item exists, returns (zeroValue, false).
+func (m *MultiOf[T]) DeleteMax() (T, bool) {
+	e, ok := m.t.DeleteMax()
+	return e.item, ok
+}
+
+// Get returns the earliest inserted item equal to the given key, or (zeroValue, false) if unable to find that item.
+func (m *MultiOf[T]) Get(key T) (T, bool) {
+	e, ok := m.first(key)
+	return e.item, ok
+}
+
+// Has returns true if the given key is in the tree.
+func (m *MultiOf[T]) Has(key T) bool {
+	_, ok := m.first(key)
+	return ok
+}
+
+// Count returns the number of items equal to the given key. It takes O(log n) time.
+func (m *MultiOf[T]) Count(key T) int {
+	return m.t.CountRange(multiItem[T]{key, minSeq}, multiItem[T]{key, maxSeq})
+}
+
+// Min returns the smallest item in the tree, or (zeroValue, false) if the tree is empty.
+func (m *MultiOf[T]) Min() (T, bool) {
+	e, ok := m.t.Min()
+	return e.item, ok
+}
+
+// Max returns the largest item in the tree, or (zeroValue, false) if the tree is empty.
+func (m *MultiOf[T]) Max() (T, bool) {
+	e, ok := m.t.Max()
+	return e.item, ok
+}
+
+// All returns an iterator over every value in the tree in ascending order.
+func (m *MultiOf[T]) All() iter.Seq[T] {
+	return m.span(ascend, empty[T](), empty[T]())
+}
+
+// AllEqual returns an iterator over all the items equal to the given key in insertion order.
+func (m *MultiOf[T]) AllEqual(key T) iter.Seq[T] {
+	return itemsOf(m.t.AllRange(multiItem[T]{key, minSeq}, multiItem[T]{key, maxSeq}))
+}
+
+// AllRange returns an iterator over every value in the tree within the range
+// [greaterOrEqual, lessThan) in ascending order.
+func (m *MultiOf[T]) AllRange(greaterOrEqual, lessThan T) iter.Seq[T] {
+	return m.span(ascend, optional(greaterOrEqual), optional(lessThan))
+}
+
+// AllLessThan returns an iterator over every value in the tree within the range
+// [first, pivot) in ascending order.
+func (m *MultiOf[T]) AllLessThan(pivot T) iter.Seq[T] {
+	return m.span(ascend, empty[T](), optional(pivot))
+}
+
+// AllGreaterOrEqual returns an iterator over every value in the tree within the range
+// [pivot, last] in ascending order.
+func (m *MultiOf[T]) AllGreaterOrEqual(pivot T) iter.Seq[T] {
+	return m.span(ascend, optional(pivot), empty[T]())
+}
+
+// Backward returns an iterator over every value in the tree in descending order.
+func (m *MultiOf[T]) Backward() iter.Seq[T] {
+	return m.span(descend, empty[T](), empty[T]())
+}
+
+// BackwardRange returns an iterator over every value in the tree within the range
+// [lessOrEqual, greaterThan) in descending order.
+func (m *MultiOf[T]) BackwardRange(lessOrEqual, greaterThan T) iter.Seq[T] {
+	return m.span(descend, optional(lessOrEqual), optional(greaterThan))
+}
+
+// BackwardLessOrEqual returns an iterator over every value in the tree within the range
+// [pivot, first] in descending order.
+func (m *MultiOf[T]) BackwardLessOrEqual(pivot T) iter.Seq[T] {
+	return m.span(descend, optional(pivot), empty[T]())
+}
+
+// BackwardGreaterThan returns an iterator over every value in the tree within the range
+// [last, pivot) in descending order.
+func (m *MultiOf[T]) BackwardGreaterThan(pivot T) iter.Seq[T] {
+	return m.span(descend, empty[T](), optional(pivot))
+}
+
+// first returns the earliest inserted item equal to the given key.
+func (m *MultiOf[T]) first(key T) (_ multiItem[T], _ bool) {
+	for e := range m.t.AllGreaterOrEqual(multiItem[T]{key, minSeq}) {
+		if m.cmp(e.item, key) == 0 {
+			return e, true
+		}
+		break
+	}
+	return
+}
+
+// span returns an iterator over every value in the tree between start and stop. When ascending, the
+// items equal to start are included and those equal to stop are excluded. When descending, it's the opposite.
+func (m *MultiOf[T]) span(dir direction, start, stop optionalItem[T]) iter.Seq[T] {
+	// All the items equal to a bound are after {bound, minSeq} and before {bound, maxSeq}.
+	seq := minSeq
+	if dir == descend {
+		seq = maxSeq
+	}
+	bound := func(o optionalItem[T]) optionalItem[multiItem[T]] {
+		if !o.valid {
+			return empty[multiItem[T]]()
+		}
+		return optional(multiItem[T]{o.item, seq})
+	}
+	return itemsOf(m.t.seq(dir, bound(start), bound(stop), true))
+}
+
+// itemsOf converts an iterator over the underlying items into an iterator over the items.
+func itemsOf[T any](s iter.Seq[multiItem[T]]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for e := range s {
+			if !yield(e.item) {
+				return
+			}
+		}
+	}
+}
+
+func (m *multiBTree) WithComparator(c utils.Comparator) MultiInterface {
+	m.MultiOf.WithComparator(utils.CompareFunc[interface{}](c))
+	return m
+}
+
+func (m *multiBTree) Clone() MultiInterface {
+	return &multiBTree{m.MultiOf.Clone()}
+}
+
+// Insert adds the given item to the tree.
+//
+// nil cannot be added to the tree (will panic).
+func (m *multiBTree) Insert(item interface{}) {
+	if item == nil {
+		panic("nil item being added to BTree")
+	}
+	m.MultiOf.Insert(item)
+}
+
+func (m *multiBTree) DeleteOne(key interface{}) interface{} {
+	out, _ := m.MultiOf.DeleteOne(key)
+	return out
+}
+
+func (m *multiBTree) DeleteMin() interface{} {
+	out, _ := m.MultiOf.DeleteMin()
+	return out
+}
+
+func (m *multiBTree) DeleteMax() interface{} {
+	out, _ := m.MultiOf.DeleteMax()
+	return out
+}
+
+func (m *multiBTree) Get(key interface{}) interface{} {
+	out, _ := m.MultiOf.Get(key)
+	return out
+}
+
+func (m *multiBTree) Min() interface{} {
+	out, _ := m.MultiOf.Min()
+	return out
+}
+
+func (m *multiBTree) Max() interface{} {
+	out, _ := m.MultiOf.Max()
+	return out
+}
+
+func (m *multiBTree) AllRange(greaterOrEqual, lessThan interface{}) iter.Seq[interface{}] {
+	return m.span(ascend, optionalOrEmpty(greaterOrEqual), optionalOrEmpty(lessThan))
+}
+
+func (m *multiBTree) BackwardRange(lessOrEqual, greaterThan interface{}) iter.Seq[interface{}] {
+	return m.span(descend, optionalOrEmpty(lessOrEqual), optionalOrEmpty(greaterThan))
+}
@@ -0,0 +1,177 @@
+// Copyright (c) 2019, Benjamin Wang (benjamin_wang@aliyun.com). All rights reserved.
+// Licensed under the MIT license that can be found in the LICENSE file.
+
+package btree_test
+
+import (
+	"math/rand"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/ahrtr/gocontainer/btree"
+)
+
+func TestMulti(t *testing.T) {
+	for _, degree := range []int{2, 3, *btreeDegree} {
+		// The items are ordered by key, and the values record the insertion order.
+		m := btree.NewMultiOf(degree, func(a, b kv) int { return a.k - b.k })
+		want := make(map[int][]int)
+		for i, k := range rand.Perm(3000) {
+			k %= 100
+			m.Insert(kv{k, i})
+			want[k] = append(want[k], i)
+		}
+		if m.Size() != 3000 {
+			t.Fatalf("degree %d: expected size 3000, got %d", degree, m.Size())
+		}
+
+		values := func(k int) []int {
+			var out []int
+			for item := range m.AllEqual(kv{k: k}) {
+				out = append(out, item.v)
+			}
+			return out
+		}
+		for k := 0; k < 100; k++ {
+			if got := values(k); !reflect.DeepEqual(got, want[k]) {
+				t.Fatalf("degree %d: key %d: expected %v, got %v", degree, k, want[k], got)
+			}
+			if n := m.Count(kv{k: k}); n != 30 {
+				t.Fatalf("degree %d: key %d: expected count 30, got %d", degree, k, n)
+			}
+			if item, ok := m.Get(kv{k: k}); !ok || item.v != want[k][0] {
+				t.Fatalf("degree %d: key %d: expected first %d, got %v", degree, k, want[k][0], item)
+			}
+		}
+		if m.Has(kv{k: 100}) || m.Count(kv{k: 100}) != 0 {
+			t.Fatal("unexpected key 100")
+		}
+
+		// DeleteOne removes the earliest inserted item.
+		for k := 0; k < 100; k += 2 {
+			item, ok := m.DeleteOne(kv{k: k})
+			if !ok || item.v != want[k][0] {
+				t.Fatalf("degree %d: key %d: expected to delete %d, got %v", degree, k, want[k][0], item)
+			}
+			want[k] = want[k][1:]
+			if got := values(k); !reflect.DeepEqual(got, want[k]) {
+				t.Fatalf("degree %d: key %d: expected %v, got %v", degree, k, want[k], got)
+			}
+		}
+		if m.Size() != 2950 {
+			t.Fatalf("degree %d: expected size 2950, got %d", degree, m.Size())
+		}
+
+		// DeleteAll removes all the duplicates.
+		for k := 0; k < 100; k += 3 {
+			if n := m.DeleteAll(kv{k: k}); n != len(want[k]) {
+				t.Fatalf("degree %d: key %d: expected to delete %d items, got %d", degree, k, len(want[k]), n)
+			}
+			delete(want, k)
+			if m.Has(kv{k: k}) || m.DeleteAll(kv{k: k}) != 0 {
+				t.Fatalf("degree %d: key %d still exists", degree, k)
+			}
+			if _, ok := m.DeleteOne(kv{k: k}); ok {
+				t.Fatalf("degree %d: key %d still exists", degree, k)
+			}
+		}
+
+		var all []kv
+		for k := 0; k < 100; k++ {
+			for _, v := range want[k] {
+				all = append(all, kv{k, v})
+			}
+		}
+		if got := slices.Collect(m.All()); !reflect.DeepEqual(got, all) {
+			t.Fatalf("degree %d: All mismatch", degree)
+		}
+		slices.Reverse(all)
+		if got := slices.Collect(m.Backward()); !reflect.DeepEqual(got, all) {
+			t.Fatalf("degree %d: Backward mismatch", degree)
+		}
+	}
+}
+
+func TestMultiRange(t *testing.T) {
+	m := btree.NewMultiOf[int](2, nil)
+	for i := 0; i < 3; i++ {
+		for _, v := range perm(10) {
+			m.Insert(v.(int))
+		}
+	}
+	for _, tc := range []struct {
+		got, want []int
+	}{
+		{slices.Collect(m.AllRange(3, 5)), []int{3, 3, 3, 4, 4, 4}},
+		{slices.Collect(m.AllRange(5, 5)), nil},
+		{slices.Collect(m.AllLessThan(2)), []int{0, 0, 0, 1, 1, 1}},
+		{slices.Collect(m.AllGreaterOrEqual(8)), []int{8, 8, 8, 9, 9, 9}},
+		{slices.Collect(m.BackwardRange(5, 3)), []int{5, 5, 5, 4, 4, 4}},
+		{slices.Collect(m.BackwardLessOrEqual(1)), []int{1, 1, 1, 0, 0, 0}},
+		{slices.Collect(m.BackwardGreaterThan(7)), []int{9, 9, 9, 8, 8, 8}},
+	} {
+		if !reflect.DeepEqual(tc.got, tc.want) {
+			t.Fatalf("expected %v, got %v", tc.want, tc.got)
+		}
+	}
+
+	// The clone doesn't share the modifications with the original tree.
+	clone := m.Clone()
+	clone.DeleteAll(0)
+	clone.Insert(9)
+	if m.Count(0) != 3 || m.Count(9) != 3 || clone.Count(0) != 0 || clone.Count(9) != 4 {
+		t.Fatal("the clone shares modifications with the original tree")
+	}
+	if v, _ := clone.DeleteMin(); v != 1 {
+		t.Fatalf("expected min 1, got %d", v)
+	}
+	if v, _ := clone.DeleteMax(); v != 9 || clone.Count(9) != 3 {
+		t.Fatalf("expected max 9, got %d", v)
+	}
+	m.Clear()
+	if !m.IsEmpty() {
+		t.Fatal("the tree should be empty after Clear")
+	}
+}
+
+func TestMultiInterface(t *testing.T) {
+	m := btree.NewMulti(*btreeDegree)
+	for _, v := range []string{"b", "a", "c", "a", "b", "a"} {
+		m.Insert(v)
+	}
+	if m.Size() != 6 || m.Count("a") != 3 || m.Count("d") != 0 {
+		t.Fatalf("unexpected size %d or count %d", m.Size(), m.Count("a"))
+	}
+	want := []interface{}{"a", "a", "a", "b", "b", "c"}
+	if got := slices.Collect(m.All()); !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	if got := slices.Collect(m.AllRange(nil, "b")); !reflect.DeepEqual(got, want[:3]) {
+		t.Fatalf("expected %v, got %v", want[:3], got)
+	}
+	if got := slices.Collect(m.BackwardRange(nil, "a")); !reflect.DeepEqual(got, []interface{}{"c", "b", "b"}) {
+		t.Fatalf("unexpected items %v", got)
+	}
+	if m.DeleteOne("b") != "b" || m.DeleteOne("d") != nil || m.DeleteAll("a") != 3 {
+		t.Fatal("unexpected deletion result")
+	}
+	if m.Min() != "b" || m.Max() != "c" || m.Get("a") != nil {
+		t.Fatalf("unexpected min %v or max %v", m.Min(), m.Max())
+	}
+
+	// Order the items in reverse.
+	r := btree.NewMulti(2).WithComparator(descendingInt{})
+	for _, v := range []int{1, 3, 2, 3} {
+		r.Insert(v)
+	}
+	if got := slices.Collect(r.All()); !reflect.DeepEqual(got, []interface{}{3, 3, 2, 1}) {
+		t.Fatalf("unexpected items %v", got)
+	}
+}
+
+type descendingInt struct{}
+
+func (descendingInt) Compare(v1, v2 interface{}) (int, error) {
+	return v2.(int) - v1.(int), nil
+}
@@ -0,0 +1,108 @@
+// Copyright (c) 2019, Benjamin Wang (benjamin_wang@aliyun.com). All rights reserved.
+// Licensed under the MIT license that can be found in the LICENSE file.
+
+package btree
+
+// Each node keeps the number of items in its subtree, so the order-statistic
+// operations below only walk a single path from the root, which is O(log n).
+
+// Select returns the k-th smallest item in the tree, where k is zero-based. It returns
+// (zeroValue, false) if k is out of the range [0, Size()).
+func (t *BTree[T]) Select(k int) (_ T, _ bool) {
+	if k < 0 || k >= t.length {
+		return
+	}
+	return t.root.selectAt(k), true
+}
+
+// Rank returns the number of items in the tree which are less than the given item.
+// If the item is in the tree, it's the zero-based index of the item in ascending order.
+func (t *BTree[T]) Rank(item T) int {
+	if t.root == nil {
+		return 0
+	}
+	return t.root.rank(item, t.cmp)
+}
+
+// CountRange returns the number of items in the tree within the range [greaterOrEqual, lessThan).
+func (t *BTree[T]) CountRange(greaterOrEqual, lessThan T) int {
+	return t.countRange(optional(greaterOrEqual), optional(lessThan))
+}
+
+// DeleteAt removes the k-th smallest item in the tree and returns it, where k is zero-based.
+// It returns (zeroValue, false) if k is out of the range [0, Size()).
+func (t *BTree[T]) DeleteAt(k int) (_ T, _ bool) {
+	item, ok := t.Select(k)
+	if !ok {
+		return
+	}
+	return t.Delete(item)
+}
+
+// countRange returns the number of items in the tree between the two bounds, an absent
+// bound means the range is unbounded on that side.
+func (t *BTree[T]) countRange(greaterOrEqual, lessThan optionalItem[T]) int {
+	lo, hi := 0, t.length
+	if greaterOrEqual.valid {
+		lo = t.Rank(greaterOrEqual.item)
+	}
+	if lessThan.valid {
+		hi = t.Rank(lessThan.item)
+	}
+	if hi < lo {
+		return 0
+	}
+	return hi - lo
+}
+
+// selectAt returns the k-th smallest item in the subtree, k must be in the range [0, n.size).
+func (n *node[T]) selectAt(k int) T {
+	for len(n.children) > 0 {
+		i := 0
+		for ; i < len(n.items); i++ {
+			if k < n.children[i].size {
+				break
+			}
+			k -= n.children[i].size
+			if k == 0 {
+				return n.items[i]
+			}
+			k--
+		}
+		n = n.children[i]
+	}
+	return n.items[k]
+}
+
+// rank returns the number of items in the subtree which are less than the given item.
+func (n *node[T]) rank(item T, cmp func(a, b T) int) int {
+	r := 0
+	for {
+		i, found := n.items.find(item, cmp)
+		r += i
+		if len(n.children) == 0 {
+			return r
+		}
+		for _, c := range n.children[:i] {
+			r += c.size
+		}
+		if found {
+			return r + n.children[i].size
+		}
+		n = n.children[i]
+	}
+}
+
+func (t *bTree) Select(k int) interface{} {
+	out, _ := t.BTree.Select(k)
+	return out
+}
+
+func (t *bTree) CountRange(greaterOrEqual, lessThan interface{}) int {
+	return t.countRange(optionalOrEmpty(greaterOrEqual), optionalOrEmpty(lessThan))
+}
+
+func (t *bTree) DeleteAt(k int) interface{} {
+	out, _ := t.BTree.DeleteAt(k)
+	return out
+}
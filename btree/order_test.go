@@ -0,0 +1,140 @@
+// Copyright (c) 2019, Benjamin Wang (benjamin_wang@aliyun.com). All rights reserved.
+// Licensed under the MIT license that can be found in the LICENSE file.
+
+package btree_test
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/ahrtr/gocontainer/btree"
+)
+
+// checkOrder verifies Select, Rank and CountRange of tr against the sorted items.
+func checkOrder(t *testing.T, tr *btree.BTree[int], sorted []int) {
+	t.Helper()
+	if tr.Size() != len(sorted) {
+		t.Fatalf("size: want %d, got %d", len(sorted), tr.Size())
+	}
+	for k, want := range sorted {
+		if got, ok := tr.Select(k); !ok || got != want {
+			t.Fatalf("Select(%d): want (%d, true), got (%d, %t)", k, want, got, ok)
+		}
+		if r := tr.Rank(want); r != k {
+			t.Fatalf("Rank(%d): want %d, got %d", want, k, r)
+		}
+		// The odd numbers aren't in the tree.
+		if r, want := tr.Rank(want+1), k+1; r != want {
+			t.Fatalf("Rank(%d): want %d, got %d", sorted[k]+1, want, r)
+		}
+	}
+	for _, k := range []int{-1, len(sorted)} {
+		if _, ok := tr.Select(k); ok {
+			t.Fatalf("Select(%d) should fail", k)
+		}
+	}
+	for i := 0; i < 10; i++ {
+		lo, hi := rand.Intn(2*len(sorted)+2)-1, rand.Intn(2*len(sorted)+2)-1
+		want := 0
+		for _, v := range sorted {
+			if v >= lo && v < hi {
+				want++
+			}
+		}
+		if got := tr.CountRange(lo, hi); got != want {
+			t.Fatalf("CountRange(%d, %d): want %d, got %d", lo, hi, want, got)
+		}
+	}
+}
+
+func TestOrderStatistic(t *testing.T) {
+	for _, degree := range []int{2, 3, *btreeDegree} {
+		tr := btree.NewOf[int](degree, nil)
+		var sorted []int
+		for _, v := range rand.Perm(1000) {
+			tr.ReplaceOrInsert(2 * v)
+		}
+		for v := 0; v < 1000; v++ {
+			sorted = append(sorted, 2*v)
+		}
+		checkOrder(t, tr, sorted)
+
+		// Replacing an existing item doesn't change the sizes.
+		tr.ReplaceOrInsert(10)
+		checkOrder(t, tr, sorted)
+
+		// Modify a clone, both trees should keep their own sizes.
+		clone := tr.Clone()
+		cloneSorted := slices.Clone(sorted)
+		for i := 0; i < 300; i++ {
+			k := rand.Intn(len(cloneSorted))
+			if v, ok := clone.DeleteAt(k); !ok || v != cloneSorted[k] {
+				t.Fatalf("DeleteAt(%d): want (%d, true), got (%d, %t)", k, cloneSorted[k], v, ok)
+			}
+			cloneSorted = slices.Delete(cloneSorted, k, k+1)
+		}
+		checkOrder(t, clone, cloneSorted)
+		checkOrder(t, tr, sorted)
+
+		for len(sorted) > 0 {
+			switch rand.Intn(3) {
+			case 0:
+				tr.DeleteMin()
+				sorted = sorted[1:]
+			case 1:
+				tr.DeleteMax()
+				sorted = sorted[:len(sorted)-1]
+			default:
+				k := rand.Intn(len(sorted))
+				tr.Delete(sorted[k])
+				sorted = slices.Delete(sorted, k, k+1)
+			}
+			if len(sorted)%97 == 0 {
+				checkOrder(t, tr, sorted)
+			}
+		}
+		if _, ok := tr.DeleteAt(0); ok {
+			t.Fatal("DeleteAt on an empty tree should fail")
+		}
+	}
+}
+
+func TestOrderStatisticInterface(t *testing.T) {
+	tr := btree.New(*btreeDegree)
+	for _, v := range perm(100) {
+		tr.ReplaceOrInsert(v)
+	}
+	if v := tr.Select(42); v != 42 {
+		t.Fatalf("Select(42): want 42, got %v", v)
+	}
+	if v := tr.Select(100); v != nil {
+		t.Fatalf("Select(100): want nil, got %v", v)
+	}
+	if r := tr.Rank(42); r != 42 {
+		t.Fatalf("Rank(42): want 42, got %d", r)
+	}
+	for _, tc := range []struct {
+		lo, hi interface{}
+		want   int
+	}{
+		{10, 20, 10},
+		{nil, 20, 20},
+		{90, nil, 10},
+		{nil, nil, 100},
+		{20, 10, 0},
+	} {
+		if got := tr.CountRange(tc.lo, tc.hi); got != tc.want {
+			t.Fatalf("CountRange(%v, %v): want %d, got %d", tc.lo, tc.hi, tc.want, got)
+		}
+	}
+	if v := tr.DeleteAt(0); v != 0 {
+		t.Fatalf("DeleteAt(0): want 0, got %v", v)
+	}
+	if v := tr.DeleteAt(99); v != nil {
+		t.Fatalf("DeleteAt(99): want nil, got %v", v)
+	}
+	if tr.Size() != 99 || tr.Select(0) != 1 {
+		t.Fatalf("unexpected tree after DeleteAt: size %d, min %v", tr.Size(), tr.Select(0))
+	}
+}
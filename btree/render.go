@@ -0,0 +1,131 @@
+// Copyright (c) 2019, Benjamin Wang (benjamin_wang@aliyun.com). All rights reserved.
+// Licensed under the MIT license that can be found in the LICENSE file.
+
+package btree
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// RenderOptionsOf controls how the structure of a BTree[T] is rendered by WriteDOT and Print.
+// A nil *RenderOptionsOf is valid, and all the options take the default values.
+type RenderOptionsOf[T any] struct {
+	// Format formats an item. If it's nil, the item is formatted by fmt.Sprint.
+	Format func(item T) string
+	// HighlightShared highlights the nodes which aren't owned by the tree. They were created before the
+	// tree or the tree it's cloned from was cloned, so they may be shared with the clones.
+	HighlightShared bool
+}
+
+// RenderOptions controls how the structure of the btrees created by New and NewWithFreeList is rendered.
+type RenderOptions = RenderOptionsOf[interface{}]
+
+func (o *RenderOptionsOf[T]) format(item T) string {
+	if o == nil || o.Format == nil {
+		return fmt.Sprint(item)
+	}
+	return o.Format(item)
+}
+
+func (o *RenderOptionsOf[T]) highlight(t *BTree[T], n *node[T]) bool {
+	return o != nil && o.HighlightShared && n.cow != t.cow
+}
+
+// WriteDOT writes the structure of the tree to w as a Graphviz DOT graph, where each node of the tree is
+// a record of its items, with an edge to each of its children. It takes O(n) time. Render the graph by:
+//   dot -Tsvg btree.dot -o btree.svg
+func (t *BTree[T]) WriteDOT(w io.Writer, opts *RenderOptionsOf[T]) error {
+	bw := bufio.NewWriter(w)
+	bw.WriteString("digraph btree {\n\tnode [shape=record];\n")
+	if t.root != nil {
+		id := 0
+		t.writeDOTNode(bw, t.root, &id, opts)
+	}
+	bw.WriteString("}\n")
+	return bw.Flush()
+}
+
+// writeDOTNode writes the subtree rooted at n, and returns the id of n.
+func (t *BTree[T]) writeDOTNode(w *bufio.Writer, n *node[T], id *int, opts *RenderOptionsOf[T]) int {
+	self := *id
+	*id++
+
+	// The fields of the record are the items, interleaved with the ports of the children.
+	fields := make([]string, 0, len(n.items)+len(n.children))
+	for i, item := range n.items {
+		if len(n.children) > 0 {
+			fields = append(fields, fmt.Sprintf("<c%d>", i))
+		}
+		fields = append(fields, escapeDOT(opts.format(item)))
+	}
+	if len(n.children) > 0 {
+		fields = append(fields, fmt.Sprintf("<c%d>", len(n.items)))
+	}
+	fmt.Fprintf(w, "\tn%d [label=\"%s\"", self, strings.Join(fields, "|"))
+	if opts.highlight(t, n) {
+		w.WriteString(", style=filled, fillcolor=lightgrey")
+	}
+	w.WriteString("];\n")
+
+	for i, c := range n.children {
+		child := t.writeDOTNode(w, c, id, opts)
+		fmt.Fprintf(w, "\tn%d:c%d -> n%d;\n", self, i, child)
+	}
+	return self
+}
+
+// escapeDOT escapes the characters which have special meanings in a record label.
+func escapeDOT(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch r {
+		case '{', '}', '|', '<', '>', '"', '\\', ' ':
+			b.WriteByte('\\')
+		case '\n':
+			b.WriteString(`\n`)
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// Print writes the structure of the tree to w as indented text, one node per line, where each node is
+// followed by its children indented by two more spaces. The nodes highlighted by the options are marked
+// with a trailing "*". It takes O(n) time. For example, the tree with degree 2 after adding 1 to 9 in order:
+//   [4]
+//     [2]
+//       [1]
+//       [3]
+//     [6]
+//       [5]
+//       [7 8 9]
+func (t *BTree[T]) Print(w io.Writer, opts *RenderOptionsOf[T]) error {
+	bw := bufio.NewWriter(w)
+	if t.root != nil {
+		t.printNode(bw, t.root, 0, opts)
+	}
+	return bw.Flush()
+}
+
+func (t *BTree[T]) printNode(w *bufio.Writer, n *node[T], level int, opts *RenderOptionsOf[T]) {
+	w.WriteString(strings.Repeat("  ", level))
+	w.WriteByte('[')
+	for i, item := range n.items {
+		if i > 0 {
+			w.WriteByte(' ')
+		}
+		w.WriteString(opts.format(item))
+	}
+	w.WriteByte(']')
+	if opts.highlight(t, n) {
+		w.WriteByte('*')
+	}
+	w.WriteByte('\n')
+	for _, c := range n.children {
+		t.printNode(w, c, level+1, opts)
+	}
+}
@@ -0,0 +1,86 @@
+// Copyright (c) 2019, Benjamin Wang (benjamin_wang@aliyun.com). All rights reserved.
+// Licensed under the MIT license that can be found in the LICENSE file.
+
+package btree_test
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/ahrtr/gocontainer/btree"
+)
+
+func TestPrint(t *testing.T) {
+	tr := btree.NewOf[int](2, nil)
+	var buf bytes.Buffer
+	if err := tr.Print(&buf, nil); err != nil || buf.Len() != 0 {
+		t.Fatalf("unexpected output of an empty tree: %q, %v", buf.String(), err)
+	}
+	for v := 1; v <= 9; v++ {
+		tr.ReplaceOrInsert(v)
+	}
+	want := `[4]
+  [2]
+    [1]
+    [3]
+  [6]
+    [5]
+    [7 8 9]
+`
+	if err := tr.Print(&buf, nil); err != nil || buf.String() != want {
+		t.Fatalf("unexpected output:\n%s", buf.String())
+	}
+
+	// The shared nodes are marked after the clone is modified.
+	clone := tr.Clone()
+	clone.ReplaceOrInsert(10)
+	buf.Reset()
+	opts := &btree.RenderOptionsOf[int]{
+		Format:          func(item int) string { return fmt.Sprintf("#%d", item) },
+		HighlightShared: true,
+	}
+	want = `[#4]
+  [#2]*
+    [#1]*
+    [#3]*
+  [#6 #8]
+    [#5]*
+    [#7]
+    [#9 #10]
+`
+	if err := clone.Print(&buf, opts); err != nil || buf.String() != want {
+		t.Fatalf("unexpected output:\n%s", buf.String())
+	}
+}
+
+func TestWriteDOT(t *testing.T) {
+	tr := btree.New(2)
+	var buf bytes.Buffer
+	if err := tr.WriteDOT(&buf, nil); err != nil || buf.String() != "digraph btree {\n\tnode [shape=record];\n}\n" {
+		t.Fatalf("unexpected output of an empty tree: %q, %v", buf.String(), err)
+	}
+	for _, v := range []string{"a", "b|c", "d e", "f", "g"} {
+		tr.ReplaceOrInsert(v)
+	}
+	clone := tr.Clone()
+	clone.ReplaceOrInsert("h")
+	buf.Reset()
+	if err := clone.WriteDOT(&buf, &btree.RenderOptions{HighlightShared: true}); err != nil {
+		t.Fatal(err)
+	}
+	for _, line := range []string{
+		`n0 [label="<c0>|b\|c|<c1>|f|<c2>"];`,
+		`n1 [label="a", style=filled, fillcolor=lightgrey];`,
+		`n0:c0 -> n1;`,
+		`n2 [label="d\ e"];`,
+		`n0:c1 -> n2;`,
+		`n3 [label="g|h"];`,
+		`n0:c2 -> n3;`,
+	} {
+		if !strings.Contains(buf.String(), "\t"+line+"\n") {
+			t.Fatalf("expected %q in the output:\n%s", line, buf.String())
+		}
+	}
+}
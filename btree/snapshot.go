@@ -0,0 +1,307 @@
+// Copyright (c) 2019, Benjamin Wang (benjamin_wang@aliyun.com). All rights reserved.
+// Licensed under the MIT license that can be found in the LICENSE file.
+
+package btree
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"hash/crc32"
+	"io"
+	"iter"
+	"math"
+
+	"github.com/ahrtr/gocontainer/utils"
+)
+
+// A snapshot of a btree is encoded as below, where all the integers are unsigned varints unless noted otherwise:
+//   header:  magic "GCBT", version (1 byte), degree, count
+//   items:   count * (length, the bytes encoded by the codec)
+//   trailer: the CRC-32 (IEEE) checksum of the header and items, as a big-endian uint32
+// The checksum is in the trailer, so that the items can be streamed without being buffered.
+
+const (
+	snapshotMagic   = "GCBT"
+	snapshotVersion = 1
+	// maxSnapshotItemSize is the max length of an encoded item, a longer one means the snapshot is corrupted.
+	maxSnapshotItemSize = 1 << 30
+)
+
+var (
+	// ErrInvalidSnapshot is returned when reading a snapshot which isn't written by WriteTo or WriteToOf.
+	ErrInvalidSnapshot = errors.New("btree: invalid snapshot")
+	// ErrChecksumMismatch is returned when the checksum of a snapshot doesn't match its content.
+	ErrChecksumMismatch = errors.New("btree: snapshot checksum mismatch")
+)
+
+// CodecOf encodes the items of type T into bytes when writing a snapshot of a btree, and decodes them when
+// reading the snapshot.
+type CodecOf[T any] interface {
+	// Marshal encodes the item.
+	Marshal(item T) ([]byte, error)
+	// Unmarshal decodes an item encoded by Marshal. The item may retain data.
+	Unmarshal(data []byte) (T, error)
+}
+
+// Codec encodes and decodes the items of the btrees created by New and NewWithFreeList.
+type Codec = CodecOf[interface{}]
+
+// JSONCodecOf returns a codec which encodes the items as JSON. Note that JSON can't preserve the
+// dynamic types of interface{} values, e.g. all the numbers are decoded as float64.
+func JSONCodecOf[T any]() CodecOf[T] {
+	return jsonCodec[T]{}
+}
+
+// GobCodecOf returns a codec which encodes the items using encoding/gob. The concrete types of the
+// interface{} values must be registered by gob.Register.
+func GobCodecOf[T any]() CodecOf[T] {
+	return gobCodec[T]{}
+}
+
+type jsonCodec[T any] struct{}
+
+func (jsonCodec[T]) Marshal(item T) ([]byte, error) {
+	return json.Marshal(item)
+}
+
+func (jsonCodec[T]) Unmarshal(data []byte) (item T, err error) {
+	err = json.Unmarshal(data, &item)
+	return
+}
+
+type gobCodec[T any] struct{}
+
+func (gobCodec[T]) Marshal(item T) ([]byte, error) {
+	var buf bytes.Buffer
+	// Encode a pointer, so that the dynamic type of an interface{} value is encoded.
+	if err := gob.NewEncoder(&buf).Encode(&item); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (gobCodec[T]) Unmarshal(data []byte) (item T, err error) {
+	err = gob.NewDecoder(bytes.NewReader(data)).Decode(&item)
+	return
+}
+
+// WriteToOf writes a snapshot of the tree to w, the items are encoded by codec in ascending order. It returns the
+// number of bytes written. The tree must not be modified while it's being written, so call it on a Clone of the
+// tree in order to take a consistent snapshot without blocking the writers of the tree:
+//   snapshot := t.Clone()
+//   go btree.WriteToOf(w, snapshot, codec)
+func WriteToOf[T any](w io.Writer, t *BTree[T], codec CodecOf[T]) (int64, error) {
+	return writeSnapshot(w, t.degree, t.length, t.All(), codec)
+}
+
+// WriteTo writes a snapshot of the btree to w, the items are encoded by codec in ascending order. It returns
+// the number of bytes written. A thread-safe btree, e.g. one created by concurrent.NewBTree, is cloned before
+// being written, otherwise the btree must not be modified while it's being written, see WriteToOf.
+//
+// It's a function instead of a method of Interface, because a WriteTo method is expected to implement io.WriterTo.
+func WriteTo(w io.Writer, t Interface, codec Codec) (int64, error) {
+	// Clone the btree wrapped by a thread-safe btree within Do, so that the snapshot is consistent
+	// and records the degree of the wrapped btree.
+	for {
+		st, ok := t.(syncWrapper)
+		if !ok {
+			break
+		}
+		st.Do(func(wrapped Interface) {
+			t = wrapped.Clone()
+		})
+	}
+	if bt, ok := t.(*bTree); ok {
+		return writeSnapshot(w, bt.degree, bt.length, bt.All(), codec)
+	}
+	// The degree of the other implementations isn't known.
+	t = t.Clone()
+	return writeSnapshot(w, defaultDegree, t.Size(), t.All(), codec)
+}
+
+// syncWrapper is implemented by the thread-safe btrees, which run f with the wrapped btree exclusively.
+type syncWrapper interface {
+	Do(f func(t Interface))
+}
+
+func writeSnapshot[T any](w io.Writer, degree, count int, items iter.Seq[T], codec CodecOf[T]) (int64, error) {
+	sw := &snapshotWriter{w: w, crc: crc32.NewIEEE()}
+	bw := bufio.NewWriter(sw)
+	buf := append([]byte(snapshotMagic), snapshotVersion)
+	buf = binary.AppendUvarint(buf, uint64(degree))
+	buf = binary.AppendUvarint(buf, uint64(count))
+	if _, err := bw.Write(buf); err != nil {
+		return sw.n, err
+	}
+
+	written := 0
+	for item := range items {
+		data, err := codec.Marshal(item)
+		if err != nil {
+			return sw.n, err
+		}
+		buf = binary.AppendUvarint(buf[:0], uint64(len(data)))
+		if _, err := bw.Write(buf); err != nil {
+			return sw.n, err
+		}
+		if _, err := bw.Write(data); err != nil {
+			return sw.n, err
+		}
+		written++
+	}
+	if written != count {
+		return sw.n, fmt.Errorf("btree: the tree is modified while being written, %d items expected, %d written", count, written)
+	}
+	if err := bw.Flush(); err != nil {
+		return sw.n, err
+	}
+	_, err := w.Write(binary.BigEndian.AppendUint32(nil, sw.crc.Sum32()))
+	if err == nil {
+		sw.n += 4
+	}
+	return sw.n, err
+}
+
+// snapshotWriter counts the bytes written to w, and computes their checksum.
+type snapshotWriter struct {
+	w   io.Writer
+	crc hash.Hash32
+	n   int64
+}
+
+func (sw *snapshotWriter) Write(p []byte) (int, error) {
+	n, err := sw.w.Write(p)
+	sw.crc.Write(p[:n])
+	sw.n += int64(n)
+	return n, err
+}
+
+// ReadFromOf reads a snapshot written by WriteToOf from r, and bulk loads the items into a new btree in O(n) time.
+// The items are decoded by codec, and must be in strictly ascending order according to cmp, otherwise ErrUnsorted
+// is returned. If cmp is nil, the items are ordered according to their natural ordering. If degree is 0, the degree
+// of the btree which the snapshot is taken from is used.
+//
+// If r isn't an io.ByteReader, it's buffered, so more bytes than the snapshot may be read from r.
+func ReadFromOf[T any](r io.Reader, codec CodecOf[T], degree int, cmp func(a, b T) int) (*BTree[T], error) {
+	return readSnapshot(r, codec, degree, cmp)
+}
+
+// ReadFrom reads a snapshot written by WriteTo from r, and bulk loads the items into a new btree in O(n) time.
+// The items are decoded by codec, and must be in strictly ascending order according to the comparator c, otherwise
+// ErrUnsorted is returned. If c is nil, the items are ordered according to their natural ordering. If degree is 0,
+// the degree of the btree which the snapshot is taken from is used.
+//
+// If r isn't an io.ByteReader, it's buffered, so more bytes than the snapshot may be read from r.
+func ReadFrom(r io.Reader, codec Codec, degree int, c utils.Comparator) (Interface, error) {
+	t, err := readSnapshot(r, codec, degree, utils.CompareFunc[interface{}](c))
+	if err != nil {
+		return nil, err
+	}
+	return &bTree{t}, nil
+}
+
+func readSnapshot[T any](r io.Reader, codec CodecOf[T], degree int, cmp func(a, b T) int) (*BTree[T], error) {
+	br, ok := r.(snapshotByteReader)
+	if !ok {
+		br = bufio.NewReader(r)
+	}
+	sr := &snapshotReader{r: br, crc: crc32.NewIEEE()}
+
+	header := make([]byte, len(snapshotMagic)+1)
+	if _, err := io.ReadFull(sr, header); err != nil {
+		return nil, unexpectedEOF(err)
+	}
+	if string(header[:len(snapshotMagic)]) != snapshotMagic {
+		return nil, ErrInvalidSnapshot
+	}
+	if v := header[len(snapshotMagic)]; v != snapshotVersion {
+		return nil, fmt.Errorf("%w: unsupported version %d", ErrInvalidSnapshot, v)
+	}
+	d, err := binary.ReadUvarint(sr)
+	if err != nil {
+		return nil, unexpectedEOF(err)
+	}
+	count, err := binary.ReadUvarint(sr)
+	if err != nil {
+		return nil, unexpectedEOF(err)
+	}
+	if degree == 0 {
+		if d <= 1 || d > math.MaxInt32 {
+			return nil, fmt.Errorf("%w: bad degree %d", ErrInvalidSnapshot, d)
+		}
+		degree = int(d)
+	}
+
+	b := NewBuilderOf(degree, cmp)
+	for i := uint64(0); i < count; i++ {
+		n, err := binary.ReadUvarint(sr)
+		if err != nil {
+			return nil, unexpectedEOF(err)
+		}
+		if n > maxSnapshotItemSize {
+			return nil, fmt.Errorf("%w: bad item length %d", ErrInvalidSnapshot, n)
+		}
+		// Each item gets its own buffer, since the codec may retain data, e.g. a []byte item.
+		data := make([]byte, n)
+		if _, err := io.ReadFull(sr, data); err != nil {
+			return nil, unexpectedEOF(err)
+		}
+		item, err := codec.Unmarshal(data)
+		if err != nil {
+			return nil, err
+		}
+		if err := b.Add(item); err != nil {
+			return nil, err
+		}
+	}
+
+	sum := sr.crc.Sum32()
+	trailer := make([]byte, 4)
+	if _, err := io.ReadFull(br, trailer); err != nil {
+		return nil, unexpectedEOF(err)
+	}
+	if binary.BigEndian.Uint32(trailer) != sum {
+		return nil, ErrChecksumMismatch
+	}
+	return b.Build(), nil
+}
+
+// snapshotByteReader is needed to read the varints without reading more bytes than the snapshot.
+type snapshotByteReader interface {
+	io.Reader
+	io.ByteReader
+}
+
+// snapshotReader computes the checksum of the bytes read from r.
+type snapshotReader struct {
+	r   snapshotByteReader
+	crc hash.Hash32
+}
+
+func (sr *snapshotReader) Read(p []byte) (int, error) {
+	n, err := sr.r.Read(p)
+	sr.crc.Write(p[:n])
+	return n, err
+}
+
+func (sr *snapshotReader) ReadByte() (byte, error) {
+	c, err := sr.r.ReadByte()
+	if err == nil {
+		sr.crc.Write([]byte{c})
+	}
+	return c, err
+}
+
+// unexpectedEOF converts io.EOF into io.ErrUnexpectedEOF, since a snapshot is truncated if it ends early.
+func unexpectedEOF(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
@@ -0,0 +1,186 @@
+// Copyright (c) 2019, Benjamin Wang (benjamin_wang@aliyun.com). All rights reserved.
+// Licensed under the MIT license that can be found in the LICENSE file.
+
+package btree_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"slices"
+	"testing"
+
+	"github.com/ahrtr/gocontainer/btree"
+)
+
+// intCodec encodes the ints as varints.
+type intCodec struct{}
+
+func (intCodec) Marshal(item int) ([]byte, error) {
+	return binary.AppendVarint(nil, int64(item)), nil
+}
+
+func (intCodec) Unmarshal(data []byte) (int, error) {
+	v, n := binary.Varint(data)
+	if n != len(data) {
+		return 0, errors.New("bad varint")
+	}
+	return int(v), nil
+}
+
+func TestSnapshot(t *testing.T) {
+	for _, codec := range []btree.CodecOf[int]{intCodec{}, btree.JSONCodecOf[int](), btree.GobCodecOf[int]()} {
+		for _, n := range []int{0, 1, 100, 10000} {
+			tr := btree.NewOf[int](3, nil)
+			for _, v := range perm(n) {
+				tr.ReplaceOrInsert(v.(int) * 2)
+			}
+			var buf bytes.Buffer
+			written, err := btree.WriteToOf(&buf, tr.Clone(), codec)
+			if err != nil || written != int64(buf.Len()) {
+				t.Fatalf("%T: failed to write %d items, written: %d, error: %v", codec, n, written, err)
+			}
+
+			// Keep the degree in the snapshot.
+			got, err := btree.ReadFromOf(bytes.NewReader(buf.Bytes()), codec, 0, nil)
+			if err != nil {
+				t.Fatalf("%T: failed to read %d items: %v", codec, n, err)
+			}
+			checkOrder(t, got, slices.Collect(tr.All()))
+
+			// Change the degree.
+			got, err = btree.ReadFromOf(bytes.NewReader(buf.Bytes()), codec, 32, nil)
+			if err != nil || !slices.Equal(slices.Collect(got.All()), slices.Collect(tr.All())) {
+				t.Fatalf("%T: failed to read %d items with a different degree: %v", codec, n, err)
+			}
+		}
+	}
+}
+
+func TestSnapshotCorrupted(t *testing.T) {
+	tr := btree.NewOf[int](2, nil)
+	for v := 0; v < 100; v++ {
+		tr.ReplaceOrInsert(v)
+	}
+	var buf bytes.Buffer
+	if _, err := btree.WriteToOf(&buf, tr, intCodec{}); err != nil {
+		t.Fatal(err)
+	}
+	data := buf.Bytes()
+
+	read := func(data []byte) error {
+		_, err := btree.ReadFromOf(bytes.NewReader(data), intCodec{}, 0, nil)
+		return err
+	}
+	for i := 0; i < len(data); i++ {
+		if err := read(data[:i]); err == nil {
+			t.Fatalf("expected an error reading %d of %d bytes", i, len(data))
+		}
+	}
+	if err := read([]byte("not a snapshot")); !errors.Is(err, btree.ErrInvalidSnapshot) {
+		t.Fatalf("expected ErrInvalidSnapshot, got %v", err)
+	}
+	bad := slices.Clone(data)
+	bad[4] = 2
+	if err := read(bad); !errors.Is(err, btree.ErrInvalidSnapshot) {
+		t.Fatalf("expected ErrInvalidSnapshot for an unsupported version, got %v", err)
+	}
+	bad = slices.Clone(data)
+	bad[len(bad)-5]++
+	if err := read(bad); !errors.Is(err, btree.ErrChecksumMismatch) {
+		t.Fatalf("expected ErrChecksumMismatch, got %v", err)
+	}
+
+	// The items are in a different order.
+	_, err := btree.ReadFromOf(bytes.NewReader(data), intCodec{}, 0, func(a, b int) int { return b - a })
+	if !errors.Is(err, btree.ErrUnsorted) {
+		t.Fatalf("expected ErrUnsorted, got %v", err)
+	}
+}
+
+func TestSnapshotMultiple(t *testing.T) {
+	// Multiple snapshots are written to the same stream, and read one by one.
+	var buf bytes.Buffer
+	for n := 1; n <= 3; n++ {
+		tr := btree.NewOf[int](2, nil)
+		for v := 0; v < n*10; v++ {
+			tr.ReplaceOrInsert(v)
+		}
+		if _, err := btree.WriteToOf(&buf, tr, intCodec{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	r := bytes.NewReader(buf.Bytes())
+	for n := 1; n <= 3; n++ {
+		tr, err := btree.ReadFromOf(r, intCodec{}, 0, nil)
+		if err != nil || tr.Size() != n*10 {
+			t.Fatalf("failed to read snapshot %d: %v", n, err)
+		}
+	}
+	if _, err := btree.ReadFromOf(r, intCodec{}, 0, nil); err != io.ErrUnexpectedEOF {
+		t.Fatalf("expected io.ErrUnexpectedEOF, got %v", err)
+	}
+}
+
+// bytesCodec encodes the []byte items as themselves, and retains the data in Unmarshal.
+type bytesCodec struct{}
+
+func (bytesCodec) Marshal(item []byte) ([]byte, error) {
+	return item, nil
+}
+
+func (bytesCodec) Unmarshal(data []byte) ([]byte, error) {
+	return data, nil
+}
+
+func TestSnapshotRetainingCodec(t *testing.T) {
+	tr := btree.NewOf[[]byte](2, bytes.Compare)
+	for _, v := range []string{"cc", "aa", "bb", "dd"} {
+		tr.ReplaceOrInsert([]byte(v))
+	}
+	var buf bytes.Buffer
+	if _, err := btree.WriteToOf(&buf, tr, bytesCodec{}); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := btree.ReadFromOf(&buf, bytesCodec{}, 0, bytes.Compare)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.EqualFunc(slices.Collect(got.All()), slices.Collect(tr.All()), bytes.Equal) {
+		t.Fatalf("unexpected items: %q", slices.Collect(got.All()))
+	}
+}
+
+func TestSnapshotInterface(t *testing.T) {
+	tr := btree.New(*btreeDegree).WithComparator(descendingInt{})
+	for _, v := range perm(1000) {
+		tr.ReplaceOrInsert(v)
+	}
+	want := slices.Collect(tr.All())
+
+	for _, src := range []btree.Interface{tr, wrapper{tr}} {
+		var buf bytes.Buffer
+		if _, err := btree.WriteTo(&buf, src, btree.GobCodecOf[interface{}]()); err != nil {
+			t.Fatal(err)
+		}
+		data := buf.Bytes()
+		got, err := btree.ReadFrom(bytes.NewReader(data), btree.GobCodecOf[interface{}](), 0, descendingInt{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !slices.Equal(slices.Collect(got.All()), want) {
+			t.Fatalf("%T: mismatch", src)
+		}
+		got.ReplaceOrInsert(1000)
+		if got.Min() != 1000 || got.Size() != 1001 {
+			t.Fatalf("%T: the comparator isn't applied, min: %v", src, got.Min())
+		}
+
+		// The ordering is validated against the comparator.
+		if _, err := btree.ReadFrom(bytes.NewReader(data), btree.GobCodecOf[interface{}](), 0, nil); !errors.Is(err, btree.ErrUnsorted) {
+			t.Fatalf("%T: want ErrUnsorted, got %v", src, err)
+		}
+	}
+}
@@ -0,0 +1,161 @@
+// Copyright (c) 2019, Benjamin Wang (benjamin_wang@aliyun.com). All rights reserved.
+// Licensed under the MIT license that can be found in the LICENSE file.
+
+package btree
+
+import (
+	"iter"
+	"slices"
+)
+
+// SplitAt splits the tree at the given key, and returns two new trees. The left one contains the
+// items less than the key, and the right one contains the others. The tree itself is left unchanged,
+// since it's cloned lazily before being split, and only the nodes on the path to the key are copied.
+// It takes O(log n) time.
+func (t *BTree[T]) SplitAt(key T) (left, right *BTree[T]) {
+	left = t.Clone()
+	l, r := left.splitSubtree(left.subtree(), key)
+	right = &BTree[T]{
+		degree: left.degree,
+		cmp:    left.cmp,
+		cow:    &copyOnWriteContext[T]{freelist: left.cow.freelist},
+	}
+	left.setSubtree(l)
+	right.setSubtree(r)
+	// The nodes created while splitting may end up in either tree, so neither tree may own them,
+	// otherwise the modifications to one tree in place would be visible to the other.
+	left.cow = &copyOnWriteContext[T]{freelist: left.cow.freelist}
+	return left, right
+}
+
+// Merge adds all the items in the other tree, which must be ordered by the same comparison function, into
+// this tree. If both trees contain an equal item, then conflict is called with the item in this tree and the
+// item in the other tree, and the item it returns is kept. If conflict is nil, the item in the other tree is kept.
+// The other tree is left unchanged, but its nodes are shared with this tree by cloning it lazily, so just like
+// Clone, Merge should not be called concurrently with the other uses of the other tree.
+//
+// Only the items in this tree within the range of the other tree are merged one by one. If the ranges
+// of the trees don't overlap, the trees are joined in O(log n) time. Otherwise it takes O(log n + k)
+// time, where k is the number of items in the other tree and the overlapped range of this tree.
+func (t *BTree[T]) Merge(other *BTree[T], conflict func(existing, incoming T) T) {
+	if other == nil || other.IsEmpty() {
+		return
+	}
+	o := other.Clone()
+	lo, _ := o.Min()
+	hi, _ := o.Max()
+	left, middle := t.splitSubtree(t.subtree(), lo)
+	middle, right := t.splitSubtree(middle, hi)
+	// The item equal to hi, if any, is the smallest item in right.
+	if item, ok := min(right.root); ok && t.cmp(item, hi) == 0 {
+		v := t.view(right)
+		v.DeleteMin()
+		right = makeSubtree(v.root, heightOf(v.root))
+		middle = t.insertInto(middle, item)
+	}
+
+	// If no item in this tree is within the range of the other tree, the nodes of the other tree
+	// can be shared, since the clone of the other tree is discarded, they are never modified in place.
+	// But the nodes of a tree with another degree don't fit in this tree, so they are rebuilt.
+	mid := o.subtree()
+	if !middle.empty() {
+		items := mergeSorted(t.view(middle).All(), o.All(), middle.size()+o.length, t.cmp, conflict)
+		middle.root.reset(t.cow)
+		mid = t.build(items)
+	} else if o.degree != t.degree {
+		mid = t.build(slices.Collect(o.All()))
+	}
+	t.setSubtree(t.concat(t.concat(left, mid), right))
+}
+
+// mergeSorted merges two ascending sequences into a slice, and resolves the conflicts of the equal items.
+func mergeSorted[T any](existing, incoming iter.Seq[T], capacity int, cmp func(a, b T) int, conflict func(existing, incoming T) T) []T {
+	out := make([]T, 0, capacity)
+	var b []T
+	for item := range incoming {
+		b = append(b, item)
+	}
+	j := 0
+	for a := range existing {
+		for ; j < len(b) && cmp(b[j], a) < 0; j++ {
+			out = append(out, b[j])
+		}
+		if j < len(b) && cmp(b[j], a) == 0 {
+			if conflict != nil {
+				a = conflict(a, b[j])
+			} else {
+				a = b[j]
+			}
+			j++
+		}
+		out = append(out, a)
+	}
+	return append(out, b[j:]...)
+}
+
+// DeleteRange removes all the items in the tree within the range [greaterOrEqual, lessThan), and returns
+// the number of items removed. The tree is split at both bounds and the remaining parts are joined, so it
+// takes O(log n) time no matter how many items are removed, besides returning the nodes to the freelist.
+func (t *BTree[T]) DeleteRange(greaterOrEqual, lessThan T) int {
+	return t.deleteRange(optional(greaterOrEqual), optional(lessThan))
+}
+
+// deleteRange removes all the items in the tree between the two bounds, an absent bound means the
+// range is unbounded on that side.
+func (t *BTree[T]) deleteRange(greaterOrEqual, lessThan optionalItem[T]) int {
+	removed := t.countRange(greaterOrEqual, lessThan)
+	if removed == 0 {
+		return 0
+	}
+	var left, right subtree[T]
+	middle := t.subtree()
+	if greaterOrEqual.valid {
+		left, middle = t.splitSubtree(middle, greaterOrEqual.item)
+	}
+	if lessThan.valid {
+		middle, right = t.splitSubtree(middle, lessThan.item)
+	}
+	middle.root.reset(t.cow)
+	t.setSubtree(t.concat(left, right))
+	return removed
+}
+
+// Filter removes all the items in the tree which don't satisfy the predicate, and returns the number of
+// items removed. The tree is rebuilt from the remaining items in O(n) time, instead of removing the items
+// one by one.
+func (t *BTree[T]) Filter(pred func(item T) bool) int {
+	var kept []T
+	for item := range t.All() {
+		if pred(item) {
+			kept = append(kept, item)
+		}
+	}
+	removed := t.length - len(kept)
+	if removed == 0 {
+		return 0
+	}
+	t.root.reset(t.cow)
+	t.setSubtree(t.build(kept))
+	return removed
+}
+
+func (t *bTree) SplitAt(key interface{}) (left, right Interface) {
+	l, r := t.BTree.SplitAt(key)
+	return &bTree{l}, &bTree{r}
+}
+
+func (t *bTree) Merge(other Interface, conflict func(existing, incoming interface{}) interface{}) {
+	o, ok := other.(*bTree)
+	if !ok {
+		// Copy the items of the other btree, which may be a wrapper of a btree.
+		o = &bTree{NewOf[interface{}](t.degree, t.cmp)}
+		for item := range other.All() {
+			o.ReplaceOrInsert(item)
+		}
+	}
+	t.BTree.Merge(o.BTree, conflict)
+}
+
+func (t *bTree) DeleteRange(greaterOrEqual, lessThan interface{}) int {
+	return t.deleteRange(optionalOrEmpty(greaterOrEqual), optionalOrEmpty(lessThan))
+}
@@ -0,0 +1,256 @@
+// Copyright (c) 2019, Benjamin Wang (benjamin_wang@aliyun.com). All rights reserved.
+// Licensed under the MIT license that can be found in the LICENSE file.
+
+package btree_test
+
+import (
+	"math/rand"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/ahrtr/gocontainer/btree"
+)
+
+// evens returns a tree with the given degree containing the even numbers in the range [0, 2n).
+func evens(degree, n int) (*btree.BTree[int], []int) {
+	tr := btree.NewOf[int](degree, nil)
+	var sorted []int
+	for _, v := range rand.Perm(n) {
+		tr.ReplaceOrInsert(2 * v)
+	}
+	for v := 0; v < n; v++ {
+		sorted = append(sorted, 2*v)
+	}
+	return tr, sorted
+}
+
+func TestSplitAt(t *testing.T) {
+	for _, degree := range []int{2, 3, *btreeDegree} {
+		for _, n := range []int{0, 1, 10, 1000} {
+			tr, sorted := evens(degree, n)
+			for _, key := range []int{-1, 0, 1, n / 2, n, n + 1, 2*n - 2, 2 * n} {
+				left, right := tr.SplitAt(key)
+				i, _ := slices.BinarySearch(sorted, key)
+				checkOrder(t, left, sorted[:i])
+				checkOrder(t, right, sorted[i:])
+				checkOrder(t, tr, sorted)
+
+				// The trees don't share any modifications.
+				left.ReplaceOrInsert(-10)
+				right.ReplaceOrInsert(10000)
+				left.DeleteMax()
+				right.DeleteMin()
+				checkOrder(t, tr, sorted)
+
+				// The nodes of right shared by merging them back into left aren't modified in place.
+				left, right = tr.SplitAt(key)
+				left.Merge(right, nil)
+				left.Filter(func(int) bool { return false })
+				checkOrder(t, right, sorted[i:])
+				if err := right.Validate(); err != nil {
+					t.Fatal(err)
+				}
+			}
+		}
+	}
+}
+
+func TestDeleteRange(t *testing.T) {
+	for _, degree := range []int{2, 3, *btreeDegree} {
+		tr, sorted := evens(degree, 2000)
+		for i := 0; i < 100; i++ {
+			lo := rand.Intn(4200) - 100
+			hi := lo + rand.Intn(400)
+			var want []int
+			for _, v := range sorted {
+				if v < lo || v >= hi {
+					want = append(want, v)
+				}
+			}
+			if removed := tr.DeleteRange(lo, hi); removed != len(sorted)-len(want) {
+				t.Fatalf("DeleteRange(%d, %d): want %d, got %d", lo, hi, len(sorted)-len(want), removed)
+			}
+			sorted = want
+			checkOrder(t, tr, sorted)
+		}
+		if removed := tr.DeleteRange(10, 5); removed != 0 {
+			t.Fatalf("DeleteRange on an empty range removed %d items", removed)
+		}
+		if removed := tr.DeleteRange(-1, 5000); removed != len(sorted) || !tr.IsEmpty() {
+			t.Fatalf("failed to delete all the items, removed %d, size %d", removed, tr.Size())
+		}
+	}
+}
+
+func TestMerge(t *testing.T) {
+	for _, degree := range []int{2, 3, *btreeDegree} {
+		// Non-overlapping trees in both orders.
+		tr, sorted := evens(degree, 1000)
+		other := btree.NewOf[int](degree, nil)
+		for v := 2000; v < 2500; v++ {
+			other.ReplaceOrInsert(v)
+		}
+		tr.Merge(other, nil)
+		for v := 2000; v < 2500; v++ {
+			sorted = append(sorted, v)
+		}
+		checkOrder(t, tr, sorted)
+
+		other = btree.NewOf[int](degree, nil)
+		for v := -500; v < 0; v++ {
+			other.ReplaceOrInsert(v)
+		}
+		tr.Merge(other, nil)
+		var want []int
+		for v := -500; v < 0; v++ {
+			want = append(want, v)
+		}
+		sorted = append(want, sorted...)
+		checkOrder(t, tr, sorted)
+
+		// The other tree is left unchanged, and it's independent of the merged tree.
+		other.ReplaceOrInsert(5000)
+		if tr.Has(5000) || other.Size() != 501 {
+			t.Fatal("the merged trees should be independent")
+		}
+
+		// Overlapping trees, the odd numbers are added.
+		other = btree.NewOf[int](degree, nil)
+		for v := 101; v < 1000; v += 2 {
+			other.ReplaceOrInsert(v)
+		}
+		tr.Merge(other, nil)
+		sorted = append(sorted, slices.Collect(other.All())...)
+		slices.Sort(sorted)
+		checkOrder(t, tr, sorted)
+
+		// Merge with an empty tree.
+		tr.Merge(btree.NewOf[int](degree, nil), nil)
+		checkOrder(t, tr, sorted)
+		empty := btree.NewOf[int](degree, nil)
+		empty.Merge(tr, nil)
+		checkOrder(t, empty, sorted)
+	}
+}
+
+func TestMergeDifferentDegrees(t *testing.T) {
+	for _, degrees := range [][2]int{{2, 8}, {8, 2}, {3, *btreeDegree}} {
+		// Non-overlapping trees, and overlapping trees.
+		for _, offset := range []int{1000, 1} {
+			tr, sorted := evens(degrees[0], 500)
+			other := btree.NewOf[int](degrees[1], nil)
+			for v := 0; v < 500; v++ {
+				other.ReplaceOrInsert(2*v + offset)
+				sorted = append(sorted, 2*v+offset)
+			}
+			slices.Sort(sorted)
+			tr.Merge(other, nil)
+			if err := tr.Validate(); err != nil {
+				t.Fatalf("degrees %v, offset %d: %v", degrees, offset, err)
+			}
+			checkOrder(t, tr, sorted)
+		}
+	}
+}
+
+type kv struct {
+	k, v int
+}
+
+func TestMergeConflict(t *testing.T) {
+	cmp := func(a, b kv) int { return a.k - b.k }
+	tr := btree.NewOf(2, cmp)
+	other := btree.NewOf(2, cmp)
+	for k := 0; k < 100; k++ {
+		tr.ReplaceOrInsert(kv{k, 1})
+		other.ReplaceOrInsert(kv{k + 50, 10})
+	}
+
+	// Sum the values of the equal keys.
+	tr.Merge(other, func(existing, incoming kv) kv {
+		return kv{existing.k, existing.v + incoming.v}
+	})
+	if tr.Size() != 150 {
+		t.Fatalf("expected 150 items, got %d", tr.Size())
+	}
+	for item := range tr.All() {
+		want := 1
+		switch {
+		case item.k >= 100:
+			want = 10
+		case item.k >= 50:
+			want = 11
+		}
+		if item.v != want {
+			t.Fatalf("%d: want %d, got %d", item.k, want, item.v)
+		}
+	}
+
+	// By default, the items in the other tree are kept.
+	other = btree.NewOf(2, cmp)
+	other.ReplaceOrInsert(kv{0, 100})
+	other.ReplaceOrInsert(kv{149, 100})
+	tr.Merge(other, nil)
+	if v, _ := tr.Get(kv{k: 0}); v.v != 100 {
+		t.Fatalf("expected 100, got %d", v.v)
+	}
+	if v, _ := tr.Get(kv{k: 149}); v.v != 100 {
+		t.Fatalf("expected 100, got %d", v.v)
+	}
+}
+
+func TestFilter(t *testing.T) {
+	for _, degree := range []int{2, 3, *btreeDegree} {
+		tr, sorted := evens(degree, 1000)
+		clone := tr.Clone()
+		if removed := tr.Filter(func(v int) bool { return v%3 != 0 }); removed != 334 {
+			t.Fatalf("expected to remove 334 items, got %d", removed)
+		}
+		var want []int
+		for _, v := range sorted {
+			if v%3 != 0 {
+				want = append(want, v)
+			}
+		}
+		checkOrder(t, tr, want)
+		checkOrder(t, clone, sorted)
+		if removed := tr.Filter(func(v int) bool { return true }); removed != 0 {
+			t.Fatalf("expected to remove nothing, got %d", removed)
+		}
+		if removed := tr.Filter(func(v int) bool { return false }); removed != len(want) || !tr.IsEmpty() {
+			t.Fatalf("failed to remove all the items, removed %d, size %d", removed, tr.Size())
+		}
+	}
+}
+
+func TestSplitMergeInterface(t *testing.T) {
+	tr := btree.New(*btreeDegree)
+	for _, v := range perm(100) {
+		tr.ReplaceOrInsert(v)
+	}
+	left, right := tr.SplitAt(40)
+	if !reflect.DeepEqual(all(left), rang(40)) || left.Size() != 40 || right.Size() != 60 || tr.Size() != 100 {
+		t.Fatalf("unexpected result of SplitAt: %v, %v", all(left), all(right))
+	}
+	right.Merge(left, nil)
+	if !reflect.DeepEqual(all(right), rang(100)) {
+		t.Fatalf("mismatch:\n got: %v\nwant: %v", all(right), rang(100))
+	}
+
+	if removed := tr.DeleteRange(nil, 10); removed != 10 {
+		t.Fatalf("expected to remove 10 items, got %d", removed)
+	}
+	if removed := tr.DeleteRange(90, nil); removed != 10 {
+		t.Fatalf("expected to remove 10 items, got %d", removed)
+	}
+	if removed := tr.Filter(func(item interface{}) bool { return item.(int)%2 == 0 }); removed != 40 {
+		t.Fatalf("expected to remove 40 items, got %d", removed)
+	}
+	if tr.Size() != 40 || tr.Min() != 10 || tr.Max() != 88 {
+		t.Fatalf("unexpected tree: size %d, min %v, max %v", tr.Size(), tr.Min(), tr.Max())
+	}
+	if removed := tr.DeleteRange(nil, nil); removed != 40 || !tr.IsEmpty() {
+		t.Fatalf("failed to delete all the items, removed %d", removed)
+	}
+}
@@ -0,0 +1,156 @@
+// Copyright (c) 2019, Benjamin Wang (benjamin_wang@aliyun.com). All rights reserved.
+// Licensed under the MIT license that can be found in the LICENSE file.
+
+package btree
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrInvalidTree is returned by Validate when the tree violates any invariant of a btree.
+var ErrInvalidTree = errors.New("btree: invalid tree")
+
+// Validate checks the invariants of the tree, and returns an error wrapping ErrInvalidTree which describes
+// the first violation found, or nil if the tree is valid. It checks that:
+//   - the items are in strictly ascending order, and the comparison function is antisymmetric on the adjacent items;
+//   - each node other than the root has [degree-1, 2*degree-1] items, and the root has at most 2*degree-1 items;
+//   - each internal node has exactly one more child than items, and all the leaves are at the same depth;
+//   - the number of items in each subtree and in the tree is consistent with the tree's bookkeeping.
+//
+// A violation usually means that the comparison function isn't a consistent total ordering, e.g. the
+// ordering of the items has changed after they were added. It takes O(n) time.
+func (t *BTree[T]) Validate() error {
+	if t.root == nil {
+		if t.length != 0 {
+			return fmt.Errorf("%w: no root, but the length is %d", ErrInvalidTree, t.length)
+		}
+		return nil
+	}
+	if len(t.root.items) == 0 && len(t.root.children) > 0 {
+		return fmt.Errorf("%w: the root has %d children but no item", ErrInvalidTree, len(t.root.children))
+	}
+	v := validator[T]{t: t, leafDepth: -1}
+	if err := v.validate(t.root, 0, empty[T](), empty[T]()); err != nil {
+		return err
+	}
+	if t.root.size != t.length {
+		return fmt.Errorf("%w: the length is %d, but the tree has %d items", ErrInvalidTree, t.length, t.root.size)
+	}
+	return nil
+}
+
+// validator validates the nodes of a tree.
+type validator[T any] struct {
+	t         *BTree[T]
+	leafDepth int
+}
+
+// validate validates the subtree rooted at n, at the given depth, whose items must be greater
+// than lo and less than hi.
+func (v *validator[T]) validate(n *node[T], depth int, lo, hi optionalItem[T]) error {
+	t := v.t
+	if len(n.items) > t.maxItems() {
+		return fmt.Errorf("%w: a node at depth %d has %d items, more than %d", ErrInvalidTree, depth, len(n.items), t.maxItems())
+	}
+	if n != t.root && len(n.items) < t.minItems() {
+		return fmt.Errorf("%w: a node at depth %d has %d items, fewer than %d", ErrInvalidTree, depth, len(n.items), t.minItems())
+	}
+	for i, item := range n.items {
+		if i > 0 && (t.cmp(n.items[i-1], item) >= 0 || t.cmp(item, n.items[i-1]) <= 0) {
+			return fmt.Errorf("%w: the items %v and %v at depth %d are out of order", ErrInvalidTree, n.items[i-1], item, depth)
+		}
+	}
+	if len(n.items) > 0 {
+		if first := n.items[0]; lo.valid && t.cmp(lo.item, first) >= 0 {
+			return fmt.Errorf("%w: the item %v at depth %d isn't greater than its ancestor %v", ErrInvalidTree, first, depth, lo.item)
+		}
+		if last := n.items[len(n.items)-1]; hi.valid && t.cmp(last, hi.item) >= 0 {
+			return fmt.Errorf("%w: the item %v at depth %d isn't less than its ancestor %v", ErrInvalidTree, last, depth, hi.item)
+		}
+	}
+
+	size := len(n.items)
+	if len(n.children) == 0 {
+		if v.leafDepth < 0 {
+			v.leafDepth = depth
+		} else if v.leafDepth != depth {
+			return fmt.Errorf("%w: the leaves are at both depth %d and %d", ErrInvalidTree, v.leafDepth, depth)
+		}
+	} else {
+		if len(n.children) != len(n.items)+1 {
+			return fmt.Errorf("%w: a node at depth %d has %d items but %d children", ErrInvalidTree, depth, len(n.items), len(n.children))
+		}
+		for i, c := range n.children {
+			clo, chi := lo, hi
+			if i > 0 {
+				clo = optional(n.items[i-1])
+			}
+			if i < len(n.items) {
+				chi = optional(n.items[i])
+			}
+			if err := v.validate(c, depth+1, clo, chi); err != nil {
+				return err
+			}
+			size += c.size
+		}
+	}
+	if n.size != size {
+		return fmt.Errorf("%w: a node at depth %d records %d items in its subtree, but has %d", ErrInvalidTree, depth, n.size, size)
+	}
+	return nil
+}
+
+// Stats describes the structure of a btree.
+type Stats struct {
+	// Height is the number of levels of the tree, 0 for an empty tree.
+	Height int
+	// Items is the number of items in the tree.
+	Items int
+	// Nodes is the number of nodes in the tree, and Leaves is the number of leaf nodes.
+	Nodes  int
+	Leaves int
+	// FillFactor is the average number of items per node, divided by the max number of items per node.
+	FillFactor float64
+	// OwnedNodes is the number of nodes which the tree can modify in place. The other nodes, SharedNodes,
+	// were created before the tree or the tree it's cloned from was cloned, and they may be shared with
+	// the clones, so they're copied before being modified.
+	OwnedNodes  int
+	SharedNodes int
+	// FreeListSize is the number of nodes in the free list used by the tree, and FreeListCapacity is
+	// the max number of nodes the free list can hold.
+	FreeListSize     int
+	FreeListCapacity int
+}
+
+// Stats returns the statistics of the structure of the tree. It takes O(n) time.
+func (t *BTree[T]) Stats() Stats {
+	var s Stats
+	if t.root != nil {
+		s.Height = heightOf(t.root) + 1
+		t.root.stats(t.cow, &s)
+	}
+	if s.Nodes > 0 {
+		s.FillFactor = float64(s.Items) / float64(s.Nodes*t.maxItems())
+	}
+	fs := t.cow.freelist.Stats()
+	s.FreeListSize, s.FreeListCapacity = fs.Size, fs.Capacity
+	return s
+}
+
+// stats adds the statistics of the subtree rooted at n to s.
+func (n *node[T]) stats(cow *copyOnWriteContext[T], s *Stats) {
+	s.Nodes++
+	s.Items += len(n.items)
+	if len(n.children) == 0 {
+		s.Leaves++
+	}
+	if n.cow == cow {
+		s.OwnedNodes++
+	} else {
+		s.SharedNodes++
+	}
+	for _, c := range n.children {
+		c.stats(cow, s)
+	}
+}
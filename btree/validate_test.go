@@ -0,0 +1,117 @@
+// Copyright (c) 2019, Benjamin Wang (benjamin_wang@aliyun.com). All rights reserved.
+// Licensed under the MIT license that can be found in the LICENSE file.
+
+package btree_test
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+
+	"github.com/ahrtr/gocontainer/btree"
+)
+
+func TestValidate(t *testing.T) {
+	for _, degree := range []int{2, 3, *btreeDegree} {
+		tr := btree.NewOf[int](degree, nil)
+		if err := tr.Validate(); err != nil {
+			t.Fatalf("degree %d: unexpected error for an empty tree: %v", degree, err)
+		}
+		for i := 0; i < 5000; i++ {
+			v := rand.Intn(2000)
+			if rand.Intn(3) == 0 {
+				tr.Delete(v)
+			} else {
+				tr.ReplaceOrInsert(v)
+			}
+			if i%500 == 0 {
+				if err := tr.Validate(); err != nil {
+					t.Fatalf("degree %d: unexpected error: %v", degree, err)
+				}
+			}
+		}
+
+		// The structural operations keep the tree valid.
+		left, right := tr.SplitAt(1000)
+		right.DeleteRange(1200, 1700)
+		left.Merge(right, nil)
+		left.Filter(func(item int) bool { return item%7 != 0 })
+		for _, x := range []*btree.BTree[int]{tr, left, right} {
+			if err := x.Validate(); err != nil {
+				t.Fatalf("degree %d: unexpected error: %v", degree, err)
+			}
+		}
+	}
+}
+
+func TestValidateInconsistentComparator(t *testing.T) {
+	tr := btree.NewOf[int](2, nil)
+	for v := 0; v < 100; v++ {
+		tr.ReplaceOrInsert(v)
+	}
+	// The ordering changes after the items are added.
+	tr.WithComparator(func(a, b int) int { return b - a })
+	if err := tr.Validate(); !errors.Is(err, btree.ErrInvalidTree) {
+		t.Fatalf("expected ErrInvalidTree, got %v", err)
+	}
+
+	// The comparator isn't antisymmetric.
+	tr.WithComparator(func(a, b int) int { return -1 })
+	if err := tr.Validate(); !errors.Is(err, btree.ErrInvalidTree) {
+		t.Fatalf("expected ErrInvalidTree, got %v", err)
+	}
+
+	itr := btree.New(2)
+	for _, v := range perm(100) {
+		itr.ReplaceOrInsert(v)
+	}
+	if err := itr.Validate(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	itr.WithComparator(descendingInt{})
+	if err := itr.Validate(); !errors.Is(err, btree.ErrInvalidTree) {
+		t.Fatalf("expected ErrInvalidTree, got %v", err)
+	}
+}
+
+func TestStats(t *testing.T) {
+	tr := btree.NewOf[int](2, nil)
+	if s := tr.Stats(); s != (btree.Stats{FreeListCapacity: btree.DefaultFreeListSize}) {
+		t.Fatalf("unexpected stats of an empty tree: %+v", s)
+	}
+	for v := 0; v < 1000; v++ {
+		tr.ReplaceOrInsert(v)
+	}
+	s := tr.Stats()
+	if s.Items != 1000 || s.Height < 5 || s.Height > 10 || s.Leaves >= s.Nodes || s.SharedNodes != 0 || s.OwnedNodes != s.Nodes {
+		t.Fatalf("unexpected stats: %+v", s)
+	}
+	if s.FillFactor <= 1.0/3 || s.FillFactor > 1 {
+		t.Fatalf("unexpected fill factor: %f", s.FillFactor)
+	}
+
+	// All the nodes are shared after Clone, and the modified path is owned.
+	clone := tr.Clone()
+	if cs := clone.Stats(); cs.SharedNodes != s.Nodes || cs.OwnedNodes != 0 {
+		t.Fatalf("unexpected stats of the clone: %+v", cs)
+	}
+	clone.Delete(0)
+	if cs := clone.Stats(); cs.OwnedNodes != cs.Height || cs.OwnedNodes+cs.SharedNodes != cs.Nodes {
+		t.Fatalf("unexpected stats of the modified clone: %+v", cs)
+	}
+	if s2 := tr.Stats(); s2.SharedNodes != s.Nodes {
+		t.Fatalf("unexpected stats of the original tree: %+v", s2)
+	}
+
+	// The nodes are returned to the free list.
+	tr2 := btree.NewOf[int](2, nil)
+	for v := 0; v < 100; v++ {
+		tr2.ReplaceOrInsert(v)
+	}
+	for v := 0; v < 100; v++ {
+		tr2.Delete(v)
+	}
+	if s := tr2.Stats(); s.FreeListSize == 0 || s.FreeListSize > s.FreeListCapacity {
+		t.Fatalf("unexpected free list stats: %+v", s)
+	}
+}
@@ -0,0 +1,292 @@
+// Copyright (c) 2019, Benjamin Wang (benjamin_wang@aliyun.com). All rights reserved.
+// Licensed under the MIT license that can be found in the LICENSE file.
+
+package concurrent
+
+import (
+	"io"
+	"iter"
+	"sync"
+
+	"github.com/ahrtr/gocontainer/btree"
+	"github.com/ahrtr/gocontainer/utils"
+)
+
+// BTree is a thread-safe btree.
+type BTree interface {
+	btree.Interface
+
+	// Do calls f with the wrapped btree while holding the write lock, so that the operations
+	// performed by f are atomic. f must not use the BTree itself, otherwise it deadlocks.
+	Do(f func(t btree.Interface))
+}
+
+// syncBTree implements the BTree interface.
+type syncBTree struct {
+	mu sync.RWMutex
+	t  btree.Interface
+}
+
+// NewBTree returns a thread-safe btree backed by the specified btree.
+func NewBTree(t btree.Interface) BTree {
+	return &syncBTree{t: t}
+}
+
+func (st *syncBTree) WithComparator(c utils.Comparator) btree.Interface {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	st.t.WithComparator(c)
+	return st
+}
+
+// Clone clones the btree lazily, and returns a new thread-safe btree.
+func (st *syncBTree) Clone() btree.Interface {
+	return NewBTree(st.snapshot())
+}
+
+func (st *syncBTree) Size() int {
+	st.mu.RLock()
+	defer st.mu.RUnlock()
+	return st.t.Size()
+}
+
+func (st *syncBTree) IsEmpty() bool {
+	st.mu.RLock()
+	defer st.mu.RUnlock()
+	return st.t.IsEmpty()
+}
+
+func (st *syncBTree) Clear() {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	st.t.Clear()
+}
+
+func (st *syncBTree) ReplaceOrInsert(item interface{}) interface{} {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	return st.t.ReplaceOrInsert(item)
+}
+
+func (st *syncBTree) Delete(item interface{}) interface{} {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	return st.t.Delete(item)
+}
+
+func (st *syncBTree) DeleteMin() interface{} {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	return st.t.DeleteMin()
+}
+
+func (st *syncBTree) DeleteMax() interface{} {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	return st.t.DeleteMax()
+}
+
+func (st *syncBTree) AscendRange(greaterOrEqual, lessThan interface{}, iterator btree.ItemIterator) {
+	st.snapshot().AscendRange(greaterOrEqual, lessThan, iterator)
+}
+
+func (st *syncBTree) AscendLessThan(pivot interface{}, iterator btree.ItemIterator) {
+	st.snapshot().AscendLessThan(pivot, iterator)
+}
+
+func (st *syncBTree) AscendGreaterOrEqual(pivot interface{}, iterator btree.ItemIterator) {
+	st.snapshot().AscendGreaterOrEqual(pivot, iterator)
+}
+
+func (st *syncBTree) Ascend(iterator btree.ItemIterator) {
+	st.snapshot().Ascend(iterator)
+}
+
+func (st *syncBTree) DescendRange(lessOrEqual, greaterThan interface{}, iterator btree.ItemIterator) {
+	st.snapshot().DescendRange(lessOrEqual, greaterThan, iterator)
+}
+
+func (st *syncBTree) DescendLessOrEqual(pivot interface{}, iterator btree.ItemIterator) {
+	st.snapshot().DescendLessOrEqual(pivot, iterator)
+}
+
+func (st *syncBTree) DescendGreaterThan(pivot interface{}, iterator btree.ItemIterator) {
+	st.snapshot().DescendGreaterThan(pivot, iterator)
+}
+
+func (st *syncBTree) Descend(iterator btree.ItemIterator) {
+	st.snapshot().Descend(iterator)
+}
+
+func (st *syncBTree) All() iter.Seq[interface{}] {
+	return st.seq(func(t btree.Interface) iter.Seq[interface{}] { return t.All() })
+}
+
+func (st *syncBTree) AllRange(greaterOrEqual, lessThan interface{}) iter.Seq[interface{}] {
+	return st.seq(func(t btree.Interface) iter.Seq[interface{}] { return t.AllRange(greaterOrEqual, lessThan) })
+}
+
+func (st *syncBTree) AllLessThan(pivot interface{}) iter.Seq[interface{}] {
+	return st.seq(func(t btree.Interface) iter.Seq[interface{}] { return t.AllLessThan(pivot) })
+}
+
+func (st *syncBTree) AllGreaterOrEqual(pivot interface{}) iter.Seq[interface{}] {
+	return st.seq(func(t btree.Interface) iter.Seq[interface{}] { return t.AllGreaterOrEqual(pivot) })
+}
+
+func (st *syncBTree) Backward() iter.Seq[interface{}] {
+	return st.seq(func(t btree.Interface) iter.Seq[interface{}] { return t.Backward() })
+}
+
+func (st *syncBTree) BackwardRange(lessOrEqual, greaterThan interface{}) iter.Seq[interface{}] {
+	return st.seq(func(t btree.Interface) iter.Seq[interface{}] { return t.BackwardRange(lessOrEqual, greaterThan) })
+}
+
+func (st *syncBTree) BackwardLessOrEqual(pivot interface{}) iter.Seq[interface{}] {
+	return st.seq(func(t btree.Interface) iter.Seq[interface{}] { return t.BackwardLessOrEqual(pivot) })
+}
+
+func (st *syncBTree) BackwardGreaterThan(pivot interface{}) iter.Seq[interface{}] {
+	return st.seq(func(t btree.Interface) iter.Seq[interface{}] { return t.BackwardGreaterThan(pivot) })
+}
+
+func (st *syncBTree) Get(key interface{}) interface{} {
+	st.mu.RLock()
+	defer st.mu.RUnlock()
+	return st.t.Get(key)
+}
+
+func (st *syncBTree) Min() interface{} {
+	st.mu.RLock()
+	defer st.mu.RUnlock()
+	return st.t.Min()
+}
+
+func (st *syncBTree) Max() interface{} {
+	st.mu.RLock()
+	defer st.mu.RUnlock()
+	return st.t.Max()
+}
+
+func (st *syncBTree) Has(key interface{}) bool {
+	st.mu.RLock()
+	defer st.mu.RUnlock()
+	return st.t.Has(key)
+}
+
+func (st *syncBTree) Select(k int) interface{} {
+	st.mu.RLock()
+	defer st.mu.RUnlock()
+	return st.t.Select(k)
+}
+
+func (st *syncBTree) Rank(item interface{}) int {
+	st.mu.RLock()
+	defer st.mu.RUnlock()
+	return st.t.Rank(item)
+}
+
+func (st *syncBTree) CountRange(greaterOrEqual, lessThan interface{}) int {
+	st.mu.RLock()
+	defer st.mu.RUnlock()
+	return st.t.CountRange(greaterOrEqual, lessThan)
+}
+
+func (st *syncBTree) DeleteAt(k int) interface{} {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	return st.t.DeleteAt(k)
+}
+
+func (st *syncBTree) SplitAt(key interface{}) (left, right btree.Interface) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	l, r := st.t.SplitAt(key)
+	return NewBTree(l), NewBTree(r)
+}
+
+// Merge calls conflict while holding the write lock, so conflict must not use the BTree itself.
+func (st *syncBTree) Merge(other btree.Interface, conflict func(existing, incoming interface{}) interface{}) {
+	// A thread-safe btree is copied under its own lock, so that two locks are never held at the same time.
+	if o, ok := other.(*syncBTree); ok {
+		other = o.snapshot()
+	}
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	st.t.Merge(other, conflict)
+}
+
+func (st *syncBTree) DeleteRange(greaterOrEqual, lessThan interface{}) int {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	return st.t.DeleteRange(greaterOrEqual, lessThan)
+}
+
+// Filter calls pred while holding the write lock, so pred must not use the BTree itself.
+func (st *syncBTree) Filter(pred func(item interface{}) bool) int {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	return st.t.Filter(pred)
+}
+
+func (st *syncBTree) ClearAndRecycle() {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	st.t.ClearAndRecycle()
+}
+
+func (st *syncBTree) Validate() error {
+	st.mu.RLock()
+	defer st.mu.RUnlock()
+	return st.t.Validate()
+}
+
+func (st *syncBTree) Stats() btree.Stats {
+	st.mu.RLock()
+	defer st.mu.RUnlock()
+	return st.t.Stats()
+}
+
+func (st *syncBTree) WriteDOT(w io.Writer, opts *btree.RenderOptions) error {
+	st.mu.RLock()
+	defer st.mu.RUnlock()
+	return st.t.WriteDOT(w, opts)
+}
+
+func (st *syncBTree) Print(w io.Writer, opts *btree.RenderOptions) error {
+	st.mu.RLock()
+	defer st.mu.RUnlock()
+	return st.t.Print(w, opts)
+}
+
+// Cursor returns a read-only cursor over a snapshot of the btree taken when Cursor is called, so the
+// modifications to the btree afterwards aren't visible to the cursor, and Delete of the cursor panics with
+// btree.ErrReadOnlyCursor. Call Cursor on the wrapped btree within Do in order to modify the btree using a cursor.
+func (st *syncBTree) Cursor() *btree.Cursor {
+	return st.snapshot().Cursor().ReadOnly()
+}
+
+func (st *syncBTree) Do(f func(t btree.Interface)) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	f(st.t)
+}
+
+// snapshot clones the btree lazily. The write lock is needed because
+// Clone changes the copy-on-write context of the wrapped btree.
+func (st *syncBTree) snapshot() btree.Interface {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	return st.t.Clone()
+}
+
+// seq returns an iterator which ranges over a snapshot taken when the iteration starts.
+func (st *syncBTree) seq(f func(t btree.Interface) iter.Seq[interface{}]) iter.Seq[interface{}] {
+	return func(yield func(interface{}) bool) {
+		for item := range f(st.snapshot()) {
+			if !yield(item) {
+				return
+			}
+		}
+	}
+}
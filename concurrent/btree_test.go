@@ -0,0 +1,147 @@
+// Copyright (c) 2019, Benjamin Wang (benjamin_wang@aliyun.com). All rights reserved.
+// Licensed under the MIT license that can be found in the LICENSE file.
+
+package concurrent_test
+
+import (
+	"bytes"
+	"sync"
+	"testing"
+
+	"github.com/ahrtr/gocontainer/btree"
+	"github.com/ahrtr/gocontainer/concurrent"
+)
+
+func TestBTreeConcurrentOperations(t *testing.T) {
+	tr := concurrent.NewBTree(btree.New(2))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				tr.ReplaceOrInsert(i*100 + j)
+			}
+		}(i)
+		go func() {
+			defer wg.Done()
+			prev := -1
+			for item := range tr.All() {
+				if item.(int) <= prev {
+					t.Errorf("The items aren't in ascending order, %d <= %d", item, prev)
+				}
+				prev = item.(int)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if tr.Size() != 1000 {
+		t.Errorf("The length isn't expected, expect: 1000, actual: %d\n", tr.Size())
+	}
+
+	// the btree can be modified while it's being iterated.
+	tr.Ascend(func(item interface{}) bool {
+		tr.Delete(item)
+		return true
+	})
+	if !tr.IsEmpty() {
+		t.Errorf("The btree should be empty, actual length: %d\n", tr.Size())
+	}
+}
+
+func TestBTreeCursor(t *testing.T) {
+	tr := concurrent.NewBTree(btree.New(2))
+	for i := 0; i < 10; i++ {
+		tr.ReplaceOrInsert(i)
+	}
+
+	// The cursor ranges over a snapshot, so the btree can be modified meanwhile.
+	c := tr.Cursor()
+	count := 0
+	for ok := c.First(); ok; ok = c.Next() {
+		tr.Delete(c.Item())
+		count++
+	}
+	if count != 10 || !tr.IsEmpty() {
+		t.Errorf("Unexpected result, count: %d, length: %d\n", count, tr.Size())
+	}
+
+	// The cursor can't delete the items from the snapshot.
+	tr.ReplaceOrInsert(1)
+	func() {
+		defer func() {
+			if r := recover(); r != btree.ErrReadOnlyCursor {
+				t.Errorf("Expected ErrReadOnlyCursor, got %v\n", r)
+			}
+		}()
+		c := tr.Cursor()
+		c.First()
+		c.Delete()
+	}()
+	tr.Delete(1)
+
+	// Modify the btree using a cursor within Do.
+	for i := 0; i < 10; i++ {
+		tr.ReplaceOrInsert(i)
+	}
+	tr.Do(func(t btree.Interface) {
+		c := t.Cursor()
+		for c.Seek(5); c.Valid(); {
+			c.Delete()
+		}
+	})
+	if tr.Size() != 5 || tr.Max() != 4 {
+		t.Errorf("Unexpected result, length: %d, max: %v\n", tr.Size(), tr.Max())
+	}
+}
+
+func TestBTreeMerge(t *testing.T) {
+	tr := concurrent.NewBTree(btree.New(2))
+	other := concurrent.NewBTree(btree.New(2))
+	for i := 0; i < 100; i++ {
+		tr.ReplaceOrInsert(i)
+		other.ReplaceOrInsert(i + 50)
+	}
+	tr.Merge(other, nil)
+	// Merging a btree into itself must not deadlock.
+	tr.Merge(tr, nil)
+	if tr.Size() != 150 || other.Size() != 100 {
+		t.Errorf("Unexpected sizes: %d, %d\n", tr.Size(), other.Size())
+	}
+
+	left, right := tr.SplitAt(75)
+	if left.Size() != 75 || right.Size() != 75 {
+		t.Errorf("Unexpected sizes: %d, %d\n", left.Size(), right.Size())
+	}
+	if removed := right.DeleteRange(100, nil); removed != 50 {
+		t.Errorf("Unexpected number of removed items: %d\n", removed)
+	}
+}
+
+func TestBTreeSnapshot(t *testing.T) {
+	tr := concurrent.NewBTree(btree.New(3))
+	for i := 0; i < 1000; i++ {
+		tr.ReplaceOrInsert(i)
+	}
+
+	var buf bytes.Buffer
+	if _, err := btree.WriteTo(&buf, tr, btree.GobCodecOf[interface{}]()); err != nil {
+		t.Fatal(err)
+	}
+	got, err := btree.ReadFrom(&buf, btree.GobCodecOf[interface{}](), 0, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The snapshot records the degree of the wrapped btree, so the btree read back has the same shape.
+	items := make([]interface{}, 1000)
+	for i := range items {
+		items[i] = i
+	}
+	want, _ := btree.BuildFromSorted(3, items, nil)
+	if got.Size() != 1000 || got.Stats().Nodes != want.Stats().Nodes {
+		t.Errorf("Unexpected btree, length: %d, nodes: %d, expected nodes: %d\n", got.Size(), got.Stats().Nodes, want.Stats().Nodes)
+	}
+}
@@ -0,0 +1,23 @@
+// Copyright (c) 2019, Benjamin Wang (benjamin_wang@aliyun.com). All rights reserved.
+// Licensed under the MIT license that can be found in the LICENSE file.
+
+// Package concurrent implements thread-safe wrappers of the containers in this repository.
+//
+// Each wrapper guards the wrapped container with a sync.RWMutex. The read operations share the
+// read lock, while the write operations, including the ones which change the internal state
+// implicitly (e.g. Get on an access-order linked map, or Clone on a btree), hold the write lock.
+//
+// Each single method call is atomic. Use Do to perform a compound operation atomically:
+//	l.Do(func(l list.Interface) {
+//		if !l.Contains(5) {
+//			l.Add(5)
+//		}
+//	})
+//
+// The iterators (Iterator, All, Iterate, Ascend, etc.) range over a snapshot taken when the
+// iteration starts, so they never hold the lock while the caller's code runs, and the
+// container can be accessed or modified during the iteration. The snapshot is a copy of
+// the elements, except for the btree, whose snapshot is a lazy Clone.
+//
+// The wrapped container shouldn't be accessed directly once it's wrapped.
+package concurrent
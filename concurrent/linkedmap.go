@@ -0,0 +1,257 @@
+// Copyright (c) 2019, Benjamin Wang (benjamin_wang@aliyun.com). All rights reserved.
+// Licensed under the MIT license that can be found in the LICENSE file.
+
+package concurrent
+
+import (
+	"iter"
+	"sync"
+	"time"
+
+	"github.com/ahrtr/gocontainer/map/linkedmap"
+)
+
+// LinkedMap is a thread-safe linked map.
+type LinkedMap interface {
+	linkedmap.Interface
+
+	// Do calls f with the wrapped linked map while holding the write lock, so that the operations
+	// performed by f are atomic. f must not use the LinkedMap itself, otherwise it deadlocks.
+	Do(f func(lm linkedmap.Interface))
+}
+
+// syncLinkedMap implements the LinkedMap interface.
+type syncLinkedMap struct {
+	mu sync.RWMutex
+	lm linkedmap.Interface
+}
+
+// entry is a key-value pair copied from a linked map.
+type entry struct {
+	k, v interface{}
+}
+
+// NewLinkedMap returns a thread-safe linked map backed by the specified linked map.
+func NewLinkedMap(lm linkedmap.Interface) LinkedMap {
+	return &syncLinkedMap{lm: lm}
+}
+
+// lockForLookup acquires the lock for the methods which look up a key, and returns the function to release it.
+// Looking up a key may remove an expired element, and Get/GetOrDefault (reorder is true) move the element to
+// the end of the list for an access-order map, so the write lock is needed in those cases.
+func (slm *syncLinkedMap) lockForLookup(reorder bool) func() {
+	slm.mu.RLock()
+	// Neither the access order nor the expiring elements can be changed while the read lock is being held.
+	if !slm.lm.Expiring() && !(reorder && slm.lm.AccessOrder()) {
+		return slm.mu.RUnlock
+	}
+	slm.mu.RUnlock()
+	slm.mu.Lock()
+	return slm.mu.Unlock
+}
+
+func (slm *syncLinkedMap) Size() int {
+	slm.mu.RLock()
+	defer slm.mu.RUnlock()
+	return slm.lm.Size()
+}
+
+func (slm *syncLinkedMap) IsEmpty() bool {
+	slm.mu.RLock()
+	defer slm.mu.RUnlock()
+	return slm.lm.IsEmpty()
+}
+
+func (slm *syncLinkedMap) Clear() {
+	slm.mu.Lock()
+	defer slm.mu.Unlock()
+	slm.lm.Clear()
+}
+
+func (slm *syncLinkedMap) Put(k, v interface{}) interface{} {
+	slm.mu.Lock()
+	defer slm.mu.Unlock()
+	return slm.lm.Put(k, v)
+}
+
+func (slm *syncLinkedMap) WithAccessOrder(accessOrder bool) linkedmap.Interface {
+	slm.mu.Lock()
+	defer slm.mu.Unlock()
+	slm.lm.WithAccessOrder(accessOrder)
+	return slm
+}
+
+func (slm *syncLinkedMap) AccessOrder() bool {
+	slm.mu.RLock()
+	defer slm.mu.RUnlock()
+	return slm.lm.AccessOrder()
+}
+
+func (slm *syncLinkedMap) WithDefaultTTL(ttl time.Duration) linkedmap.Interface {
+	slm.mu.Lock()
+	defer slm.mu.Unlock()
+	slm.lm.WithDefaultTTL(ttl)
+	return slm
+}
+
+func (slm *syncLinkedMap) WithClock(now func() time.Time) linkedmap.Interface {
+	slm.mu.Lock()
+	defer slm.mu.Unlock()
+	slm.lm.WithClock(now)
+	return slm
+}
+
+// WithOnExpire sets a callback, which is called while holding the write lock, so f must not use the LinkedMap itself.
+func (slm *syncLinkedMap) WithOnExpire(f func(k, v interface{})) linkedmap.Interface {
+	slm.mu.Lock()
+	defer slm.mu.Unlock()
+	slm.lm.WithOnExpire(f)
+	return slm
+}
+
+func (slm *syncLinkedMap) PutWithTTL(k, v interface{}, ttl time.Duration) interface{} {
+	slm.mu.Lock()
+	defer slm.mu.Unlock()
+	return slm.lm.PutWithTTL(k, v, ttl)
+}
+
+func (slm *syncLinkedMap) PurgeExpired() int {
+	slm.mu.Lock()
+	defer slm.mu.Unlock()
+	return slm.lm.PurgeExpired()
+}
+
+func (slm *syncLinkedMap) Expiring() bool {
+	slm.mu.RLock()
+	defer slm.mu.RUnlock()
+	return slm.lm.Expiring()
+}
+
+func (slm *syncLinkedMap) Get(k interface{}) interface{} {
+	defer slm.lockForLookup(true)()
+	return slm.lm.Get(k)
+}
+
+func (slm *syncLinkedMap) GetOrDefault(k, defaultValue interface{}) interface{} {
+	defer slm.lockForLookup(true)()
+	return slm.lm.GetOrDefault(k, defaultValue)
+}
+
+func (slm *syncLinkedMap) Peek(k interface{}) (interface{}, bool) {
+	defer slm.lockForLookup(false)()
+	return slm.lm.Peek(k)
+}
+
+func (slm *syncLinkedMap) GetFirstElement() (interface{}, interface{}, bool) {
+	slm.mu.RLock()
+	defer slm.mu.RUnlock()
+	return slm.lm.GetFirstElement()
+}
+
+func (slm *syncLinkedMap) GetLastElement() (interface{}, interface{}, bool) {
+	slm.mu.RLock()
+	defer slm.mu.RUnlock()
+	return slm.lm.GetLastElement()
+}
+
+func (slm *syncLinkedMap) ContainsKey(k interface{}) bool {
+	defer slm.lockForLookup(false)()
+	return slm.lm.ContainsKey(k)
+}
+
+func (slm *syncLinkedMap) ContainsValue(v interface{}) bool {
+	slm.mu.RLock()
+	defer slm.mu.RUnlock()
+	return slm.lm.ContainsValue(v)
+}
+
+// ContainsValueFunc calls pred while holding the read lock, so pred must not use the LinkedMap itself.
+func (slm *syncLinkedMap) ContainsValueFunc(pred func(v interface{}) bool) bool {
+	slm.mu.RLock()
+	defer slm.mu.RUnlock()
+	return slm.lm.ContainsValueFunc(pred)
+}
+
+func (slm *syncLinkedMap) Remove(k interface{}) (interface{}, bool) {
+	slm.mu.Lock()
+	defer slm.mu.Unlock()
+	return slm.lm.Remove(k)
+}
+
+func (slm *syncLinkedMap) RemoveFirstElement() (interface{}, interface{}, bool) {
+	slm.mu.Lock()
+	defer slm.mu.Unlock()
+	return slm.lm.RemoveFirstElement()
+}
+
+func (slm *syncLinkedMap) RemoveLastElement() (interface{}, interface{}, bool) {
+	slm.mu.Lock()
+	defer slm.mu.Unlock()
+	return slm.lm.RemoveLastElement()
+}
+
+func (slm *syncLinkedMap) Iterator() (func() (interface{}, interface{}, bool), bool) {
+	entries := slm.snapshot()
+	index := 0
+
+	return func() (interface{}, interface{}, bool) {
+		var k, v interface{}
+		if index < len(entries) {
+			k, v = entries[index].k, entries[index].v
+			index++
+		}
+		return k, v, index < len(entries)
+	}, index < len(entries)
+}
+
+func (slm *syncLinkedMap) ReverseIterator() (func() (interface{}, interface{}, bool), bool) {
+	entries := slm.snapshot()
+	index := len(entries) - 1
+
+	return func() (interface{}, interface{}, bool) {
+		var k, v interface{}
+		if index >= 0 {
+			k, v = entries[index].k, entries[index].v
+			index--
+		}
+		return k, v, index >= 0
+	}, index >= 0
+}
+
+func (slm *syncLinkedMap) All() iter.Seq2[interface{}, interface{}] {
+	return func(yield func(interface{}, interface{}) bool) {
+		for _, e := range slm.snapshot() {
+			if !yield(e.k, e.v) {
+				return
+			}
+		}
+	}
+}
+
+func (slm *syncLinkedMap) Backward() iter.Seq2[interface{}, interface{}] {
+	return func(yield func(interface{}, interface{}) bool) {
+		entries := slm.snapshot()
+		for i := len(entries) - 1; i >= 0; i-- {
+			if !yield(entries[i].k, entries[i].v) {
+				return
+			}
+		}
+	}
+}
+
+func (slm *syncLinkedMap) Do(f func(lm linkedmap.Interface)) {
+	slm.mu.Lock()
+	defer slm.mu.Unlock()
+	f(slm.lm)
+}
+
+// snapshot copies all the key-value pairs into a slice in proper sequence.
+func (slm *syncLinkedMap) snapshot() []entry {
+	slm.mu.RLock()
+	defer slm.mu.RUnlock()
+	entries := make([]entry, 0, slm.lm.Size())
+	for k, v := range slm.lm.All() {
+		entries = append(entries, entry{k, v})
+	}
+	return entries
+}
@@ -0,0 +1,98 @@
+// Copyright (c) 2019, Benjamin Wang (benjamin_wang@aliyun.com). All rights reserved.
+// Licensed under the MIT license that can be found in the LICENSE file.
+
+package concurrent_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ahrtr/gocontainer/concurrent"
+	"github.com/ahrtr/gocontainer/map/linkedmap"
+)
+
+func TestLinkedMapAccessOrderGet(t *testing.T) {
+	lm := concurrent.NewLinkedMap(linkedmap.New().WithAccessOrder(true))
+	for i := 0; i < 100; i++ {
+		lm.Put(i, i)
+	}
+
+	// Get moves the element to the end of the list for an access-order map,
+	// so it must be protected by the write lock.
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				lm.Get((i + j) % 100)
+				lm.GetOrDefault(j, -1)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if lm.Size() != 100 {
+		t.Errorf("The length isn't expected, expect: 100, actual: %d\n", lm.Size())
+	}
+	count := 0
+	for range lm.All() {
+		count++
+	}
+	if count != 100 {
+		t.Errorf("The count of iterated elements isn't expected, expect: 100, actual: %d\n", count)
+	}
+
+	lm.Get(0)
+	if k, _, _ := lm.GetLastElement(); k != 0 {
+		t.Errorf("The last element isn't expected, expect: 0, actual: %v\n", k)
+	}
+}
+
+func TestLinkedMapIterator(t *testing.T) {
+	lm := concurrent.NewLinkedMap(linkedmap.New())
+	lm.Put(24, "benjamin")
+	lm.Put(43, "alice")
+
+	it, hasNext := lm.Iterator()
+	var k, v interface{}
+	keys := []interface{}{}
+	for hasNext {
+		k, v, hasNext = it()
+		keys = append(keys, k)
+		lm.Remove(k)
+		if v == nil {
+			t.Errorf("The value of key %v shouldn't be nil\n", k)
+		}
+	}
+	if len(keys) != 2 || keys[0] != 24 || keys[1] != 43 {
+		t.Errorf("The keys aren't expected, expect: [24 43], actual: %v\n", keys)
+	}
+}
+
+func TestLinkedMapExpiringLookup(t *testing.T) {
+	lm := concurrent.NewLinkedMap(linkedmap.New())
+	for i := 0; i < 100; i++ {
+		lm.PutWithTTL(i, i, time.Millisecond)
+	}
+	time.Sleep(2 * time.Millisecond)
+
+	// looking up an expired key removes it, so it must be protected by the write lock.
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				lm.ContainsKey(j)
+				lm.Peek(j)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if !lm.IsEmpty() {
+		t.Errorf("All the keys should have expired, length: %d\n", lm.Size())
+	}
+}
@@ -0,0 +1,250 @@
+// Copyright (c) 2019, Benjamin Wang (benjamin_wang@aliyun.com). All rights reserved.
+// Licensed under the MIT license that can be found in the LICENSE file.
+
+package concurrent
+
+import (
+	"iter"
+	"sync"
+
+	"github.com/ahrtr/gocontainer/list"
+	"github.com/ahrtr/gocontainer/utils"
+)
+
+// List is a thread-safe list.
+type List interface {
+	list.Interface
+
+	// Do calls f with the wrapped list while holding the write lock, so that the operations
+	// performed by f are atomic. f must not use the List itself, otherwise it deadlocks.
+	Do(f func(l list.Interface))
+}
+
+// syncList implements the List interface.
+type syncList struct {
+	mu sync.RWMutex
+	l  list.Interface
+}
+
+// NewList returns a thread-safe list backed by the specified list.
+func NewList(l list.Interface) List {
+	return &syncList{l: l}
+}
+
+func (sl *syncList) Size() int {
+	sl.mu.RLock()
+	defer sl.mu.RUnlock()
+	return sl.l.Size()
+}
+
+func (sl *syncList) IsEmpty() bool {
+	sl.mu.RLock()
+	defer sl.mu.RUnlock()
+	return sl.l.IsEmpty()
+}
+
+func (sl *syncList) Clear() {
+	sl.mu.Lock()
+	defer sl.mu.Unlock()
+	sl.l.Clear()
+}
+
+func (sl *syncList) Add(vals ...interface{}) {
+	sl.mu.Lock()
+	defer sl.mu.Unlock()
+	sl.l.Add(vals...)
+}
+
+func (sl *syncList) AddTo(index int, val interface{}) error {
+	sl.mu.Lock()
+	defer sl.mu.Unlock()
+	return sl.l.AddTo(index, val)
+}
+
+func (sl *syncList) AddAllAt(index int, vals ...interface{}) error {
+	sl.mu.Lock()
+	defer sl.mu.Unlock()
+	return sl.l.AddAllAt(index, vals...)
+}
+
+func (sl *syncList) Contains(val interface{}) bool {
+	sl.mu.RLock()
+	defer sl.mu.RUnlock()
+	return sl.l.Contains(val)
+}
+
+// ContainsFunc calls pred while holding the read lock, so pred must not use the List itself.
+func (sl *syncList) ContainsFunc(pred func(val interface{}) bool) bool {
+	sl.mu.RLock()
+	defer sl.mu.RUnlock()
+	return sl.l.ContainsFunc(pred)
+}
+
+func (sl *syncList) Get(index int) (interface{}, error) {
+	sl.mu.RLock()
+	defer sl.mu.RUnlock()
+	return sl.l.Get(index)
+}
+
+func (sl *syncList) Set(index int, val interface{}) (interface{}, error) {
+	sl.mu.Lock()
+	defer sl.mu.Unlock()
+	return sl.l.Set(index, val)
+}
+
+func (sl *syncList) IndexOf(val interface{}) int {
+	sl.mu.RLock()
+	defer sl.mu.RUnlock()
+	return sl.l.IndexOf(val)
+}
+
+// IndexFunc calls pred while holding the read lock, so pred must not use the List itself.
+func (sl *syncList) IndexFunc(pred func(val interface{}) bool) int {
+	sl.mu.RLock()
+	defer sl.mu.RUnlock()
+	return sl.l.IndexFunc(pred)
+}
+
+func (sl *syncList) LastIndexOf(val interface{}) int {
+	sl.mu.RLock()
+	defer sl.mu.RUnlock()
+	return sl.l.LastIndexOf(val)
+}
+
+// LastIndexFunc calls pred while holding the read lock, so pred must not use the List itself.
+func (sl *syncList) LastIndexFunc(pred func(val interface{}) bool) int {
+	sl.mu.RLock()
+	defer sl.mu.RUnlock()
+	return sl.l.LastIndexFunc(pred)
+}
+
+func (sl *syncList) Remove(index int) (interface{}, error) {
+	sl.mu.Lock()
+	defer sl.mu.Unlock()
+	return sl.l.Remove(index)
+}
+
+func (sl *syncList) RemoveByValue(val interface{}) bool {
+	sl.mu.Lock()
+	defer sl.mu.Unlock()
+	return sl.l.RemoveByValue(val)
+}
+
+// RemoveFunc calls pred while holding the write lock, so pred must not use the List itself.
+func (sl *syncList) RemoveFunc(pred func(val interface{}) bool) bool {
+	sl.mu.Lock()
+	defer sl.mu.Unlock()
+	return sl.l.RemoveFunc(pred)
+}
+
+func (sl *syncList) RemoveRange(fromIndex, toIndex int) error {
+	sl.mu.Lock()
+	defer sl.mu.Unlock()
+	return sl.l.RemoveRange(fromIndex, toIndex)
+}
+
+// RemoveIf calls pred while holding the write lock, so pred must not use the List itself.
+func (sl *syncList) RemoveIf(pred func(val interface{}) bool) int {
+	sl.mu.Lock()
+	defer sl.mu.Unlock()
+	return sl.l.RemoveIf(pred)
+}
+
+// ReplaceAll calls fn while holding the write lock, so fn must not use the List itself.
+func (sl *syncList) ReplaceAll(fn func(val interface{}) interface{}) {
+	sl.mu.Lock()
+	defer sl.mu.Unlock()
+	sl.l.ReplaceAll(fn)
+}
+
+// ForEach calls fn for each element in a snapshot of the list, so fn may access the list.
+func (sl *syncList) ForEach(fn func(val interface{})) {
+	sl.snapshot().ForEach(fn)
+}
+
+func (sl *syncList) Sort() {
+	sl.mu.Lock()
+	defer sl.mu.Unlock()
+	sl.l.Sort()
+}
+
+func (sl *syncList) SortWithOptions(reverse bool, c utils.Comparator) {
+	sl.mu.Lock()
+	defer sl.mu.Unlock()
+	sl.l.SortWithOptions(reverse, c)
+}
+
+func (sl *syncList) Swap(i, j int) error {
+	sl.mu.Lock()
+	defer sl.mu.Unlock()
+	return sl.l.Swap(i, j)
+}
+
+func (sl *syncList) Reverse() {
+	sl.mu.Lock()
+	defer sl.mu.Unlock()
+	sl.l.Reverse()
+}
+
+// SubList returns a read-only view of the portion of a snapshot of the list, whose methods modifying the
+// list fail with list.ErrReadOnly. Call SubList on the wrapped list in Do to work on a live view.
+func (sl *syncList) SubList(fromIndex, toIndex int) (*list.SubList, error) {
+	sub, err := sl.snapshot().SubList(fromIndex, toIndex)
+	if err != nil {
+		return nil, err
+	}
+	return sub.ReadOnly(), nil
+}
+
+func (sl *syncList) Iterator() (func() (interface{}, bool), bool) {
+	return sl.snapshot().Iterator()
+}
+
+func (sl *syncList) ReverseIterator() (func() (interface{}, bool), bool) {
+	return sl.snapshot().ReverseIterator()
+}
+
+// ListIterator returns a ListIterator over a snapshot of the list, whose Remove, Set and Add fail with
+// list.ErrReadOnly. Call ListIterator on the wrapped list in Do to modify the list during iteration.
+func (sl *syncList) ListIterator(index int) (list.ListIterator, error) {
+	l := sl.snapshot()
+	sub, _ := l.SubList(0, l.Size())
+	return sub.ReadOnly().ListIterator(index)
+}
+
+func (sl *syncList) All() iter.Seq2[int, interface{}] {
+	return func(yield func(int, interface{}) bool) {
+		for i, v := range sl.snapshot().All() {
+			if !yield(i, v) {
+				return
+			}
+		}
+	}
+}
+
+func (sl *syncList) Backward() iter.Seq2[int, interface{}] {
+	return func(yield func(int, interface{}) bool) {
+		for i, v := range sl.snapshot().Backward() {
+			if !yield(i, v) {
+				return
+			}
+		}
+	}
+}
+
+func (sl *syncList) Do(f func(l list.Interface)) {
+	sl.mu.Lock()
+	defer sl.mu.Unlock()
+	f(sl.l)
+}
+
+// snapshot copies all the elements into a new list.
+func (sl *syncList) snapshot() list.Interface {
+	sl.mu.RLock()
+	defer sl.mu.RUnlock()
+	al := list.NewArrayList()
+	for _, v := range sl.l.All() {
+		al.Add(v)
+	}
+	return al
+}
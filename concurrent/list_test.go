@@ -0,0 +1,131 @@
+// Copyright (c) 2019, Benjamin Wang (benjamin_wang@aliyun.com). All rights reserved.
+// Licensed under the MIT license that can be found in the LICENSE file.
+
+package concurrent_test
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/ahrtr/gocontainer/concurrent"
+	"github.com/ahrtr/gocontainer/list"
+)
+
+func TestListConcurrentAdd(t *testing.T) {
+	l := concurrent.NewList(list.NewArrayList())
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				l.Add(i*100 + j)
+				l.Contains(j)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if l.Size() != 1000 {
+		t.Errorf("The length isn't expected, expect: 1000, actual: %d\n", l.Size())
+	}
+}
+
+func TestListDo(t *testing.T) {
+	l := concurrent.NewList(list.NewLinkedList())
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				// add the value only if it isn't present
+				l.Do(func(l list.Interface) {
+					if !l.Contains(j) {
+						l.Add(j)
+					}
+				})
+			}
+		}()
+	}
+	wg.Wait()
+
+	if l.Size() != 100 {
+		t.Errorf("The length isn't expected, expect: 100, actual: %d\n", l.Size())
+	}
+}
+
+func TestListModifyDuringIteration(t *testing.T) {
+	l := concurrent.NewList(list.NewArrayList())
+	l.Add(5, 6, 7)
+
+	count := 0
+	for _, v := range l.All() {
+		// the iteration ranges over a snapshot, so it's safe to modify the list.
+		l.RemoveByValue(v)
+		count++
+	}
+	if count != 3 || !l.IsEmpty() {
+		t.Errorf("Unexpected result, count: %d, length: %d\n", count, l.Size())
+	}
+}
+
+func TestListSubList(t *testing.T) {
+	l := concurrent.NewList(list.NewArrayList())
+	l.Add(0, 1, 2, 3, 4)
+
+	// The sublist is a view of a snapshot, so it can't be used to modify the list.
+	sub, err := l.SubList(1, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	l.Add(5)
+	if sub.Size() != 3 {
+		t.Errorf("The length isn't expected, expect: 3, actual: %d\n", sub.Size())
+	}
+	if err := sub.AddTo(0, 9); !errors.Is(err, list.ErrReadOnly) {
+		t.Errorf("Expected ErrReadOnly, actual: %v\n", err)
+	}
+	if _, err := l.SubList(2, 7); err == nil {
+		t.Error("SubList should fail with an index out of range")
+	}
+	if l.Size() != 6 || l.Contains(9) {
+		t.Errorf("The list shouldn't be modified through the sublist, length: %d\n", l.Size())
+	}
+}
+
+func TestListListIterator(t *testing.T) {
+	l := concurrent.NewList(list.NewLinkedList())
+	l.Add(0, 1, 2)
+
+	// The iterator ranges over a snapshot, so it can't be used to modify the list.
+	it, err := l.ListIterator(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	l.Add(3)
+	if v, err := it.Next(); err != nil || v != 1 {
+		t.Errorf("Unexpected result of Next, expect: 1, actual: %v, %v\n", v, err)
+	}
+	if err := it.Remove(); !errors.Is(err, list.ErrReadOnly) {
+		t.Errorf("Expected ErrReadOnly, actual: %v\n", err)
+	}
+	if err := it.Set(9); !errors.Is(err, list.ErrReadOnly) {
+		t.Errorf("Expected ErrReadOnly, actual: %v\n", err)
+	}
+	if err := it.Add(9); !errors.Is(err, list.ErrReadOnly) {
+		t.Errorf("Expected ErrReadOnly, actual: %v\n", err)
+	}
+	if v, err := it.Next(); err != nil || v != 2 || it.HasNext() {
+		t.Errorf("Unexpected result of Next, expect: 2, actual: %v, %v\n", v, err)
+	}
+	if _, err := l.ListIterator(5); err == nil {
+		t.Error("ListIterator should fail with an index out of range")
+	}
+	if l.Size() != 4 || l.Contains(9) {
+		t.Errorf("The list shouldn't be modified through the iterator, length: %d\n", l.Size())
+	}
+}
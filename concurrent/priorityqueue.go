@@ -0,0 +1,156 @@
+// Copyright (c) 2019, Benjamin Wang (benjamin_wang@aliyun.com). All rights reserved.
+// Licensed under the MIT license that can be found in the LICENSE file.
+
+package concurrent
+
+import (
+	"iter"
+	"slices"
+	"sync"
+
+	"github.com/ahrtr/gocontainer/queue/priorityqueue"
+	"github.com/ahrtr/gocontainer/utils"
+)
+
+// PriorityQueue is a thread-safe priority queue.
+type PriorityQueue interface {
+	priorityqueue.Interface
+
+	// Do calls f with the wrapped priority queue while holding the write lock, so that the operations
+	// performed by f are atomic. f must not use the PriorityQueue itself, otherwise it deadlocks.
+	Do(f func(pq priorityqueue.Interface))
+}
+
+// syncPriorityQueue implements the PriorityQueue interface.
+type syncPriorityQueue struct {
+	mu sync.RWMutex
+	pq priorityqueue.Interface
+}
+
+// NewPriorityQueue returns a thread-safe priority queue backed by the specified priority queue.
+func NewPriorityQueue(pq priorityqueue.Interface) PriorityQueue {
+	return &syncPriorityQueue{pq: pq}
+}
+
+func (spq *syncPriorityQueue) WithComparator(c utils.Comparator) priorityqueue.Interface {
+	spq.mu.Lock()
+	defer spq.mu.Unlock()
+	spq.pq.WithComparator(c)
+	return spq
+}
+
+func (spq *syncPriorityQueue) WithMinHeap(isMinHeap bool) priorityqueue.Interface {
+	spq.mu.Lock()
+	defer spq.mu.Unlock()
+	spq.pq.WithMinHeap(isMinHeap)
+	return spq
+}
+
+func (spq *syncPriorityQueue) Size() int {
+	spq.mu.RLock()
+	defer spq.mu.RUnlock()
+	return spq.pq.Size()
+}
+
+func (spq *syncPriorityQueue) IsEmpty() bool {
+	spq.mu.RLock()
+	defer spq.mu.RUnlock()
+	return spq.pq.IsEmpty()
+}
+
+func (spq *syncPriorityQueue) Clear() {
+	spq.mu.Lock()
+	defer spq.mu.Unlock()
+	spq.pq.Clear()
+}
+
+func (spq *syncPriorityQueue) Add(vals ...interface{}) {
+	spq.mu.Lock()
+	defer spq.mu.Unlock()
+	spq.pq.Add(vals...)
+}
+
+func (spq *syncPriorityQueue) Peek() interface{} {
+	spq.mu.RLock()
+	defer spq.mu.RUnlock()
+	return spq.pq.Peek()
+}
+
+func (spq *syncPriorityQueue) Poll() interface{} {
+	spq.mu.Lock()
+	defer spq.mu.Unlock()
+	return spq.pq.Poll()
+}
+
+func (spq *syncPriorityQueue) Contains(val interface{}) bool {
+	spq.mu.RLock()
+	defer spq.mu.RUnlock()
+	return spq.pq.Contains(val)
+}
+
+// ContainsFunc calls pred while holding the read lock, so pred must not use the PriorityQueue itself.
+func (spq *syncPriorityQueue) ContainsFunc(pred func(val interface{}) bool) bool {
+	spq.mu.RLock()
+	defer spq.mu.RUnlock()
+	return spq.pq.ContainsFunc(pred)
+}
+
+func (spq *syncPriorityQueue) Remove(val interface{}) bool {
+	spq.mu.Lock()
+	defer spq.mu.Unlock()
+	return spq.pq.Remove(val)
+}
+
+// RemoveFunc calls pred while holding the write lock, so pred must not use the PriorityQueue itself.
+func (spq *syncPriorityQueue) RemoveFunc(pred func(val interface{}) bool) bool {
+	spq.mu.Lock()
+	defer spq.mu.Unlock()
+	return spq.pq.RemoveFunc(pred)
+}
+
+func (spq *syncPriorityQueue) AddWithHandle(val interface{}) *priorityqueue.Handle {
+	spq.mu.Lock()
+	defer spq.mu.Unlock()
+	return spq.pq.AddWithHandle(val)
+}
+
+func (spq *syncPriorityQueue) Update(h *priorityqueue.Handle, val interface{}) bool {
+	spq.mu.Lock()
+	defer spq.mu.Unlock()
+	return spq.pq.Update(h, val)
+}
+
+func (spq *syncPriorityQueue) RemoveHandle(h *priorityqueue.Handle) bool {
+	spq.mu.Lock()
+	defer spq.mu.Unlock()
+	return spq.pq.RemoveHandle(h)
+}
+
+func (spq *syncPriorityQueue) ContainsHandle(h *priorityqueue.Handle) bool {
+	spq.mu.RLock()
+	defer spq.mu.RUnlock()
+	return spq.pq.ContainsHandle(h)
+}
+
+func (spq *syncPriorityQueue) All() iter.Seq[interface{}] {
+	return func(yield func(interface{}) bool) {
+		for _, v := range spq.snapshot() {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+func (spq *syncPriorityQueue) Do(f func(pq priorityqueue.Interface)) {
+	spq.mu.Lock()
+	defer spq.mu.Unlock()
+	f(spq.pq)
+}
+
+// snapshot copies all the elements into a slice, in the heap's internal order.
+func (spq *syncPriorityQueue) snapshot() []interface{} {
+	spq.mu.RLock()
+	defer spq.mu.RUnlock()
+	return slices.AppendSeq(make([]interface{}, 0, spq.pq.Size()), spq.pq.All())
+}
@@ -0,0 +1,34 @@
+// Copyright (c) 2019, Benjamin Wang (benjamin_wang@aliyun.com). All rights reserved.
+// Licensed under the MIT license that can be found in the LICENSE file.
+
+package concurrent_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/ahrtr/gocontainer/concurrent"
+	"github.com/ahrtr/gocontainer/queue/priorityqueue"
+)
+
+func TestPriorityQueueConcurrentAdd(t *testing.T) {
+	pq := concurrent.NewPriorityQueue(priorityqueue.New())
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				pq.Add(i*100 + j)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	for i := 0; i < 1000; i++ {
+		if v := pq.Poll(); v != i {
+			t.Fatalf("The value polled isn't expected, expect: %d, actual: %v\n", i, v)
+		}
+	}
+}
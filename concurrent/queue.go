@@ -0,0 +1,91 @@
+// Copyright (c) 2019, Benjamin Wang (benjamin_wang@aliyun.com). All rights reserved.
+// Licensed under the MIT license that can be found in the LICENSE file.
+
+package concurrent
+
+import (
+	"iter"
+	"slices"
+	"sync"
+
+	"github.com/ahrtr/gocontainer/queue"
+)
+
+// Queue is a thread-safe queue.
+type Queue interface {
+	queue.Interface
+
+	// Do calls f with the wrapped queue while holding the write lock, so that the operations
+	// performed by f are atomic. f must not use the Queue itself, otherwise it deadlocks.
+	Do(f func(q queue.Interface))
+}
+
+// syncQueue implements the Queue interface.
+type syncQueue struct {
+	mu sync.RWMutex
+	q  queue.Interface
+}
+
+// NewQueue returns a thread-safe queue backed by the specified queue.
+func NewQueue(q queue.Interface) Queue {
+	return &syncQueue{q: q}
+}
+
+func (sq *syncQueue) Size() int {
+	sq.mu.RLock()
+	defer sq.mu.RUnlock()
+	return sq.q.Size()
+}
+
+func (sq *syncQueue) IsEmpty() bool {
+	sq.mu.RLock()
+	defer sq.mu.RUnlock()
+	return sq.q.IsEmpty()
+}
+
+func (sq *syncQueue) Clear() {
+	sq.mu.Lock()
+	defer sq.mu.Unlock()
+	sq.q.Clear()
+}
+
+func (sq *syncQueue) Add(vals ...interface{}) {
+	sq.mu.Lock()
+	defer sq.mu.Unlock()
+	sq.q.Add(vals...)
+}
+
+func (sq *syncQueue) Peek() interface{} {
+	sq.mu.RLock()
+	defer sq.mu.RUnlock()
+	return sq.q.Peek()
+}
+
+func (sq *syncQueue) Poll() interface{} {
+	sq.mu.Lock()
+	defer sq.mu.Unlock()
+	return sq.q.Poll()
+}
+
+func (sq *syncQueue) All() iter.Seq[interface{}] {
+	return func(yield func(interface{}) bool) {
+		for _, v := range sq.snapshot() {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+func (sq *syncQueue) Do(f func(q queue.Interface)) {
+	sq.mu.Lock()
+	defer sq.mu.Unlock()
+	f(sq.q)
+}
+
+// snapshot copies all the elements into a slice, from the head to the tail.
+func (sq *syncQueue) snapshot() []interface{} {
+	sq.mu.RLock()
+	defer sq.mu.RUnlock()
+	return slices.AppendSeq(make([]interface{}, 0, sq.q.Size()), sq.q.All())
+}
@@ -0,0 +1,39 @@
+// Copyright (c) 2019, Benjamin Wang (benjamin_wang@aliyun.com). All rights reserved.
+// Licensed under the MIT license that can be found in the LICENSE file.
+
+package concurrent_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/ahrtr/gocontainer/concurrent"
+	"github.com/ahrtr/gocontainer/queue"
+)
+
+func TestQueueConcurrentAddPoll(t *testing.T) {
+	q := concurrent.NewQueue(queue.New())
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	polled := 0
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				q.Add(j)
+				if q.Poll() != nil {
+					mu.Lock()
+					polled++
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if polled != 1000 || !q.IsEmpty() {
+		t.Errorf("Unexpected result, polled: %d, length: %d\n", polled, q.Size())
+	}
+}
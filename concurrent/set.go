@@ -0,0 +1,219 @@
+// Copyright (c) 2019, Benjamin Wang (benjamin_wang@aliyun.com). All rights reserved.
+// Licensed under the MIT license that can be found in the LICENSE file.
+
+package concurrent
+
+import (
+	"iter"
+	"slices"
+	"sync"
+
+	"github.com/ahrtr/gocontainer/set"
+)
+
+// Set is a thread-safe set.
+type Set interface {
+	set.Interface
+
+	// Do calls f with the wrapped set while holding the write lock, so that the operations
+	// performed by f are atomic. f must not use the Set itself, otherwise it deadlocks.
+	Do(f func(s set.Interface))
+}
+
+// syncSet implements the Set interface.
+type syncSet struct {
+	mu sync.RWMutex
+	s  set.Interface
+}
+
+// NewSet returns a thread-safe set backed by the specified set.
+func NewSet(s set.Interface) Set {
+	return &syncSet{s: s}
+}
+
+func (ss *syncSet) Size() int {
+	ss.mu.RLock()
+	defer ss.mu.RUnlock()
+	return ss.s.Size()
+}
+
+func (ss *syncSet) IsEmpty() bool {
+	ss.mu.RLock()
+	defer ss.mu.RUnlock()
+	return ss.s.IsEmpty()
+}
+
+func (ss *syncSet) Clear() {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+	ss.s.Clear()
+}
+
+func (ss *syncSet) Add(vals ...interface{}) bool {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+	return ss.s.Add(vals...)
+}
+
+func (ss *syncSet) Contains(val interface{}) bool {
+	ss.mu.RLock()
+	defer ss.mu.RUnlock()
+	return ss.s.Contains(val)
+}
+
+func (ss *syncSet) Remove(val interface{}) bool {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+	return ss.s.Remove(val)
+}
+
+func (ss *syncSet) Iterate(cb set.IterateCallback) {
+	for _, v := range ss.snapshot() {
+		if !cb(v) {
+			break
+		}
+	}
+}
+
+func (ss *syncSet) All() iter.Seq[interface{}] {
+	return func(yield func(interface{}) bool) {
+		for _, v := range ss.snapshot() {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+func (ss *syncSet) ToSlice() []interface{} {
+	ss.mu.RLock()
+	defer ss.mu.RUnlock()
+	return ss.s.ToSlice()
+}
+
+func (ss *syncSet) Clone() set.Interface {
+	ss.mu.RLock()
+	defer ss.mu.RUnlock()
+	return NewSet(ss.s.Clone())
+}
+
+func (ss *syncSet) Pop() (interface{}, bool) {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+	return ss.s.Pop()
+}
+
+// RemoveIf calls f while holding the write lock, so f must not use the Set itself.
+func (ss *syncSet) RemoveIf(f func(interface{}) bool) int {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+	return ss.s.RemoveIf(f)
+}
+
+func (ss *syncSet) Union(other set.Interface) set.Interface {
+	o := otherSet(other)
+	ss.mu.RLock()
+	defer ss.mu.RUnlock()
+	return NewSet(ss.s.Union(o))
+}
+
+func (ss *syncSet) Intersection(other set.Interface) set.Interface {
+	o := otherSet(other)
+	ss.mu.RLock()
+	defer ss.mu.RUnlock()
+	return NewSet(ss.s.Intersection(o))
+}
+
+func (ss *syncSet) Difference(other set.Interface) set.Interface {
+	o := otherSet(other)
+	ss.mu.RLock()
+	defer ss.mu.RUnlock()
+	return NewSet(ss.s.Difference(o))
+}
+
+func (ss *syncSet) SymmetricDifference(other set.Interface) set.Interface {
+	o := otherSet(other)
+	ss.mu.RLock()
+	defer ss.mu.RUnlock()
+	return NewSet(ss.s.SymmetricDifference(o))
+}
+
+func (ss *syncSet) UnionWith(other set.Interface) {
+	o := otherSet(other)
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+	ss.s.UnionWith(o)
+}
+
+func (ss *syncSet) IntersectWith(other set.Interface) {
+	o := otherSet(other)
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+	ss.s.IntersectWith(o)
+}
+
+func (ss *syncSet) DifferenceWith(other set.Interface) {
+	o := otherSet(other)
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+	ss.s.DifferenceWith(o)
+}
+
+func (ss *syncSet) SymmetricDifferenceWith(other set.Interface) {
+	o := otherSet(other)
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+	ss.s.SymmetricDifferenceWith(o)
+}
+
+func (ss *syncSet) IsSubsetOf(other set.Interface) bool {
+	o := otherSet(other)
+	ss.mu.RLock()
+	defer ss.mu.RUnlock()
+	return ss.s.IsSubsetOf(o)
+}
+
+func (ss *syncSet) IsSupersetOf(other set.Interface) bool {
+	o := otherSet(other)
+	ss.mu.RLock()
+	defer ss.mu.RUnlock()
+	return ss.s.IsSupersetOf(o)
+}
+
+func (ss *syncSet) IsDisjoint(other set.Interface) bool {
+	o := otherSet(other)
+	ss.mu.RLock()
+	defer ss.mu.RUnlock()
+	return ss.s.IsDisjoint(o)
+}
+
+func (ss *syncSet) Equal(other set.Interface) bool {
+	o := otherSet(other)
+	ss.mu.RLock()
+	defer ss.mu.RUnlock()
+	return ss.s.Equal(o)
+}
+
+func (ss *syncSet) Do(f func(s set.Interface)) {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+	f(ss.s)
+}
+
+// snapshot copies all the elements into a slice.
+func (ss *syncSet) snapshot() []interface{} {
+	ss.mu.RLock()
+	defer ss.mu.RUnlock()
+	return slices.AppendSeq(make([]interface{}, 0, ss.s.Size()), ss.s.All())
+}
+
+// otherSet returns a set which can be used as the other operand of the set algebra while holding the lock.
+// A thread-safe set is copied under its own lock, so that two locks are never held at the same time.
+func otherSet(other set.Interface) set.Interface {
+	if o, ok := other.(*syncSet); ok {
+		o.mu.RLock()
+		defer o.mu.RUnlock()
+		return o.s.Clone()
+	}
+	return other
+}
@@ -0,0 +1,54 @@
+// Copyright (c) 2019, Benjamin Wang (benjamin_wang@aliyun.com). All rights reserved.
+// Licensed under the MIT license that can be found in the LICENSE file.
+
+package concurrent_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/ahrtr/gocontainer/concurrent"
+	"github.com/ahrtr/gocontainer/set"
+)
+
+func TestSetConcurrentAdd(t *testing.T) {
+	s := concurrent.NewSet(set.New())
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				s.Add(j)
+				s.Iterate(func(v interface{}) bool {
+					return s.Contains(v)
+				})
+			}
+		}()
+	}
+	wg.Wait()
+
+	if s.Size() != 100 {
+		t.Errorf("The length isn't expected, expect: 100, actual: %d\n", s.Size())
+	}
+}
+
+func TestSetAlgebraWithItself(t *testing.T) {
+	s := concurrent.NewSet(set.New())
+	s.Add(1, 2, 3)
+	other := set.New()
+	other.Add(3, 4)
+
+	// using the set as the other operand of itself mustn't deadlock
+	s.UnionWith(s)
+	if !s.Equal(s) || s.Size() != 3 {
+		t.Errorf("Unexpected result, length: %d\n", s.Size())
+	}
+
+	s.UnionWith(other)
+	u := s.Intersection(concurrent.NewSet(other))
+	if !u.Equal(other) {
+		t.Errorf("The intersection isn't expected, expect: %v, actual: %v\n", other.ToSlice(), u.ToSlice())
+	}
+}
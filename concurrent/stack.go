@@ -0,0 +1,101 @@
+// Copyright (c) 2019, Benjamin Wang (benjamin_wang@aliyun.com). All rights reserved.
+// Licensed under the MIT license that can be found in the LICENSE file.
+
+package concurrent
+
+import (
+	"iter"
+	"slices"
+	"sync"
+
+	"github.com/ahrtr/gocontainer/stack"
+)
+
+// Stack is a thread-safe stack.
+type Stack interface {
+	stack.Interface
+
+	// Do calls f with the wrapped stack while holding the write lock, so that the operations
+	// performed by f are atomic. f must not use the Stack itself, otherwise it deadlocks.
+	Do(f func(s stack.Interface))
+}
+
+// syncStack implements the Stack interface.
+type syncStack struct {
+	mu sync.RWMutex
+	s  stack.Interface
+}
+
+// NewStack returns a thread-safe stack backed by the specified stack.
+func NewStack(s stack.Interface) Stack {
+	return &syncStack{s: s}
+}
+
+func (ss *syncStack) Size() int {
+	ss.mu.RLock()
+	defer ss.mu.RUnlock()
+	return ss.s.Size()
+}
+
+func (ss *syncStack) IsEmpty() bool {
+	ss.mu.RLock()
+	defer ss.mu.RUnlock()
+	return ss.s.IsEmpty()
+}
+
+func (ss *syncStack) Clear() {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+	ss.s.Clear()
+}
+
+func (ss *syncStack) Push(val interface{}) {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+	ss.s.Push(val)
+}
+
+func (ss *syncStack) Pop() interface{} {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+	return ss.s.Pop()
+}
+
+func (ss *syncStack) Peek() interface{} {
+	ss.mu.RLock()
+	defer ss.mu.RUnlock()
+	return ss.s.Peek()
+}
+
+func (ss *syncStack) All() iter.Seq[interface{}] {
+	return func(yield func(interface{}) bool) {
+		for _, v := range ss.snapshot() {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+func (ss *syncStack) Backward() iter.Seq[interface{}] {
+	return func(yield func(interface{}) bool) {
+		for _, v := range slices.Backward(ss.snapshot()) {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+func (ss *syncStack) Do(f func(s stack.Interface)) {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+	f(ss.s)
+}
+
+// snapshot copies all the elements into a slice, from the top to the bottom.
+func (ss *syncStack) snapshot() []interface{} {
+	ss.mu.RLock()
+	defer ss.mu.RUnlock()
+	return slices.AppendSeq(make([]interface{}, 0, ss.s.Size()), ss.s.All())
+}
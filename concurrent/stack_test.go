@@ -0,0 +1,34 @@
+// Copyright (c) 2019, Benjamin Wang (benjamin_wang@aliyun.com). All rights reserved.
+// Licensed under the MIT license that can be found in the LICENSE file.
+
+package concurrent_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/ahrtr/gocontainer/concurrent"
+	"github.com/ahrtr/gocontainer/stack"
+)
+
+func TestStackConcurrentPushPop(t *testing.T) {
+	s := concurrent.NewStack(stack.New())
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				s.Push(j)
+				s.Push(j)
+				s.Pop()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if s.Size() != 1000 {
+		t.Errorf("The length isn't expected, expect: 1000, actual: %d\n", s.Size())
+	}
+}
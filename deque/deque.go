@@ -0,0 +1,234 @@
+// Copyright (c) 2019, Benjamin Wang (benjamin_wang@aliyun.com). All rights reserved.
+// Licensed under the MIT license that can be found in the LICENSE file.
+
+// Package deque implements a double-ended queue, which supports inserting and removing elements at both ends.
+// It's backed by a circular slice (ring buffer), which grows and shrinks automatically, so that all the operations
+// at both ends are amortized O(1).
+// New creates a deque of interface{} values, while NewOf creates the type-parameterized Deque[T].
+//
+// A deque can be used as a stack or a queue. The front of the deque is the top of the stack and the head of the queue,
+// which means Push and Pop work on the front, Add adds elements to the back, and Poll and Peek work on the front.
+package deque
+
+import (
+	"fmt"
+	"iter"
+
+	"github.com/ahrtr/gocontainer/queue"
+	"github.com/ahrtr/gocontainer/stack"
+)
+
+// minCapacity is the capacity of the ring buffer when the first element is added, it must be a power of 2.
+const minCapacity = 16
+
+// Interface is a type of double-ended queue. It's both a stack.Interface and a queue.Interface.
+type Interface interface {
+	stack.Interface
+	queue.Interface
+
+	// PushFront inserts an element at the front of this deque.
+	PushFront(val interface{})
+	// PushBack inserts an element at the back of this deque.
+	PushBack(val interface{})
+	// PopFront removes and returns the element at the front of this deque, or returns nil if this deque is empty.
+	PopFront() interface{}
+	// PopBack removes and returns the element at the back of this deque, or returns nil if this deque is empty.
+	PopBack() interface{}
+	// PeekFront retrieves, but does not remove, the element at the front of this deque, or returns nil if this deque is empty.
+	PeekFront() interface{}
+	// PeekBack retrieves, but does not remove, the element at the back of this deque, or returns nil if this deque is empty.
+	PeekBack() interface{}
+	// Get returns the element at the specified position in this deque, the front element is at position 0.
+	// It returns an error if the index is out of range.
+	Get(index int) (interface{}, error)
+}
+
+// Deque is a double-ended queue backed by a ring buffer, whose elements are of type T.
+// Deque[interface{}] implements the Interface.
+type Deque[T any] struct {
+	// buf is the ring buffer, its length is always 0 or a power of 2.
+	buf    []T
+	head   int
+	length int
+}
+
+// New creates a deque.
+func New() Interface {
+	return NewOf[interface{}]()
+}
+
+// NewOf creates a deque, whose elements are of type T.
+func NewOf[T any]() *Deque[T] {
+	return &Deque[T]{}
+}
+
+// Size returns the number of elements in this deque.
+func (d *Deque[T]) Size() int {
+	return d.length
+}
+
+// IsEmpty returns true if this deque contains no elements.
+func (d *Deque[T]) IsEmpty() bool {
+	return d.length == 0
+}
+
+// Clear removes all of the elements from this deque.
+func (d *Deque[T]) Clear() {
+	d.buf, d.head, d.length = nil, 0, 0
+}
+
+// PushFront inserts an element at the front of this deque.
+func (d *Deque[T]) PushFront(val T) {
+	d.grow()
+	d.head = d.index(-1)
+	d.buf[d.head] = val
+	d.length++
+}
+
+// PushBack inserts an element at the back of this deque.
+func (d *Deque[T]) PushBack(val T) {
+	d.grow()
+	d.buf[d.index(d.length)] = val
+	d.length++
+}
+
+// PopFront removes and returns the element at the front of this deque, or returns the zero value of T if this deque is empty.
+func (d *Deque[T]) PopFront() T {
+	var zero T
+	if d.length == 0 {
+		return zero
+	}
+	val := d.buf[d.head]
+	d.buf[d.head] = zero
+	d.head = d.index(1)
+	d.length--
+	d.shrink()
+	return val
+}
+
+// PopBack removes and returns the element at the back of this deque, or returns the zero value of T if this deque is empty.
+func (d *Deque[T]) PopBack() T {
+	var zero T
+	if d.length == 0 {
+		return zero
+	}
+	i := d.index(d.length - 1)
+	val := d.buf[i]
+	d.buf[i] = zero
+	d.length--
+	d.shrink()
+	return val
+}
+
+// PeekFront retrieves, but does not remove, the element at the front of this deque, or returns the zero value of T if this deque is empty.
+func (d *Deque[T]) PeekFront() T {
+	if d.length == 0 {
+		var zero T
+		return zero
+	}
+	return d.buf[d.head]
+}
+
+// PeekBack retrieves, but does not remove, the element at the back of this deque, or returns the zero value of T if this deque is empty.
+func (d *Deque[T]) PeekBack() T {
+	if d.length == 0 {
+		var zero T
+		return zero
+	}
+	return d.buf[d.index(d.length-1)]
+}
+
+// Get returns the element at the specified position in this deque, the front element is at position 0.
+// It returns an error if the index is out of range.
+func (d *Deque[T]) Get(index int) (T, error) {
+	if index < 0 || index >= d.length {
+		var zero T
+		return zero, fmt.Errorf("index out of range, index:%d, len:%d", index, d.length)
+	}
+	return d.buf[d.index(index)], nil
+}
+
+// Push pushes an element onto the front of this deque, which is the top of the stack.
+func (d *Deque[T]) Push(val T) {
+	d.PushFront(val)
+}
+
+// Pop is the same as PopFront.
+func (d *Deque[T]) Pop() T {
+	return d.PopFront()
+}
+
+// Add inserts the elements at the back of this deque, which is the tail of the queue.
+func (d *Deque[T]) Add(vals ...T) {
+	for _, v := range vals {
+		d.PushBack(v)
+	}
+}
+
+// Poll is the same as PopFront.
+func (d *Deque[T]) Poll() T {
+	return d.PopFront()
+}
+
+// Peek is the same as PeekFront.
+func (d *Deque[T]) Peek() T {
+	return d.PeekFront()
+}
+
+// All returns an iterator over the elements in this deque, from the front to the back.
+func (d *Deque[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for i := 0; i < d.length; i++ {
+			if !yield(d.buf[d.index(i)]) {
+				return
+			}
+		}
+	}
+}
+
+// Backward returns an iterator over the elements in this deque, from the back to the front.
+func (d *Deque[T]) Backward() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for i := d.length - 1; i >= 0; i-- {
+			if !yield(d.buf[d.index(i)]) {
+				return
+			}
+		}
+	}
+}
+
+// index converts the position relative to the front into the index of the ring buffer.
+func (d *Deque[T]) index(i int) int {
+	return (d.head + i) & (len(d.buf) - 1)
+}
+
+// grow doubles the capacity of the ring buffer if it's full.
+func (d *Deque[T]) grow() {
+	if d.length < len(d.buf) {
+		return
+	}
+	newCap := len(d.buf) * 2
+	if newCap == 0 {
+		newCap = minCapacity
+	}
+	d.resize(newCap)
+}
+
+// shrink halves the capacity of the ring buffer when len(deque) <= cap(deque)/4.
+func (d *Deque[T]) shrink() {
+	if len(d.buf) > minCapacity && d.length <= len(d.buf)/4 {
+		d.resize(len(d.buf) / 2)
+	}
+}
+
+// resize moves the elements into a new ring buffer with the specified capacity, the front element is moved to index 0.
+func (d *Deque[T]) resize(newCap int) {
+	newBuf := make([]T, newCap)
+	if d.head+d.length <= len(d.buf) {
+		copy(newBuf, d.buf[d.head:d.head+d.length])
+	} else {
+		n := copy(newBuf, d.buf[d.head:])
+		copy(newBuf[n:], d.buf[:d.length-n])
+	}
+	d.buf, d.head = newBuf, 0
+}
@@ -0,0 +1,117 @@
+// Copyright (c) 2019, Benjamin Wang (benjamin_wang@aliyun.com). All rights reserved.
+// Licensed under the MIT license that can be found in the LICENSE file.
+
+package deque_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/ahrtr/gocontainer/deque"
+	"github.com/ahrtr/gocontainer/queue"
+	"github.com/ahrtr/gocontainer/stack"
+)
+
+func TestDequePushPop(t *testing.T) {
+	d := deque.New()
+
+	d.PushBack(6)
+	d.PushFront(5)
+	d.PushBack(7)
+	d.PushFront(4)
+
+	if d.Size() != 4 {
+		t.Errorf("The length isn't expected, expect: 4, actual: %d\n", d.Size())
+	}
+	if d.PeekFront() != 4 || d.PeekBack() != 7 {
+		t.Errorf("Unexpected result, front: %v, back: %v\n", d.PeekFront(), d.PeekBack())
+	}
+	for i := 0; i < 4; i++ {
+		if v, err := d.Get(i); err != nil || v != i+4 {
+			t.Errorf("The value isn't expected, expect: %d, actual: %v, error: %v\n", i+4, v, err)
+		}
+	}
+	if _, err := d.Get(4); err == nil {
+		t.Errorf("Expect an error when the index is out of range\n")
+	}
+
+	if v := d.PopBack(); v != 7 {
+		t.Errorf("The value popped isn't expected, expect: 7, actual: %v\n", v)
+	}
+	if v := d.PopFront(); v != 4 {
+		t.Errorf("The value popped isn't expected, expect: 4, actual: %v\n", v)
+	}
+	d.Clear()
+	if !d.IsEmpty() || d.PopFront() != nil || d.PopBack() != nil || d.PeekFront() != nil || d.PeekBack() != nil {
+		t.Errorf("The deque should be empty\n")
+	}
+}
+
+func TestDequeGrowAndShrink(t *testing.T) {
+	d := deque.NewOf[int]()
+
+	// alternate the ends, so that the elements wrap around the ring buffer
+	for i := 0; i < 5000; i++ {
+		if i%2 == 0 {
+			d.PushFront(-i)
+		} else {
+			d.PushBack(i)
+		}
+	}
+	if d.Size() != 5000 {
+		t.Errorf("The length isn't expected, expect: 5000, actual: %d\n", d.Size())
+	}
+	if d.PeekFront() != -4998 || d.PeekBack() != 4999 {
+		t.Errorf("Unexpected result, front: %d, back: %d\n", d.PeekFront(), d.PeekBack())
+	}
+
+	prev := d.PeekFront() - 1
+	for v := range d.All() {
+		if v <= prev {
+			t.Fatalf("The elements aren't in ascending order, %d <= %d\n", v, prev)
+		}
+		prev = v
+	}
+
+	for i := 0; i < 4990; i++ {
+		if i%2 == 0 {
+			d.PopFront()
+		} else {
+			d.PopBack()
+		}
+	}
+	expected := []int{-8, -6, -4, -2, 0, 1, 3, 5, 7, 9}
+	if actual := slices.Collect(d.All()); !slices.Equal(actual, expected) {
+		t.Errorf("The elements aren't expected, expect: %v, actual: %v\n", expected, actual)
+	}
+	slices.Reverse(expected)
+	if actual := slices.Collect(d.Backward()); !slices.Equal(actual, expected) {
+		t.Errorf("The elements aren't expected, expect: %v, actual: %v\n", expected, actual)
+	}
+}
+
+func TestDequeAsStack(t *testing.T) {
+	var s stack.Interface = deque.New()
+
+	s.Push(5)
+	s.Push(6)
+	s.Push(7)
+
+	for _, expected := range []int{7, 6, 5} {
+		if v := s.Pop(); v != expected {
+			t.Errorf("The value popped isn't expected, expect: %d, actual: %v\n", expected, v)
+		}
+	}
+}
+
+func TestDequeAsQueue(t *testing.T) {
+	var q queue.Interface = deque.New()
+
+	q.Add(5, 6, 7)
+
+	for _, expected := range []int{5, 6, 7} {
+		if v := q.Poll(); v != expected {
+			t.Errorf("The value polled isn't expected, expect: %d, actual: %v\n", expected, v)
+		}
+	}
+}
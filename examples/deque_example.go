@@ -0,0 +1,26 @@
+// Copyright (c) 2019, Benjamin Wang (benjamin_wang@aliyun.com). All rights reserved.
+// Licensed under the MIT license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/ahrtr/gocontainer/deque"
+)
+
+func dequeExample1() {
+	d := deque.New()
+
+	d.PushBack("alice")
+	d.PushFront("benjamin")
+	d.PushBack("john")
+
+	for v := range d.All() {
+		fmt.Printf("%v\n", v)
+	}
+
+	for !d.IsEmpty() {
+		fmt.Printf("d.PopBack() = %v\n", d.PopBack())
+	}
+}
@@ -92,6 +92,12 @@ func listBasicOperation(h list.Interface) {
 		v, hasPrev = it()
 		fmt.Printf("    Value: %v\n", v)
 	}
+
+	// Iterate all the elements (method 4: range over func)
+	fmt.Println("Iterate (method 4): ")
+	for i, v := range h.All() {
+		fmt.Printf("    Index: %d, value: %v\n", i, v)
+	}
 }
 
 func listSortData(h list.Interface) {
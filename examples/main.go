@@ -15,6 +15,8 @@ func main() {
 
 		queueExample1,
 
+		dequeExample1,
+
 		setExample1,
 
 		priorityqueueExample1,
@@ -2,6 +2,13 @@
 // Licensed under the MIT license that can be found in the LICENSE file.
 
 // Package list implements both an arrayList and a linkedList.
+// NewArrayList and NewLinkedList create lists of interface{} values, while
+// NewArrayListOf and NewLinkedListOf create the type-parameterized ArrayList[T] and LinkedList[T].
+//
+// To range over a list (where l is a list.Interface):
+//	for i, v := range l.All() {
+//		// do something with i & v
+//	}
 //
 // To iterate over an arrayList (where al is a *arrayList):
 //	it, hasNext := al.Iterator()
@@ -23,36 +30,51 @@ package list
 
 import (
 	"fmt"
+	"iter"
+	"slices"
 
 	"github.com/ahrtr/gocontainer/utils"
 )
 
-// arrayList represents an array list.
-// It implements the interface list.Interface.
+// ArrayList represents an array list, whose elements are of type T.
+type ArrayList[T any] struct {
+	items []T
+	// modCount is the number of times the list has been structurally modified,
+	// which is used by the sublists to detect the concurrent modifications.
+	modCount int
+}
+
+// arrayList is the list returned by NewArrayList, it implements the interface list.Interface.
 type arrayList struct {
-	items []interface{}
+	*ArrayList[interface{}]
 }
 
 // NewArrayList initializes and returns an ArrayList.
 func NewArrayList() Interface {
-	return &arrayList{
-		items: []interface{}{},
+	return &arrayList{NewArrayListOf[interface{}]()}
+}
+
+// NewArrayListOf initializes and returns an ArrayList, whose elements are of type T.
+func NewArrayListOf[T any]() *ArrayList[T] {
+	return &ArrayList[T]{
+		items: []T{},
 	}
 }
 
-func (al *arrayList) Size() int {
+func (al *ArrayList[T]) Size() int {
 	return len(al.items)
 }
 
-func (al *arrayList) IsEmpty() bool {
+func (al *ArrayList[T]) IsEmpty() bool {
 	return al.Size() == 0
 }
 
-func (al *arrayList) Add(vals ...interface{}) {
+func (al *ArrayList[T]) Add(vals ...T) {
 	al.items = append(al.items, vals...)
+	al.modCount++
 }
 
-func (al *arrayList) AddTo(index int, val interface{}) error {
+func (al *ArrayList[T]) AddTo(index int, val T) error {
 	if index < 0 || index > len(al.items) {
 		return fmt.Errorf("index out of range, index:%d, len:%d", index, al.Size())
 	}
@@ -64,8 +86,19 @@ func (al *arrayList) AddTo(index int, val interface{}) error {
 		al.items = append(al.items, val)
 		copy(al.items[(index+1):(curLen+1)], al.items[index:curLen])
 		al.items[index] = val
+		al.modCount++
+	}
+
+	return nil
+}
+
+func (al *ArrayList[T]) AddAllAt(index int, vals ...T) error {
+	if index < 0 || index > len(al.items) {
+		return fmt.Errorf("index out of range, index:%d, len:%d", index, al.Size())
 	}
 
+	al.items = slices.Insert(al.items, index, vals...)
+	al.modCount++
 	return nil
 }
 
@@ -74,8 +107,13 @@ func (al *arrayList) Contains(val interface{}) bool {
 		return false
 	}
 
+	return al.ContainsFunc(func(v interface{}) bool { return v == val })
+}
+
+// ContainsFunc returns true if the list contains an element satisfying pred.
+func (al *ArrayList[T]) ContainsFunc(pred func(val T) bool) bool {
 	for _, v := range al.items {
-		if v == val {
+		if pred(v) {
 			return true
 		}
 	}
@@ -83,35 +121,97 @@ func (al *arrayList) Contains(val interface{}) bool {
 	return false
 }
 
-func (al *arrayList) Get(index int) (interface{}, error) {
+func (al *ArrayList[T]) Get(index int) (T, error) {
 	if index < 0 || index >= len(al.items) {
-		return nil, fmt.Errorf("index out of range, index:%d, len:%d", index, al.Size())
+		var zero T
+		return zero, fmt.Errorf("index out of range, index:%d, len:%d", index, al.Size())
 	}
 
 	return al.items[index], nil
 }
 
-func (al *arrayList) Remove(index int) (interface{}, error) {
+func (al *ArrayList[T]) Set(index int, val T) (T, error) {
 	if index < 0 || index >= len(al.items) {
-		return nil, fmt.Errorf("index out of range, index:%d, len:%d", index, al.Size())
+		var zero T
+		return zero, fmt.Errorf("index out of range, index:%d, len:%d", index, al.Size())
+	}
+
+	old := al.items[index]
+	al.items[index] = val
+	return old, nil
+}
+
+func (al *arrayList) IndexOf(val interface{}) int {
+	return al.IndexFunc(func(v interface{}) bool { return v == val })
+}
+
+// IndexFunc returns the index of the first element satisfying pred, or -1 if no element satisfies pred.
+func (al *ArrayList[T]) IndexFunc(pred func(val T) bool) int {
+	for i, v := range al.items {
+		if pred(v) {
+			return i
+		}
+	}
+	return -1
+}
+
+func (al *arrayList) LastIndexOf(val interface{}) int {
+	return al.LastIndexFunc(func(v interface{}) bool { return v == val })
+}
+
+// LastIndexFunc returns the index of the last element satisfying pred, or -1 if no element satisfies pred.
+func (al *ArrayList[T]) LastIndexFunc(pred func(val T) bool) int {
+	for i := len(al.items) - 1; i >= 0; i-- {
+		if pred(al.items[i]) {
+			return i
+		}
+	}
+	return -1
+}
+
+func (al *ArrayList[T]) Remove(index int) (T, error) {
+	if index < 0 || index >= len(al.items) {
+		var zero T
+		return zero, fmt.Errorf("index out of range, index:%d, len:%d", index, al.Size())
 	}
 
 	val := al.items[index]
 
 	al.items = append(al.items[:index], al.items[(index+1):]...)
+	al.modCount++
 
 	al.shrinkList()
 	return val, nil
 }
 
+func (al *ArrayList[T]) RemoveRange(fromIndex, toIndex int) error {
+	if fromIndex < 0 || toIndex > len(al.items) || fromIndex > toIndex {
+		return fmt.Errorf("range out of bounds, fromIndex:%d, toIndex:%d, len:%d", fromIndex, toIndex, al.Size())
+	}
+
+	n := copy(al.items[fromIndex:], al.items[toIndex:])
+	clear(al.items[fromIndex+n:])
+	al.items = al.items[:fromIndex+n]
+	al.modCount++
+
+	al.shrinkList()
+	return nil
+}
+
 func (al *arrayList) RemoveByValue(val interface{}) bool {
+	return al.RemoveFunc(func(v interface{}) bool { return v == val })
+}
+
+// RemoveFunc removes the first element satisfying pred, if any.
+func (al *ArrayList[T]) RemoveFunc(pred func(val T) bool) bool {
 	if al.Size() == 0 {
 		return false
 	}
 
 	for i, v := range al.items {
-		if v == val {
+		if pred(v) {
 			al.items = append(al.items[:i], al.items[(i+1):]...)
+			al.modCount++
 			al.shrinkList()
 			return true
 		}
@@ -120,56 +220,143 @@ func (al *arrayList) RemoveByValue(val interface{}) bool {
 	return false
 }
 
-func (al *arrayList) Clear() {
+func (al *ArrayList[T]) RemoveIf(pred func(val T) bool) int {
+	n := 0
+	for _, v := range al.items {
+		if !pred(v) {
+			al.items[n] = v
+			n++
+		}
+	}
+
+	removed := len(al.items) - n
+	if removed == 0 {
+		return 0
+	}
+	clear(al.items[n:])
+	al.items = al.items[:n]
+	al.modCount++
+
+	al.shrinkList()
+	return removed
+}
+
+func (al *ArrayList[T]) ReplaceAll(fn func(val T) T) {
+	for i, v := range al.items {
+		al.items[i] = fn(v)
+	}
+}
+
+func (al *ArrayList[T]) ForEach(fn func(val T)) {
+	for _, v := range al.items {
+		fn(v)
+	}
+}
+
+func (al *ArrayList[T]) Clear() {
+	var zero T
 	for i := 0; i < len(al.items); i++ {
-		al.items[i] = nil
+		al.items[i] = zero
 	}
-	al.items = []interface{}{}
+	al.items = []T{}
+	al.modCount++
 }
 
-func (al *arrayList) Sort() {
+func (al *ArrayList[T]) Sort() {
 	al.SortWithOptions(false, nil)
 }
 
-func (al *arrayList) SortWithOptions(reverse bool, c utils.Comparator) {
+func (al *ArrayList[T]) SortWithOptions(reverse bool, c utils.Comparator) {
 	if reverse {
-		utils.ReverseSort(al.items, c)
+		utils.ReverseSortFunc(al.items, utils.CompareFunc[T](c))
 	} else {
-		utils.Sort(al.items, c)
+		utils.SortFunc(al.items, utils.CompareFunc[T](c))
+	}
+}
+
+// SortFunc sorts the elements into ascending sequence according to the provided comparison function.
+func (al *ArrayList[T]) SortFunc(cmp func(v1, v2 T) int) {
+	utils.SortFunc(al.items, cmp)
+}
+
+func (al *ArrayList[T]) Swap(i, j int) error {
+	if i < 0 || i >= len(al.items) || j < 0 || j >= len(al.items) {
+		return fmt.Errorf("index out of range, i:%d, j:%d, len:%d", i, j, al.Size())
+	}
+
+	al.items[i], al.items[j] = al.items[j], al.items[i]
+	return nil
+}
+
+func (al *ArrayList[T]) Reverse() {
+	for i, j := 0, len(al.items)-1; i < j; i, j = i+1, j-1 {
+		al.items[i], al.items[j] = al.items[j], al.items[i]
+	}
+}
+
+func (al *ArrayList[T]) SubList(fromIndex, toIndex int) (*SubListOf[T], error) {
+	return newSubList[T](al, fromIndex, toIndex, al.Size())
+}
+
+func (al *arrayList) SubList(fromIndex, toIndex int) (*SubList, error) {
+	sub, err := al.ArrayList.SubList(fromIndex, toIndex)
+	if err != nil {
+		return nil, err
 	}
+	return &SubList{sub}, nil
 }
 
-func (al *arrayList) Iterator() (func() (interface{}, bool), bool) {
+func (al *ArrayList[T]) Iterator() (func() (T, bool), bool) {
 	index := 0
 
-	return func() (interface{}, bool) {
-		var element interface{}
+	return func() (T, bool) {
+		var element T
 		if index < al.Size() {
 			element = al.items[index]
 			index++
-		} else {
-			element = nil
 		}
 		return element, index < al.Size()
 	}, index < al.Size()
 }
 
-func (al *arrayList) ReverseIterator() (func() (interface{}, bool), bool) {
+func (al *ArrayList[T]) ReverseIterator() (func() (T, bool), bool) {
 	index := al.Size() - 1
 
-	return func() (interface{}, bool) {
-		var element interface{}
+	return func() (T, bool) {
+		var element T
 		if index >= 0 {
 			element = al.items[index]
 			index--
-		} else {
-			element = nil
 		}
 		return element, index >= 0
 	}, index >= 0
 }
 
-func (al *arrayList) shrinkList() {
+func (al *ArrayList[T]) ListIterator(index int) (ListIteratorOf[T], error) {
+	return newIndexedIterator[T](al, index, al.Size())
+}
+
+func (al *ArrayList[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i := 0; i < len(al.items); i++ {
+			if !yield(i, al.items[i]) {
+				return
+			}
+		}
+	}
+}
+
+func (al *ArrayList[T]) Backward() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i := len(al.items) - 1; i >= 0; i-- {
+			if !yield(i, al.items[i]) {
+				return
+			}
+		}
+	}
+}
+
+func (al *ArrayList[T]) shrinkList() {
 	oldcap := cap(al.items)
 	if oldcap <= 1024 {
 		// no need to shrink when the capacity is less than 1024
@@ -177,9 +364,37 @@ func (al *arrayList) shrinkList() {
 	}
 	oldlen := len(al.items)
 	if oldlen <= oldcap/4 { // shrink when len(list) <= cap(list)/4
-		newItems := make([]interface{}, oldlen)
+		newItems := make([]T, oldlen)
 		copy(newItems, al.items)
 		al.Clear()
 		al.items = newItems
 	}
 }
+
+// span returns an iterator over the index-value pairs in the range [fromIndex, toIndex).
+func (al *ArrayList[T]) span(fromIndex, toIndex int, backward bool) iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		if backward {
+			for i := toIndex - 1; i >= fromIndex; i-- {
+				if !yield(i, al.items[i]) {
+					return
+				}
+			}
+			return
+		}
+		for i := fromIndex; i < toIndex; i++ {
+			if !yield(i, al.items[i]) {
+				return
+			}
+		}
+	}
+}
+
+// replace replaces the elements starting at the specified position with vals.
+func (al *ArrayList[T]) replace(index int, vals []T) {
+	copy(al.items[index:], vals)
+}
+
+func (al *ArrayList[T]) modifications() int {
+	return al.modCount
+}
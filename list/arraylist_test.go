@@ -4,6 +4,7 @@
 package list_test
 
 import (
+	"reflect"
 	"testing"
 
 	"github.com/ahrtr/gocontainer/list"
@@ -297,3 +298,84 @@ func (aln *arrayListNode) Compare(v1, v2 interface{}) (int, error) {
 
 	return 1, nil
 }
+
+func TestArrayListOf(t *testing.T) {
+	al := list.NewArrayListOf[string]()
+	al.Add("benjamin", "alice", "john")
+
+	if !list.Contains(al, "alice") || list.Contains(al, "tom") {
+		t.Error("Unexpected result of Contains")
+	}
+
+	v, err := al.Get(2)
+	if err != nil || v != "john" {
+		t.Errorf("The value isn't expected, expect: john, actual: %s, error: %v\n", v, err)
+	}
+
+	if _, err := al.Get(3); err == nil {
+		t.Error("An error is expected when the index is out of range")
+	}
+
+	al.SortFunc(func(v1, v2 string) int { return len(v1) - len(v2) })
+	expected := []string{"john", "alice", "benjamin"}
+	for i, e := range expected {
+		if v, _ := al.Get(i); v != e {
+			t.Errorf("The value isn't expected, index: %d, expect: %s, actual: %s\n", i, e, v)
+		}
+	}
+}
+
+func TestArrayListOfNonComparable(t *testing.T) {
+	// The slices aren't comparable, so they can only be looked up by a predicate.
+	al := list.NewArrayListOf[[]int]()
+	al.Add([]int{1}, []int{2}, []int{3})
+	is := func(n int) func(v []int) bool {
+		return func(v []int) bool { return v[0] == n }
+	}
+
+	if !al.ContainsFunc(is(2)) || al.ContainsFunc(is(4)) {
+		t.Error("Unexpected result of ContainsFunc")
+	}
+	if !al.RemoveFunc(is(2)) || al.RemoveFunc(is(2)) {
+		t.Error("Unexpected result of RemoveFunc")
+	}
+	if al.Size() != 2 {
+		t.Errorf("The length isn't expected, expect: 2, actual: %d\n", al.Size())
+	}
+
+	// So are the slices stored in a list of interface{} values.
+	l := list.NewArrayList()
+	l.Add([]int{1}, []int{2})
+	if !l.RemoveFunc(func(v interface{}) bool { return v.([]int)[0] == 1 }) || l.ContainsFunc(func(v interface{}) bool { return v.([]int)[0] == 1 }) {
+		t.Error("Unexpected result of RemoveFunc or ContainsFunc")
+	}
+}
+
+func TestArrayListAll(t *testing.T) {
+	al := list.NewArrayList()
+	al.Add(5, 6, 7)
+
+	expected := []interface{}{5, 6, 7}
+	count := 0
+	for i, v := range al.All() {
+		if v != expected[i] {
+			t.Errorf("The value isn't expected, index: %d, expect: %v, actual: %v\n", i, expected[i], v)
+		}
+		count++
+	}
+	if count != 3 {
+		t.Errorf("The count isn't expected, expect: 3, actual: %d\n", count)
+	}
+
+	// break the iteration at index 1
+	indexes := []int{}
+	for i := range al.Backward() {
+		indexes = append(indexes, i)
+		if i == 1 {
+			break
+		}
+	}
+	if !reflect.DeepEqual(indexes, []int{2, 1}) {
+		t.Errorf("The indexes aren't expected, expect: [2 1], actual: %v\n", indexes)
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright (c) 2019, Benjamin Wang (benjamin_wang@aliyun.com). All rights reserved.
+// Licensed under the MIT license that can be found in the LICENSE file.
+
+package list
+
+// newLike returns an empty list of the same implementation as l. A sublist is of the implementation of
+// its root list, and the lists of the other implementations, e.g. the thread-safe lists, get an ArrayList.
+func newLike(l Interface) Interface {
+	switch v := l.(type) {
+	case *linkedList:
+		return NewLinkedList()
+	case *SubList:
+		p := v.parent
+		for s, ok := p.(*SubListOf[interface{}]); ok; s, ok = p.(*SubListOf[interface{}]) {
+			p = s.parent
+		}
+		if _, ok := p.(*LinkedList[interface{}]); ok {
+			return NewLinkedList()
+		}
+	}
+	return NewArrayList()
+}
+
+// Filter returns a new list of the same implementation as l, containing the elements of l
+// which satisfy the predicate, in the same order.
+func Filter(l Interface, pred func(val interface{}) bool) Interface {
+	ret := newLike(l)
+	for _, v := range l.All() {
+		if pred(v) {
+			ret.Add(v)
+		}
+	}
+	return ret
+}
+
+// Map returns a new list of the same implementation as l, containing the results of applying fn
+// to each element of l, in the same order.
+func Map(l Interface, fn func(val interface{}) interface{}) Interface {
+	ret := newLike(l)
+	for _, v := range l.All() {
+		ret.Add(fn(v))
+	}
+	return ret
+}
+
+// Reduce combines the elements of l in proper sequence, by calling fn with the accumulated value,
+// which starts from initial, and each element. It returns initial if l is empty.
+func Reduce(l Interface, initial interface{}, fn func(acc, val interface{}) interface{}) interface{} {
+	acc := initial
+	for _, v := range l.All() {
+		acc = fn(acc, v)
+	}
+	return acc
+}
+
+// Partition returns two new lists of the same implementation as l. The first one contains the
+// elements of l which satisfy the predicate, and the second one contains the others.
+func Partition(l Interface, pred func(val interface{}) bool) (matched, unmatched Interface) {
+	matched, unmatched = newLike(l), newLike(l)
+	for _, v := range l.All() {
+		if pred(v) {
+			matched.Add(v)
+		} else {
+			unmatched.Add(v)
+		}
+	}
+	return matched, unmatched
+}
+
+// GroupBy groups the elements of l by the keys returned by key, which must be comparable. Each group
+// is a new list of the same implementation as l, containing the elements in the same order as in l.
+func GroupBy(l Interface, key func(val interface{}) interface{}) map[interface{}]Interface {
+	groups := make(map[interface{}]Interface)
+	for _, v := range l.All() {
+		k := key(v)
+		g, ok := groups[k]
+		if !ok {
+			g = newLike(l)
+			groups[k] = g
+		}
+		g.Add(v)
+	}
+	return groups
+}
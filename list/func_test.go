@@ -0,0 +1,109 @@
+// Copyright (c) 2019, Benjamin Wang (benjamin_wang@aliyun.com). All rights reserved.
+// Licensed under the MIT license that can be found in the LICENSE file.
+
+package list_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ahrtr/gocontainer/list"
+)
+
+func TestListBulkOperations(t *testing.T) {
+	for name, newList := range newLists {
+		t.Run(name, func(t *testing.T) {
+			l := newList()
+			l.Add(1, 2, 3, 4, 5, 6, 7, 8)
+
+			isOdd := func(v interface{}) bool { return v.(int)%2 != 0 }
+			if removed := l.RemoveIf(isOdd); removed != 4 {
+				t.Fatalf("RemoveIf, expect: 4, actual: %d", removed)
+			}
+			checkValues(t, l, 2, 4, 6, 8)
+			if removed := l.RemoveIf(isOdd); removed != 0 {
+				t.Fatalf("RemoveIf, expect: 0, actual: %d", removed)
+			}
+
+			l.ReplaceAll(func(v interface{}) interface{} { return v.(int) + 1 })
+			checkValues(t, l, 3, 5, 7, 9)
+
+			var got []interface{}
+			l.ForEach(func(v interface{}) { got = append(got, v) })
+			if !reflect.DeepEqual(got, []interface{}{3, 5, 7, 9}) {
+				t.Fatalf("ForEach, unexpected values: %v", got)
+			}
+
+			// The bulk operations on a sublist only affect the portion of the parent list.
+			sub, _ := l.SubList(1, 3)
+			sub.ReplaceAll(func(v interface{}) interface{} { return v.(int) * 10 })
+			checkValues(t, l, 3, 50, 70, 9)
+			if removed := sub.RemoveIf(func(v interface{}) bool { return v == 50 }); removed != 1 {
+				t.Fatalf("RemoveIf, expect: 1, actual: %d", removed)
+			}
+			checkValues(t, sub, 70)
+			checkValues(t, l, 3, 70, 9)
+
+			if removed := l.RemoveIf(func(interface{}) bool { return true }); removed != 3 || !l.IsEmpty() {
+				t.Fatalf("failed to remove all the elements, removed: %d, size: %d", removed, l.Size())
+			}
+		})
+	}
+}
+
+func TestListFunctions(t *testing.T) {
+	for name, newList := range newLists {
+		t.Run(name, func(t *testing.T) {
+			l := newList()
+			l.Add(1, 2, 3, 4, 5, 6, 7)
+			sameType := func(other list.Interface) {
+				t.Helper()
+				if reflect.TypeOf(other) != reflect.TypeOf(l) {
+					t.Fatalf("expect a list of %T, actual: %T", l, other)
+				}
+			}
+
+			evens := list.Filter(l, func(v interface{}) bool { return v.(int)%2 == 0 })
+			sameType(evens)
+			checkValues(t, evens, 2, 4, 6)
+
+			squares := list.Map(l, func(v interface{}) interface{} { return v.(int) * v.(int) })
+			sameType(squares)
+			checkValues(t, squares, 1, 4, 9, 16, 25, 36, 49)
+
+			sum := list.Reduce(l, 0, func(acc, v interface{}) interface{} { return acc.(int) + v.(int) })
+			if sum != 28 {
+				t.Fatalf("Reduce, expect: 28, actual: %v", sum)
+			}
+			if v := list.Reduce(newList(), "init", nil); v != "init" {
+				t.Fatalf("Reduce on an empty list, expect: init, actual: %v", v)
+			}
+
+			small, large := list.Partition(l, func(v interface{}) bool { return v.(int) < 3 })
+			sameType(small)
+			sameType(large)
+			checkValues(t, small, 1, 2)
+			checkValues(t, large, 3, 4, 5, 6, 7)
+
+			groups := list.GroupBy(l, func(v interface{}) interface{} { return v.(int) % 3 })
+			if len(groups) != 3 {
+				t.Fatalf("GroupBy, expect 3 groups, actual: %d", len(groups))
+			}
+			sameType(groups[0])
+			checkValues(t, groups[0], 3, 6)
+			checkValues(t, groups[1], 1, 4, 7)
+			checkValues(t, groups[2], 2, 5)
+
+			// The functions work over a sublist, and return lists of the implementation of the parent list.
+			sub, _ := l.SubList(2, 5)
+			doubled := list.Map(sub, func(v interface{}) interface{} { return v.(int) * 2 })
+			sameType(doubled)
+			checkValues(t, doubled, 6, 8, 10)
+			nested, _ := sub.SubList(1, 3)
+			sameType(list.Filter(nested, func(interface{}) bool { return true }))
+
+			// The original list is left unchanged.
+			checkValues(t, l, 1, 2, 3, 4, 5, 6, 7)
+		})
+	}
+}
@@ -4,30 +4,87 @@
 package list
 
 import (
+	"iter"
+
 	"github.com/ahrtr/gocontainer/collection"
 	"github.com/ahrtr/gocontainer/utils"
 )
 
+// ReadOnlyInterface is the read side of a list, which is implemented by all the lists, including SortedList.
+type ReadOnlyInterface interface {
+	// Size returns the number of elements in this list.
+	Size() int
+	// IsEmpty returns true if this list contains no elements.
+	IsEmpty() bool
+
+	// Contains returns true if this list contains the specified element. The elements of the lists other than
+	// SortedList are compared with ==, which panics if they have identical dynamic types which aren't comparable,
+	// e.g. slices; use ContainsFunc instead.
+	Contains(val interface{}) bool
+	// Get returns the element at the specified position in this list. The index must be in the range of [0, size).
+	Get(index int) (interface{}, error)
+	// IndexOf returns the index of the first occurrence of the specified element in this list, or -1 if it isn't present.
+	// It panics on the non-comparable elements as Contains does; use IndexFunc instead.
+	IndexOf(val interface{}) int
+	// LastIndexOf returns the index of the last occurrence of the specified element in this list, or -1 if it isn't present.
+	// It panics on the non-comparable elements as Contains does; use LastIndexFunc instead.
+	LastIndexOf(val interface{}) int
+
+	// Iterator returns an iterator over the elements in this list in proper sequence.
+	Iterator() (func() (interface{}, bool), bool)
+	// ReverseIterator returns an iterator over the elements in this list in reverse sequence as Iterator.
+	ReverseIterator() (func() (interface{}, bool), bool)
+
+	// All returns an iterator over the index-value pairs in this list in proper sequence.
+	All() iter.Seq2[int, interface{}]
+	// Backward returns an iterator over the index-value pairs in this list in reverse sequence,
+	// with the indexes descending.
+	Backward() iter.Seq2[int, interface{}]
+}
+
 // Interface is a type of list, both ArrayList and LinkedList implement this interface.
 type Interface interface {
 	collection.Interface
+	ReadOnlyInterface
 
 	// Add appends the specified elements to the end of this list.
 	Add(vals ...interface{})
 	// AddTo inserts the specified element at the specified position in this list.
 	AddTo(index int, val interface{}) error
+	// AddAllAt inserts the specified elements at the specified position in this list, keeping their order.
+	AddAllAt(index int, vals ...interface{}) error
 
-	// Contains returns true if this list contains the specified element.
-	Contains(val interface{}) bool
-	// Get returns the element at the specified position in this list. The index must be in the range of [0, size).
-	Get(index int) (interface{}, error)
+	// ContainsFunc returns true if this list contains an element satisfying pred.
+	ContainsFunc(pred func(val interface{}) bool) bool
+	// IndexFunc returns the index of the first element satisfying pred in this list, or -1 if no element satisfies pred.
+	IndexFunc(pred func(val interface{}) bool) int
+	// LastIndexFunc returns the index of the last element satisfying pred in this list, or -1 if no element satisfies pred.
+	LastIndexFunc(pred func(val interface{}) bool) int
+
+	// Set replaces the element at the specified position in this list with the specified element,
+	// and returns the element previously at the position. The index must be in the range of [0, size).
+	Set(index int, val interface{}) (interface{}, error)
 
 	// Remove removes the element at the specified position in this list.
 	// It returns an error if the index is out of range.
 	Remove(index int) (interface{}, error)
 	// RemoveByValue removes the first occurrence of the specified element from this list, if it is present.
 	// It returns false if the target value isn't present, otherwise returns true.
+	// It panics on the non-comparable elements as Contains does; use RemoveFunc instead.
 	RemoveByValue(val interface{}) bool
+	// RemoveFunc removes the first element satisfying pred from this list, if any.
+	// It returns false if no element satisfies pred, otherwise returns true.
+	RemoveFunc(pred func(val interface{}) bool) bool
+	// RemoveRange removes all the elements whose index is in the range of [fromIndex, toIndex) from this list.
+	RemoveRange(fromIndex, toIndex int) error
+	// RemoveIf removes all the elements satisfying the predicate from this list in a single pass,
+	// and returns the number of elements removed.
+	RemoveIf(pred func(val interface{}) bool) int
+
+	// ReplaceAll replaces each element in this list with the result of applying fn to it.
+	ReplaceAll(fn func(val interface{}) interface{})
+	// ForEach calls fn for each element in this list in proper sequence.
+	ForEach(fn func(val interface{}))
 
 	// Sort sorts the element using default options below. It sorts the elements into ascending sequence according to their natural ordering.
 	//     reverse: false
@@ -39,9 +96,42 @@ type Interface interface {
 	//     c:       sort the data according to the provided comparator
 	// If reverse is true, and a comparator is also provided, then the result will be the reverse sequence as the comparator generates.
 	SortWithOptions(reverse bool, c utils.Comparator)
+	// Swap swaps the elements at the specified positions in this list.
+	Swap(i, j int) error
+	// Reverse reverses the order of the elements in this list.
+	Reverse()
 
-	// Iterator returns an iterator over the elements in this list in proper sequence.
-	Iterator() (func() (interface{}, bool), bool)
-	// ReverseIterator returns an iterator over the elements in this list in reverse sequence as Iterator.
-	ReverseIterator() (func() (interface{}, bool), bool)
+	// SubList returns a view of the portion of this list in the range of [fromIndex, toIndex). The sublist is
+	// backed by this list, so the changes in the sublist are reflected in this list. It becomes invalid once
+	// this list is structurally modified other than through the sublist, see SubListOf.
+	SubList(fromIndex, toIndex int) (*SubList, error)
+
+	// ListIterator returns a ListIterator over the elements in this list, starting at the specified position,
+	// which is the index of the first element returned by Next. The index must be in the range of [0, size].
+	ListIterator(index int) (ListIterator, error)
+}
+
+// Contains returns true if the list, e.g. an ArrayList[T] or a LinkedList[T], contains the specified element.
+// The elements of the type-parameterized lists are only compared with == if they're comparable, which is
+// checked at compile time, otherwise they are looked up by the ContainsFunc method of the list.
+func Contains[T comparable](l interface{ ContainsFunc(pred func(val T) bool) bool }, val T) bool {
+	return l.ContainsFunc(func(v T) bool { return v == val })
+}
+
+// IndexOf returns the index of the first occurrence of the specified element in the list, e.g. an ArrayList[T],
+// a LinkedList[T] or a SubListOf[T], or -1 if it isn't present.
+func IndexOf[T comparable](l interface{ IndexFunc(pred func(val T) bool) int }, val T) int {
+	return l.IndexFunc(func(v T) bool { return v == val })
+}
+
+// LastIndexOf returns the index of the last occurrence of the specified element in the list, e.g. an ArrayList[T],
+// a LinkedList[T] or a SubListOf[T], or -1 if it isn't present.
+func LastIndexOf[T comparable](l interface{ LastIndexFunc(pred func(val T) bool) int }, val T) int {
+	return l.LastIndexFunc(func(v T) bool { return v == val })
+}
+
+// RemoveByValue removes the first occurrence of the specified element from the list, e.g. an ArrayList[T] or
+// a LinkedList[T], if it is present. It returns false if the target value isn't present, otherwise returns true.
+func RemoveByValue[T comparable](l interface{ RemoveFunc(pred func(val T) bool) bool }, val T) bool {
+	return l.RemoveFunc(func(v T) bool { return v == val })
 }
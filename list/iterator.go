@@ -0,0 +1,295 @@
+// Copyright (c) 2019, Benjamin Wang (benjamin_wang@aliyun.com). All rights reserved.
+// Licensed under the MIT license that can be found in the LICENSE file.
+
+package list
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrNoSuchElement is returned by ListIterator.Next and ListIterator.Previous when there is no more element.
+	ErrNoSuchElement = errors.New("list: no such element")
+	// ErrIllegalState is returned by ListIterator.Remove and ListIterator.Set when neither Next nor Previous
+	// has been called, or Remove or Add has been called after the last call to Next or Previous.
+	ErrIllegalState = errors.New("list: illegal iterator state")
+)
+
+// ListIteratorOf is an iterator for lists, whose elements are of type T, which allows to traverse the list
+// in either direction, and to modify the list during iteration. Its cursor always lies between the element
+// that would be returned by Previous and the element that would be returned by Next.
+//
+// The iterator fails fast with ErrConcurrentModification if the list is structurally modified other than
+// through the iterator after the iterator is created.
+type ListIteratorOf[T any] interface {
+	// HasNext returns true if there are more elements when traversing the list in the forward direction.
+	HasNext() bool
+	// Next returns the next element in the list and advances the cursor.
+	Next() (T, error)
+	// HasPrevious returns true if there are more elements when traversing the list in the reverse direction.
+	HasPrevious() bool
+	// Previous returns the previous element in the list and moves the cursor backwards.
+	Previous() (T, error)
+	// NextIndex returns the index of the element that would be returned by Next, or the list size at the end of the list.
+	NextIndex() int
+	// PreviousIndex returns the index of the element that would be returned by Previous, or -1 at the beginning of the list.
+	PreviousIndex() int
+
+	// Remove removes the last element returned by Next or Previous from the list.
+	Remove() error
+	// Set replaces the last element returned by Next or Previous with the specified element.
+	Set(val T) error
+	// Add inserts the specified element into the list immediately before the element that would be returned by
+	// Next, so a subsequent call to Next is unaffected, and a subsequent call to Previous returns the new element.
+	Add(val T) error
+}
+
+// ListIterator is an iterator for the lists created by NewArrayList or NewLinkedList.
+type ListIterator = ListIteratorOf[interface{}]
+
+// indexedIterator is a ListIteratorOf[T] for the lists which are accessed by index efficiently.
+type indexedIterator[T any] struct {
+	l backing[T]
+	// size is the size of the list, as seen by the iterator.
+	size   int
+	cursor int
+	// lastRet is the index of the element returned by the last call to Next or Previous, or -1 if there is none.
+	lastRet  int
+	modCount int
+}
+
+func newIndexedIterator[T any](l backing[T], index, size int) (ListIteratorOf[T], error) {
+	if index < 0 || index > size {
+		return nil, fmt.Errorf("index out of range, index:%d, len:%d", index, size)
+	}
+
+	return &indexedIterator[T]{
+		l:        l,
+		size:     size,
+		cursor:   index,
+		lastRet:  -1,
+		modCount: l.modifications(),
+	}, nil
+}
+
+func (it *indexedIterator[T]) check() error {
+	if it.l.modifications() != it.modCount {
+		return ErrConcurrentModification
+	}
+	return nil
+}
+
+func (it *indexedIterator[T]) HasNext() bool {
+	return it.cursor < it.size
+}
+
+func (it *indexedIterator[T]) Next() (T, error) {
+	var zero T
+	if err := it.check(); err != nil {
+		return zero, err
+	}
+	if !it.HasNext() {
+		return zero, ErrNoSuchElement
+	}
+
+	val, err := it.l.Get(it.cursor)
+	if err != nil {
+		return zero, err
+	}
+	it.lastRet = it.cursor
+	it.cursor++
+	return val, nil
+}
+
+func (it *indexedIterator[T]) HasPrevious() bool {
+	return it.cursor > 0
+}
+
+func (it *indexedIterator[T]) Previous() (T, error) {
+	var zero T
+	if err := it.check(); err != nil {
+		return zero, err
+	}
+	if !it.HasPrevious() {
+		return zero, ErrNoSuchElement
+	}
+
+	val, err := it.l.Get(it.cursor - 1)
+	if err != nil {
+		return zero, err
+	}
+	it.cursor--
+	it.lastRet = it.cursor
+	return val, nil
+}
+
+func (it *indexedIterator[T]) NextIndex() int {
+	return it.cursor
+}
+
+func (it *indexedIterator[T]) PreviousIndex() int {
+	return it.cursor - 1
+}
+
+func (it *indexedIterator[T]) Remove() error {
+	if err := it.check(); err != nil {
+		return err
+	}
+	if it.lastRet < 0 {
+		return ErrIllegalState
+	}
+
+	if err := it.l.RemoveRange(it.lastRet, it.lastRet+1); err != nil {
+		return err
+	}
+	it.cursor = it.lastRet
+	it.lastRet = -1
+	it.size--
+	it.modCount = it.l.modifications()
+	return nil
+}
+
+func (it *indexedIterator[T]) Set(val T) error {
+	if err := it.check(); err != nil {
+		return err
+	}
+	if it.lastRet < 0 {
+		return ErrIllegalState
+	}
+
+	_, err := it.l.Set(it.lastRet, val)
+	return err
+}
+
+func (it *indexedIterator[T]) Add(val T) error {
+	if err := it.check(); err != nil {
+		return err
+	}
+
+	if err := it.l.AddAllAt(it.cursor, val); err != nil {
+		return err
+	}
+	it.cursor++
+	it.lastRet = -1
+	it.size++
+	it.modCount = it.l.modifications()
+	return nil
+}
+
+// linkedListIterator is a ListIteratorOf[T] for LinkedList[T], which modifies the list in O(1) time.
+type linkedListIterator[T any] struct {
+	ll *LinkedList[T]
+	// next is the element that would be returned by Next, or nil at the end of the list.
+	next      *element[T]
+	nextIndex int
+	// lastReturned is the element returned by the last call to Next or Previous, or nil if there is none.
+	lastReturned *element[T]
+	modCount     int
+}
+
+func (it *linkedListIterator[T]) check() error {
+	if it.ll.modCount != it.modCount {
+		return ErrConcurrentModification
+	}
+	return nil
+}
+
+func (it *linkedListIterator[T]) HasNext() bool {
+	return it.nextIndex < it.ll.length
+}
+
+func (it *linkedListIterator[T]) Next() (T, error) {
+	var zero T
+	if err := it.check(); err != nil {
+		return zero, err
+	}
+	if !it.HasNext() {
+		return zero, ErrNoSuchElement
+	}
+
+	it.lastReturned = it.next
+	it.next = it.next.next
+	it.nextIndex++
+	return it.lastReturned.value, nil
+}
+
+func (it *linkedListIterator[T]) HasPrevious() bool {
+	return it.nextIndex > 0
+}
+
+func (it *linkedListIterator[T]) Previous() (T, error) {
+	var zero T
+	if err := it.check(); err != nil {
+		return zero, err
+	}
+	if !it.HasPrevious() {
+		return zero, ErrNoSuchElement
+	}
+
+	if it.next == nil {
+		it.next = it.ll.tail
+	} else {
+		it.next = it.next.prev
+	}
+	it.lastReturned = it.next
+	it.nextIndex--
+	return it.lastReturned.value, nil
+}
+
+func (it *linkedListIterator[T]) NextIndex() int {
+	return it.nextIndex
+}
+
+func (it *linkedListIterator[T]) PreviousIndex() int {
+	return it.nextIndex - 1
+}
+
+func (it *linkedListIterator[T]) Remove() error {
+	if err := it.check(); err != nil {
+		return err
+	}
+	if it.lastReturned == nil {
+		return ErrIllegalState
+	}
+
+	lastNext := it.lastReturned.next
+	if it.next == it.lastReturned {
+		// The element was returned by Previous.
+		it.next = lastNext
+	} else {
+		it.nextIndex--
+	}
+	it.ll.unlink(it.lastReturned)
+	it.lastReturned = nil
+	it.modCount = it.ll.modCount
+	return nil
+}
+
+func (it *linkedListIterator[T]) Set(val T) error {
+	if err := it.check(); err != nil {
+		return err
+	}
+	if it.lastReturned == nil {
+		return ErrIllegalState
+	}
+
+	it.lastReturned.value = val
+	return nil
+}
+
+func (it *linkedListIterator[T]) Add(val T) error {
+	if err := it.check(); err != nil {
+		return err
+	}
+
+	if it.next == nil {
+		it.ll.linkLast(val)
+	} else {
+		it.ll.linkBefore(val, it.next)
+	}
+	it.nextIndex++
+	it.lastReturned = nil
+	it.modCount = it.ll.modCount
+	return nil
+}
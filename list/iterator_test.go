@@ -0,0 +1,120 @@
+// Copyright (c) 2019, Benjamin Wang (benjamin_wang@aliyun.com). All rights reserved.
+// Licensed under the MIT license that can be found in the LICENSE file.
+
+package list_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ahrtr/gocontainer/list"
+)
+
+func TestListIterator(t *testing.T) {
+	newSubList := func() list.Interface {
+		l := list.NewLinkedList()
+		l.Add(-1, -2)
+		sub, _ := l.SubList(1, 1)
+		return sub
+	}
+	lists := map[string]func() list.Interface{"subList": newSubList}
+	for name, newList := range newLists {
+		lists[name] = newList
+	}
+
+	for name, newList := range lists {
+		t.Run(name, func(t *testing.T) {
+			l := newList()
+			l.Add(1, 2, 3, 4, 5, 6)
+
+			// Remove the odd numbers, double the others, and insert 0 after each of them.
+			it, err := l.ListIterator(0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !it.HasNext() || it.HasPrevious() || it.NextIndex() != 0 || it.PreviousIndex() != -1 {
+				t.Fatal("unexpected iterator state at the beginning of the list")
+			}
+			if err := it.Remove(); err != list.ErrIllegalState {
+				t.Fatalf("expect ErrIllegalState, actual: %v", err)
+			}
+			for it.HasNext() {
+				v, err := it.Next()
+				if err != nil {
+					t.Fatal(err)
+				}
+				if v.(int)%2 != 0 {
+					if err := it.Remove(); err != nil {
+						t.Fatal(err)
+					}
+					if err := it.Set(0); err != list.ErrIllegalState {
+						t.Fatalf("expect ErrIllegalState, actual: %v", err)
+					}
+					continue
+				}
+				if err := it.Set(v.(int) * 2); err != nil {
+					t.Fatal(err)
+				}
+				if err := it.Add(0); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if _, err := it.Next(); err != list.ErrNoSuchElement {
+				t.Fatalf("expect ErrNoSuchElement, actual: %v", err)
+			}
+			if it.NextIndex() != 6 {
+				t.Fatalf("NextIndex, expect: 6, actual: %d", it.NextIndex())
+			}
+			checkValues(t, l, 4, 0, 8, 0, 12, 0)
+
+			// Walk backwards, remove the zeros, and insert the halves before the others.
+			for it.HasPrevious() {
+				v, err := it.Previous()
+				if err != nil {
+					t.Fatal(err)
+				}
+				if v == 0 {
+					if err := it.Remove(); err != nil {
+						t.Fatal(err)
+					}
+					continue
+				}
+				if err := it.Add(v.(int) / 2); err != nil {
+					t.Fatal(err)
+				}
+				// Previous returns the new element.
+				if p, _ := it.Previous(); p != v.(int)/2 {
+					t.Fatalf("Previous, expect: %d, actual: %v", v.(int)/2, p)
+				}
+			}
+			if _, err := it.Previous(); err != list.ErrNoSuchElement {
+				t.Fatalf("expect ErrNoSuchElement, actual: %v", err)
+			}
+			checkValues(t, l, 2, 4, 4, 8, 6, 12)
+
+			// Start in the middle, and add at the end of the list.
+			it, err = l.ListIterator(3)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if v, _ := it.Previous(); v != 4 {
+				t.Fatalf("Previous, expect: 4, actual: %v", v)
+			}
+			it, _ = l.ListIterator(l.Size())
+			it.Add(14)
+			checkValues(t, l, 2, 4, 4, 8, 6, 12, 14)
+			if _, err := l.ListIterator(l.Size() + 1); err == nil {
+				t.Fatal("ListIterator should fail with an index out of range")
+			}
+
+			// The structural changes other than through the iterator are detected.
+			l.Remove(0)
+			if _, err := it.Previous(); !errors.Is(err, list.ErrConcurrentModification) {
+				t.Fatalf("expect ErrConcurrentModification, actual: %v", err)
+			}
+			if err := it.Add(1); !errors.Is(err, list.ErrConcurrentModification) {
+				t.Fatalf("expect ErrConcurrentModification, actual: %v", err)
+			}
+		})
+	}
+}
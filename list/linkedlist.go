@@ -23,50 +23,63 @@ package list
 
 import (
 	"fmt"
+	"iter"
 
 	"github.com/ahrtr/gocontainer/utils"
 )
 
-type element struct {
-	next, prev *element
+type element[T any] struct {
+	next, prev *element[T]
 	// The value stored with this element.
-	value interface{}
+	value T
 }
 
-// linkedList represents a doubly linked list.
-// It implements the interface list.Interface.
-type linkedList struct {
-	head   *element
-	tail   *element
+// LinkedList represents a doubly linked list, whose elements are of type T.
+type LinkedList[T any] struct {
+	head   *element[T]
+	tail   *element[T]
 	length int
+	// modCount is the number of times the list has been structurally modified,
+	// which is used by the sublists to detect the concurrent modifications.
+	modCount int
+}
+
+// linkedList is the list returned by NewLinkedList, it implements the interface list.Interface.
+type linkedList struct {
+	*LinkedList[interface{}]
 }
 
 // NewLinkedList initializes and returns an LinkedList.
 func NewLinkedList() Interface {
-	return &linkedList{
+	return &linkedList{NewLinkedListOf[interface{}]()}
+}
+
+// NewLinkedListOf initializes and returns an LinkedList, whose elements are of type T.
+func NewLinkedListOf[T any]() *LinkedList[T] {
+	return &LinkedList[T]{
 		head:   nil,
 		tail:   nil,
 		length: 0,
 	}
 }
 
-func (ll *linkedList) Size() int {
+func (ll *LinkedList[T]) Size() int {
 	return ll.length
 }
 
-func (ll *linkedList) IsEmpty() bool {
+func (ll *LinkedList[T]) IsEmpty() bool {
 	return ll.Size() == 0
 }
 
-func (ll *linkedList) Add(vals ...interface{}) {
+func (ll *LinkedList[T]) Add(vals ...T) {
 	for _, v := range vals {
 		ll.linkLast(v)
 	}
 }
 
 // linkLast links val as last element.
-func (ll *linkedList) linkLast(val interface{}) {
-	e := element{
+func (ll *LinkedList[T]) linkLast(val T) {
+	e := element[T]{
 		prev:  ll.tail,
 		next:  nil,
 		value: val,
@@ -79,9 +92,10 @@ func (ll *linkedList) linkLast(val interface{}) {
 		ll.tail = &e
 	}
 	ll.length++
+	ll.modCount++
 }
 
-func (ll *linkedList) AddTo(index int, val interface{}) error {
+func (ll *LinkedList[T]) AddTo(index int, val T) error {
 	size := ll.Size()
 	if index < 0 || index > size {
 		return fmt.Errorf("index out of range, index:%d, len:%d", index, size)
@@ -96,9 +110,27 @@ func (ll *linkedList) AddTo(index int, val interface{}) error {
 	return nil
 }
 
+func (ll *LinkedList[T]) AddAllAt(index int, vals ...T) error {
+	size := ll.Size()
+	if index < 0 || index > size {
+		return fmt.Errorf("index out of range, index:%d, len:%d", index, size)
+	}
+
+	if index == size {
+		ll.Add(vals...)
+	} else {
+		e := ll.getElement(index)
+		for _, v := range vals {
+			ll.linkBefore(v, e)
+		}
+	}
+
+	return nil
+}
+
 // linkBefore inserts val before non-null element e.
-func (ll *linkedList) linkBefore(val interface{}, e *element) {
-	newElement := element{
+func (ll *LinkedList[T]) linkBefore(val T, e *element[T]) {
+	newElement := element[T]{
 		prev:  nil,
 		next:  e,
 		value: val,
@@ -117,12 +149,13 @@ func (ll *linkedList) linkBefore(val interface{}, e *element) {
 	}
 
 	ll.length++
+	ll.modCount++
 }
 
 // getElement returns the element at the specified position.
-func (ll *linkedList) getElement(index int) *element {
+func (ll *LinkedList[T]) getElement(index int) *element[T] {
 	size := ll.Size()
-	var e *element
+	var e *element[T]
 	if index < (size >> 1) {
 		e = ll.head
 		for i := 0; i < index; i++ {
@@ -138,16 +171,24 @@ func (ll *linkedList) getElement(index int) *element {
 }
 
 func (ll *linkedList) Contains(val interface{}) bool {
-	return ll.indexOf(val) >= 0
+	return ll.IndexOf(val) >= 0
+}
+
+// ContainsFunc returns true if the list contains an element satisfying pred.
+func (ll *LinkedList[T]) ContainsFunc(pred func(val T) bool) bool {
+	return ll.IndexFunc(pred) >= 0
+}
+
+func (ll *linkedList) IndexOf(val interface{}) int {
+	return ll.IndexFunc(func(v interface{}) bool { return val == v })
 }
 
-// indexOf returns the index of the first occurrence of the specified element
-// in this list, or -1 if this list does not contain the element.
-func (ll *linkedList) indexOf(val interface{}) int {
+// IndexFunc returns the index of the first element satisfying pred, or -1 if no element satisfies pred.
+func (ll *LinkedList[T]) IndexFunc(pred func(val T) bool) int {
 	index := 0
 
 	for e := ll.head; e != nil; e = e.next {
-		if val == e.value {
+		if pred(e.value) {
 			return index
 		}
 		index++
@@ -156,28 +197,81 @@ func (ll *linkedList) indexOf(val interface{}) int {
 	return -1
 }
 
-func (ll *linkedList) Get(index int) (interface{}, error) {
+func (ll *linkedList) LastIndexOf(val interface{}) int {
+	return ll.LastIndexFunc(func(v interface{}) bool { return val == v })
+}
+
+// LastIndexFunc returns the index of the last element satisfying pred, or -1 if no element satisfies pred.
+func (ll *LinkedList[T]) LastIndexFunc(pred func(val T) bool) int {
+	index := ll.length - 1
+
+	for e := ll.tail; e != nil; e = e.prev {
+		if pred(e.value) {
+			return index
+		}
+		index--
+	}
+
+	return -1
+}
+
+func (ll *LinkedList[T]) Get(index int) (T, error) {
 	size := ll.Size()
 	if index < 0 || index >= size {
-		return nil, fmt.Errorf("index out of range, index:%d, len:%d", index, size)
+		var zero T
+		return zero, fmt.Errorf("index out of range, index:%d, len:%d", index, size)
 	}
 
 	return ll.getElement(index).value, nil
 }
 
-func (ll *linkedList) Remove(index int) (interface{}, error) {
+func (ll *LinkedList[T]) Set(index int, val T) (T, error) {
 	size := ll.Size()
 	if index < 0 || index >= size {
-		return nil, fmt.Errorf("index out of range, index:%d, len:%d", index, size)
+		var zero T
+		return zero, fmt.Errorf("index out of range, index:%d, len:%d", index, size)
+	}
+
+	e := ll.getElement(index)
+	old := e.value
+	e.value = val
+	return old, nil
+}
+
+func (ll *LinkedList[T]) Remove(index int) (T, error) {
+	size := ll.Size()
+	if index < 0 || index >= size {
+		var zero T
+		return zero, fmt.Errorf("index out of range, index:%d, len:%d", index, size)
 	}
 
 	return ll.unlink(ll.getElement(index)), nil
 }
 
+func (ll *LinkedList[T]) RemoveRange(fromIndex, toIndex int) error {
+	size := ll.Size()
+	if fromIndex < 0 || toIndex > size || fromIndex > toIndex {
+		return fmt.Errorf("range out of bounds, fromIndex:%d, toIndex:%d, len:%d", fromIndex, toIndex, size)
+	}
+	if fromIndex == toIndex {
+		return nil
+	}
+
+	e := ll.getElement(fromIndex)
+	for i := fromIndex; i < toIndex; i++ {
+		next := e.next
+		ll.unlink(e)
+		e = next
+	}
+
+	return nil
+}
+
 // unlink removes the specified element e in this list.
-func (ll *linkedList) unlink(e *element) interface{} {
+func (ll *LinkedList[T]) unlink(e *element[T]) T {
+	var zero T
 	if nil == e {
-		return nil
+		return zero
 	}
 
 	retValue := e.value
@@ -194,19 +288,25 @@ func (ll *linkedList) unlink(e *element) interface{} {
 		e.next.prev = e.prev
 	}
 
-	e.prev, e.next, e.value = nil, nil, nil
+	e.prev, e.next, e.value = nil, nil, zero
 	ll.length--
+	ll.modCount++
 
 	return retValue
 }
 
 func (ll *linkedList) RemoveByValue(val interface{}) bool {
+	return ll.RemoveFunc(func(v interface{}) bool { return val == v })
+}
+
+// RemoveFunc removes the first element satisfying pred, if any.
+func (ll *LinkedList[T]) RemoveFunc(pred func(val T) bool) bool {
 	if ll.Size() == 0 {
 		return false
 	}
 
 	for e := ll.head; e != nil; e = e.next {
-		if val == e.value {
+		if pred(e.value) {
 			ll.unlink(e)
 			return true
 		}
@@ -215,21 +315,48 @@ func (ll *linkedList) RemoveByValue(val interface{}) bool {
 	return false
 }
 
-func (ll *linkedList) Clear() {
+func (ll *LinkedList[T]) RemoveIf(pred func(val T) bool) int {
+	removed := 0
+	for e := ll.head; e != nil; {
+		next := e.next
+		if pred(e.value) {
+			ll.unlink(e)
+			removed++
+		}
+		e = next
+	}
+	return removed
+}
+
+func (ll *LinkedList[T]) ReplaceAll(fn func(val T) T) {
+	for e := ll.head; e != nil; e = e.next {
+		e.value = fn(e.value)
+	}
+}
+
+func (ll *LinkedList[T]) ForEach(fn func(val T)) {
+	for e := ll.head; e != nil; e = e.next {
+		fn(e.value)
+	}
+}
+
+func (ll *LinkedList[T]) Clear() {
+	var zero T
 	for e := ll.head; e != nil; {
 		next := e.next
-		e.prev, e.next, e.value = nil, nil, nil
+		e.prev, e.next, e.value = nil, nil, zero
 		e = next
 	}
 
 	ll.head, ll.tail, ll.length = nil, nil, 0
+	ll.modCount++
 }
 
-func (ll *linkedList) Sort() {
+func (ll *LinkedList[T]) Sort() {
 	ll.SortWithOptions(false, nil)
 }
 
-func (ll *linkedList) SortWithOptions(reverse bool, c utils.Comparator) {
+func (ll *LinkedList[T]) SortWithOptions(reverse bool, c utils.Comparator) {
 	if ll.Size() < 2 {
 		return
 	}
@@ -239,27 +366,64 @@ func (ll *linkedList) SortWithOptions(reverse bool, c utils.Comparator) {
 
 	// sort the data
 	if reverse {
-		utils.ReverseSort(vals, c)
+		utils.ReverseSortFunc(vals, utils.CompareFunc[T](c))
 	} else {
-		utils.Sort(vals, c)
+		utils.SortFunc(vals, utils.CompareFunc[T](c))
+	}
+
+	// put the sorted values back into the elements
+	ll.replace(0, vals)
+}
+
+// SortFunc sorts the elements into ascending sequence according to the provided comparison function.
+func (ll *LinkedList[T]) SortFunc(cmp func(v1, v2 T) int) {
+	if ll.Size() < 2 {
+		return
+	}
+
+	vals := ll.values()
+	utils.SortFunc(vals, cmp)
+	ll.replace(0, vals)
+}
+
+func (ll *LinkedList[T]) Swap(i, j int) error {
+	size := ll.Size()
+	if i < 0 || i >= size || j < 0 || j >= size {
+		return fmt.Errorf("index out of range, i:%d, j:%d, len:%d", i, j, size)
+	}
+
+	ei, ej := ll.getElement(i), ll.getElement(j)
+	ei.value, ej.value = ej.value, ei.value
+	return nil
+}
+
+func (ll *LinkedList[T]) Reverse() {
+	for h, t := ll.head, ll.tail; h != t && h.prev != t; h, t = h.next, t.prev {
+		h.value, t.value = t.value, h.value
 	}
+}
 
-	// clear the linked list
-	ll.Clear()
+func (ll *LinkedList[T]) SubList(fromIndex, toIndex int) (*SubListOf[T], error) {
+	return newSubList[T](ll, fromIndex, toIndex, ll.Size())
+}
 
-	// add the sorted values into the list again
-	ll.Add(vals...)
+func (ll *linkedList) SubList(fromIndex, toIndex int) (*SubList, error) {
+	sub, err := ll.LinkedList.SubList(fromIndex, toIndex)
+	if err != nil {
+		return nil, err
+	}
+	return &SubList{sub}, nil
 }
 
-func (ll *linkedList) values() []interface{} {
+func (ll *LinkedList[T]) values() []T {
 	if ll.Size() == 0 {
-		return []interface{}{}
+		return []T{}
 	}
 
-	values := make([]interface{}, ll.Size())
+	values := make([]T, ll.Size())
 
 	it, hasNext := ll.Iterator()
-	var v interface{}
+	var v T
 	index := 0
 	for hasNext {
 		v, hasNext = it()
@@ -270,32 +434,112 @@ func (ll *linkedList) values() []interface{} {
 	return values
 }
 
-func (ll *linkedList) Iterator() (func() (interface{}, bool), bool) {
+func (ll *LinkedList[T]) Iterator() (func() (T, bool), bool) {
 	e := ll.head
 
-	return func() (interface{}, bool) {
-		var element interface{}
+	return func() (T, bool) {
+		var element T
 		if e != nil {
 			element = e.value
 			e = e.next
-		} else {
-			element = nil
 		}
 		return element, e != nil
 	}, e != nil
 }
 
-func (ll *linkedList) ReverseIterator() (func() (interface{}, bool), bool) {
+func (ll *LinkedList[T]) ReverseIterator() (func() (T, bool), bool) {
 	e := ll.tail
 
-	return func() (interface{}, bool) {
-		var element interface{}
+	return func() (T, bool) {
+		var element T
 		if e != nil {
 			element = e.value
 			e = e.prev
-		} else {
-			element = nil
 		}
 		return element, e != nil
 	}, e != nil
 }
+
+// ListIterator returns a ListIteratorOf[T], which adds and removes the elements in O(1) time.
+func (ll *LinkedList[T]) ListIterator(index int) (ListIteratorOf[T], error) {
+	size := ll.Size()
+	if index < 0 || index > size {
+		return nil, fmt.Errorf("index out of range, index:%d, len:%d", index, size)
+	}
+
+	it := &linkedListIterator[T]{
+		ll:        ll,
+		nextIndex: index,
+		modCount:  ll.modCount,
+	}
+	if index < size {
+		it.next = ll.getElement(index)
+	}
+	return it, nil
+}
+
+func (ll *LinkedList[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		index := 0
+		for e := ll.head; e != nil; e = e.next {
+			if !yield(index, e.value) {
+				return
+			}
+			index++
+		}
+	}
+}
+
+func (ll *LinkedList[T]) Backward() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		index := ll.length - 1
+		for e := ll.tail; e != nil; e = e.prev {
+			if !yield(index, e.value) {
+				return
+			}
+			index--
+		}
+	}
+}
+
+// span returns an iterator over the index-value pairs in the range [fromIndex, toIndex).
+func (ll *LinkedList[T]) span(fromIndex, toIndex int, backward bool) iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		if fromIndex >= toIndex {
+			return
+		}
+		if backward {
+			e := ll.getElement(toIndex - 1)
+			for i := toIndex - 1; i >= fromIndex; i-- {
+				if !yield(i, e.value) {
+					return
+				}
+				e = e.prev
+			}
+			return
+		}
+		e := ll.getElement(fromIndex)
+		for i := fromIndex; i < toIndex; i++ {
+			if !yield(i, e.value) {
+				return
+			}
+			e = e.next
+		}
+	}
+}
+
+// replace replaces the elements starting at the specified position with vals.
+func (ll *LinkedList[T]) replace(index int, vals []T) {
+	if len(vals) == 0 {
+		return
+	}
+	e := ll.getElement(index)
+	for _, v := range vals {
+		e.value = v
+		e = e.next
+	}
+}
+
+func (ll *LinkedList[T]) modifications() int {
+	return ll.modCount
+}
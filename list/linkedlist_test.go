@@ -332,3 +332,55 @@ func (aln *linkedListNode) Compare(v1, v2 interface{}) (int, error) {
 
 	return 1, nil
 }
+
+func TestLinkedListOf(t *testing.T) {
+	ll := list.NewLinkedListOf[int]()
+	ll.Add(5, 9, 7)
+
+	if err := ll.AddTo(1, 6); err != nil {
+		t.Errorf("Failed to add element at specific index, error: %v\n", err)
+	}
+	if !list.RemoveByValue(ll, 9) {
+		t.Error("Failed to remove the value 9")
+	}
+	if !list.Contains(ll, 7) || list.Contains(ll, 9) {
+		t.Error("Unexpected result of Contains")
+	}
+	if !ll.ContainsFunc(func(v int) bool { return v > 6 }) || ll.RemoveFunc(func(v int) bool { return v > 7 }) {
+		t.Error("Unexpected result of ContainsFunc or RemoveFunc")
+	}
+
+	ll.Sort()
+	expected := []int{5, 6, 7}
+	it, hasNext := ll.Iterator()
+	var v int
+	for i := 0; hasNext; i++ {
+		v, hasNext = it()
+		if v != expected[i] {
+			t.Errorf("The value isn't expected, index: %d, expect: %d, actual: %d\n", i, expected[i], v)
+		}
+	}
+}
+
+func TestLinkedListAll(t *testing.T) {
+	ll := list.NewLinkedListOf[string]()
+	ll.Add("benjamin", "alice", "john")
+
+	expected := []string{"benjamin", "alice", "john"}
+	for i, v := range ll.All() {
+		if v != expected[i] {
+			t.Errorf("The value isn't expected, index: %d, expect: %s, actual: %s\n", i, expected[i], v)
+		}
+	}
+
+	count := 0
+	for i, v := range ll.Backward() {
+		if v != expected[i] {
+			t.Errorf("The value isn't expected, index: %d, expect: %s, actual: %s\n", i, expected[i], v)
+		}
+		count++
+	}
+	if count != 3 {
+		t.Errorf("The count isn't expected, expect: 3, actual: %d\n", count)
+	}
+}
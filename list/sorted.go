@@ -0,0 +1,237 @@
+// Copyright (c) 2019, Benjamin Wang (benjamin_wang@aliyun.com). All rights reserved.
+// Licensed under the MIT license that can be found in the LICENSE file.
+
+package list
+
+import (
+	"iter"
+	"slices"
+	"sort"
+
+	"github.com/ahrtr/gocontainer/collection"
+	"github.com/ahrtr/gocontainer/utils"
+)
+
+// SortedInterface is a type of list, which keeps its elements in ascending order. SortedList implements this interface.
+// The elements equal to each other are kept in the order they were added. The elements are located by binary search,
+// and they are compared by the comparator of the list, instead of the == operator.
+type SortedInterface interface {
+	collection.Interface
+	ReadOnlyInterface
+
+	// Add inserts the specified elements into this list, each after the elements equal to it.
+	Add(vals ...interface{})
+
+	// Floor returns the greatest element less than or equal to the specified element and true,
+	// or (nil, false) if there is no such element.
+	Floor(val interface{}) (interface{}, bool)
+	// Ceiling returns the least element greater than or equal to the specified element and true,
+	// or (nil, false) if there is no such element.
+	Ceiling(val interface{}) (interface{}, bool)
+
+	// Remove removes the element at the specified position in this list.
+	// It returns an error if the index is out of range.
+	Remove(index int) (interface{}, error)
+	// RemoveByValue removes the first occurrence of the specified element from this list, if it is present.
+	// It returns false if the target value isn't present, otherwise returns true.
+	RemoveByValue(val interface{}) bool
+	// RemoveRange removes all the elements within the range [greaterOrEqual, lessThan) from this list, and returns
+	// the number of elements removed. A nil bound means the range is unbounded on that side.
+	RemoveRange(greaterOrEqual, lessThan interface{}) int
+	// RemoveIf removes all the elements satisfying the predicate from this list in a single pass,
+	// and returns the number of elements removed.
+	RemoveIf(pred func(val interface{}) bool) int
+}
+
+// SortedList represents a sorted list, whose elements are of type T, which are kept in ascending order.
+type SortedList[T any] struct {
+	al  *ArrayList[T]
+	cmp func(v1, v2 T) int
+}
+
+// sortedList implements the SortedInterface.
+type sortedList struct {
+	*SortedList[interface{}]
+}
+
+// NewSortedList initializes and returns a sorted list, whose elements are sorted according to their natural
+// ordering, or according to the provided comparator.
+func NewSortedList(c utils.Comparator) SortedInterface {
+	return &sortedList{NewSortedListOf(utils.CompareFunc[interface{}](c))}
+}
+
+// NewSortedListOf initializes and returns a SortedList, whose elements are of type T. The elements are sorted
+// by cmp, which returns a negative integer, zero, or a positive integer as the first argument is less than, equal
+// to, or greater than the second. If cmp is nil, then the elements are sorted according to their natural ordering.
+func NewSortedListOf[T any](cmp func(v1, v2 T) int) *SortedList[T] {
+	if cmp == nil {
+		cmp = utils.CompareFunc[T](nil)
+	}
+	return &SortedList[T]{
+		al:  NewArrayListOf[T](),
+		cmp: cmp,
+	}
+}
+
+func (sl *SortedList[T]) Size() int {
+	return sl.al.Size()
+}
+
+func (sl *SortedList[T]) IsEmpty() bool {
+	return sl.al.IsEmpty()
+}
+
+func (sl *SortedList[T]) Clear() {
+	sl.al.Clear()
+}
+
+// lowerBound returns the index of the first element which isn't less than val.
+func (sl *SortedList[T]) lowerBound(val T) int {
+	items := sl.al.items
+	return sort.Search(len(items), func(i int) bool { return sl.cmp(items[i], val) >= 0 })
+}
+
+// upperBound returns the index of the first element which is greater than val.
+func (sl *SortedList[T]) upperBound(val T) int {
+	items := sl.al.items
+	return sort.Search(len(items), func(i int) bool { return sl.cmp(items[i], val) > 0 })
+}
+
+// Add inserts the specified elements into the list, each after the elements equal to it. A single element
+// is inserted in O(log n + n) time, and a batch of k elements is sorted and merged in O(k log k + n) time.
+func (sl *SortedList[T]) Add(vals ...T) {
+	switch len(vals) {
+	case 0:
+		return
+	case 1:
+		sl.al.AddTo(sl.upperBound(vals[0]), vals[0])
+		return
+	}
+
+	batch := slices.Clone(vals)
+	slices.SortStableFunc(batch, sl.cmp)
+	items := sl.al.items
+	merged := make([]T, 0, len(items)+len(batch))
+	i, j := 0, 0
+	for i < len(items) && j < len(batch) {
+		// The existing elements go first, so that the equal elements are kept in the order they were added.
+		if sl.cmp(batch[j], items[i]) < 0 {
+			merged = append(merged, batch[j])
+			j++
+		} else {
+			merged = append(merged, items[i])
+			i++
+		}
+	}
+	merged = append(merged, items[i:]...)
+	merged = append(merged, batch[j:]...)
+	sl.al.items = merged
+	sl.al.modCount++
+}
+
+func (sl *SortedList[T]) Contains(val T) bool {
+	return sl.IndexOf(val) >= 0
+}
+
+func (sl *SortedList[T]) Get(index int) (T, error) {
+	return sl.al.Get(index)
+}
+
+// IndexOf returns the index of the first element equal to the specified element, or -1 if it isn't present.
+// It takes O(log n) time.
+func (sl *SortedList[T]) IndexOf(val T) int {
+	i := sl.lowerBound(val)
+	if i < sl.al.Size() && sl.cmp(sl.al.items[i], val) == 0 {
+		return i
+	}
+	return -1
+}
+
+// LastIndexOf returns the index of the last element equal to the specified element, or -1 if it isn't present.
+// It takes O(log n) time.
+func (sl *SortedList[T]) LastIndexOf(val T) int {
+	i := sl.upperBound(val) - 1
+	if i >= 0 && sl.cmp(sl.al.items[i], val) == 0 {
+		return i
+	}
+	return -1
+}
+
+// Floor returns the greatest element less than or equal to the specified element and true, or the zero value
+// and false if there is no such element. If there are multiple such elements, the last one is returned.
+func (sl *SortedList[T]) Floor(val T) (T, bool) {
+	if i := sl.upperBound(val) - 1; i >= 0 {
+		return sl.al.items[i], true
+	}
+	var zero T
+	return zero, false
+}
+
+// Ceiling returns the least element greater than or equal to the specified element and true, or the zero value
+// and false if there is no such element. If there are multiple such elements, the first one is returned.
+func (sl *SortedList[T]) Ceiling(val T) (T, bool) {
+	if i := sl.lowerBound(val); i < sl.al.Size() {
+		return sl.al.items[i], true
+	}
+	var zero T
+	return zero, false
+}
+
+func (sl *SortedList[T]) Remove(index int) (T, error) {
+	return sl.al.Remove(index)
+}
+
+func (sl *SortedList[T]) RemoveByValue(val T) bool {
+	i := sl.IndexOf(val)
+	if i < 0 {
+		return false
+	}
+	sl.al.Remove(i)
+	return true
+}
+
+// RemoveRange removes all the elements within the range [greaterOrEqual, lessThan), and returns the number of
+// elements removed.
+func (sl *SortedList[T]) RemoveRange(greaterOrEqual, lessThan T) int {
+	return sl.removeRange(sl.lowerBound(greaterOrEqual), sl.lowerBound(lessThan))
+}
+
+// removeRange removes the elements whose index is in the range [fromIndex, toIndex).
+func (sl *SortedList[T]) removeRange(fromIndex, toIndex int) int {
+	if fromIndex >= toIndex {
+		return 0
+	}
+	sl.al.RemoveRange(fromIndex, toIndex)
+	return toIndex - fromIndex
+}
+
+func (sl *SortedList[T]) RemoveIf(pred func(val T) bool) int {
+	return sl.al.RemoveIf(pred)
+}
+
+func (sl *SortedList[T]) Iterator() (func() (T, bool), bool) {
+	return sl.al.Iterator()
+}
+
+func (sl *SortedList[T]) ReverseIterator() (func() (T, bool), bool) {
+	return sl.al.ReverseIterator()
+}
+
+func (sl *SortedList[T]) All() iter.Seq2[int, T] {
+	return sl.al.All()
+}
+
+func (sl *SortedList[T]) Backward() iter.Seq2[int, T] {
+	return sl.al.Backward()
+}
+
+func (sl *sortedList) RemoveRange(greaterOrEqual, lessThan interface{}) int {
+	fromIndex, toIndex := 0, sl.Size()
+	if greaterOrEqual != nil {
+		fromIndex = sl.lowerBound(greaterOrEqual)
+	}
+	if lessThan != nil {
+		toIndex = sl.lowerBound(lessThan)
+	}
+	return sl.removeRange(fromIndex, toIndex)
+}
@@ -0,0 +1,140 @@
+// Copyright (c) 2019, Benjamin Wang (benjamin_wang@aliyun.com). All rights reserved.
+// Licensed under the MIT license that can be found in the LICENSE file.
+
+package list_test
+
+import (
+	"math/rand"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/ahrtr/gocontainer/list"
+)
+
+// The sorted lists satisfy the read side of the lists.
+var _ list.ReadOnlyInterface = list.NewSortedList(nil)
+
+// descendingInt orders the integers in descending order.
+type descendingInt struct{}
+
+func (descendingInt) Compare(v1, v2 interface{}) (int, error) {
+	return v2.(int) - v1.(int), nil
+}
+
+func sortedValues(l list.ReadOnlyInterface) []interface{} {
+	var vals []interface{}
+	for _, v := range l.All() {
+		vals = append(vals, v)
+	}
+	return vals
+}
+
+func TestSortedList(t *testing.T) {
+	sl := list.NewSortedList(nil)
+	sl.Add(5)
+	sl.Add(3, 9, 1, 7, 3)
+	sl.Add(6)
+	if got, want := sortedValues(sl), []interface{}{1, 3, 3, 5, 6, 7, 9}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected values, expect: %v, actual: %v", want, got)
+	}
+
+	if i, j := sl.IndexOf(3), sl.LastIndexOf(3); i != 1 || j != 2 {
+		t.Fatalf("IndexOf(3) and LastIndexOf(3), expect: 1, 2, actual: %d, %d", i, j)
+	}
+	if i, j := sl.IndexOf(4), sl.LastIndexOf(10); i != -1 || j != -1 {
+		t.Fatalf("expect -1 for the absent values, actual: %d, %d", i, j)
+	}
+	if !sl.Contains(9) || sl.Contains(0) {
+		t.Fatal("unexpected result of Contains")
+	}
+	if v, err := sl.Get(3); err != nil || v != 5 {
+		t.Fatalf("Get(3), expect: 5, actual: %v, %v", v, err)
+	}
+
+	for _, c := range []struct {
+		val            int
+		floor, ceiling interface{}
+	}{{0, nil, 1}, {1, 1, 1}, {4, 3, 5}, {8, 7, 9}, {10, 9, nil}} {
+		if v, ok := sl.Floor(c.val); v != c.floor || ok != (c.floor != nil) {
+			t.Fatalf("Floor(%d), expect: %v, actual: %v", c.val, c.floor, v)
+		}
+		if v, ok := sl.Ceiling(c.val); v != c.ceiling || ok != (c.ceiling != nil) {
+			t.Fatalf("Ceiling(%d), expect: %v, actual: %v", c.val, c.ceiling, v)
+		}
+	}
+
+	if removed := sl.RemoveRange(3, 7); removed != 4 {
+		t.Fatalf("RemoveRange(3, 7), expect: 4, actual: %d", removed)
+	}
+	if removed := sl.RemoveRange(8, nil); removed != 1 {
+		t.Fatalf("RemoveRange(8, nil), expect: 1, actual: %d", removed)
+	}
+	if removed := sl.RemoveRange(5, 2); removed != 0 {
+		t.Fatalf("RemoveRange on an empty range, expect: 0, actual: %d", removed)
+	}
+	if got, want := sortedValues(sl), []interface{}{1, 7}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected values, expect: %v, actual: %v", want, got)
+	}
+
+	if !sl.RemoveByValue(7) || sl.RemoveByValue(7) {
+		t.Fatal("unexpected result of RemoveByValue")
+	}
+	if v, err := sl.Remove(0); err != nil || v != 1 || !sl.IsEmpty() {
+		t.Fatalf("Remove(0), expect: 1, actual: %v, %v", v, err)
+	}
+
+	sl = list.NewSortedList(descendingInt{})
+	sl.Add(1, 3, 2)
+	if got, want := sortedValues(sl), []interface{}{3, 2, 1}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected values, expect: %v, actual: %v", want, got)
+	}
+	if removed := sl.RemoveRange(nil, nil); removed != 3 {
+		t.Fatalf("RemoveRange(nil, nil), expect: 3, actual: %d", removed)
+	}
+}
+
+func TestSortedListStable(t *testing.T) {
+	type kv struct{ k, v int }
+	sl := list.NewSortedListOf(func(a, b kv) int { return a.k - b.k })
+
+	// The equal elements are kept in the order they were added, either one by one or in a batch.
+	var want []kv
+	for i := 0; i < 100; i++ {
+		item := kv{rand.Intn(10), i}
+		want = append(want, item)
+		if i%2 == 0 {
+			sl.Add(item)
+		}
+	}
+	var batch []kv
+	for i := 1; i < 100; i += 2 {
+		batch = append(batch, want[i])
+	}
+	sl.Add(batch...)
+
+	// The elements added one by one precede the equal elements added in the batch.
+	slices.SortStableFunc(want, func(a, b kv) int {
+		if c := a.k - b.k; c != 0 {
+			return c
+		}
+		return a.v%2 - b.v%2
+	})
+	var got []kv
+	for _, v := range sl.All() {
+		got = append(got, v)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected values, expect: %v, actual: %v", want, got)
+	}
+
+	if i := sl.IndexOf(kv{k: want[50].k}); sl.LastIndexOf(kv{k: want[50].k}) < i || i < 0 {
+		t.Fatalf("unexpected IndexOf: %d", i)
+	}
+	if removed := sl.RemoveIf(func(item kv) bool { return item.k < 5 }); removed+sl.Size() != 100 {
+		t.Fatalf("unexpected result of RemoveIf: %d", removed)
+	}
+	if v, ok := sl.Ceiling(kv{}); ok && v.k < 5 {
+		t.Fatalf("unexpected Ceiling: %v", v)
+	}
+}
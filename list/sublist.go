@@ -0,0 +1,451 @@
+// Copyright (c) 2019, Benjamin Wang (benjamin_wang@aliyun.com). All rights reserved.
+// Licensed under the MIT license that can be found in the LICENSE file.
+
+package list
+
+import (
+	"errors"
+	"fmt"
+	"iter"
+	"slices"
+
+	"github.com/ahrtr/gocontainer/utils"
+)
+
+// ErrConcurrentModification is returned, or used as the panic value by the methods which don't return
+// an error, when a sublist is used after its parent list has been structurally modified other than
+// through the sublist.
+var ErrConcurrentModification = errors.New("list: concurrent modification")
+
+// ErrReadOnly is returned, or used as the panic value by the methods which don't return an error, when
+// a read-only sublist, or a ListIterator over it, is used to modify the list.
+var ErrReadOnly = errors.New("list: read-only view")
+
+// backing is implemented by the lists which can back a sublist.
+type backing[T any] interface {
+	Get(index int) (T, error)
+	Set(index int, val T) (T, error)
+	AddAllAt(index int, vals ...T) error
+	RemoveRange(fromIndex, toIndex int) error
+
+	// span returns an iterator over the index-value pairs in the range [fromIndex, toIndex).
+	span(fromIndex, toIndex int, backward bool) iter.Seq2[int, T]
+	// replace replaces the elements starting at the specified position with vals.
+	replace(index int, vals []T)
+	// modifications returns the number of times the list has been structurally modified.
+	modifications() int
+}
+
+// SubListOf is a view of the portion of a list between the specified fromIndex, inclusive, and toIndex,
+// exclusive, whose elements are of type T. It's returned by the SubList methods of the lists.
+//
+// The sublist is backed by the parent list, so the changes in the sublist are reflected in the parent
+// list, and the non-structural changes (e.g. Set, Swap or Sort) in the parent list are reflected in the
+// sublist. If the parent list is structurally modified (i.e. its size is changed) other than through the
+// sublist, the sublist becomes invalid, and any further use of it fails with ErrConcurrentModification.
+type SubListOf[T any] struct {
+	parent backing[T]
+	offset int
+	size   int
+	// modCount is the expected number of structural modifications of the parent list.
+	modCount int
+	// mods is the number of times the sublist has been structurally modified, which is used by
+	// the nested sublists.
+	mods int
+	// readOnly is true if the sublist can't be used to modify the parent list.
+	readOnly bool
+}
+
+// SubList is a view of a portion of a list created by NewArrayList or NewLinkedList.
+// SubList implements the interface list.Interface.
+type SubList struct {
+	*SubListOf[interface{}]
+}
+
+func newSubList[T any](parent backing[T], fromIndex, toIndex, size int) (*SubListOf[T], error) {
+	if fromIndex < 0 || toIndex > size || fromIndex > toIndex {
+		return nil, fmt.Errorf("range out of bounds, fromIndex:%d, toIndex:%d, len:%d", fromIndex, toIndex, size)
+	}
+
+	return &SubListOf[T]{
+		parent:   parent,
+		offset:   fromIndex,
+		size:     toIndex - fromIndex,
+		modCount: parent.modifications(),
+	}, nil
+}
+
+// check returns ErrConcurrentModification if the parent list has been structurally modified
+// other than through the sublist.
+func (s *SubListOf[T]) check() error {
+	if s.parent.modifications() != s.modCount {
+		return ErrConcurrentModification
+	}
+	return nil
+}
+
+// mustCheck is the same as check, but panics instead.
+func (s *SubListOf[T]) mustCheck() {
+	if err := s.check(); err != nil {
+		panic(err)
+	}
+}
+
+// checkWritable returns ErrReadOnly if the sublist is read-only, otherwise it's the same as check.
+func (s *SubListOf[T]) checkWritable() error {
+	if s.readOnly {
+		return ErrReadOnly
+	}
+	return s.check()
+}
+
+// mustCheckWritable is the same as checkWritable, but panics instead.
+func (s *SubListOf[T]) mustCheckWritable() {
+	if err := s.checkWritable(); err != nil {
+		panic(err)
+	}
+}
+
+// ReadOnly makes the sublist read-only, so that the methods modifying the list fail with ErrReadOnly, and
+// returns the sublist. The nested sublists and the ListIterators of a read-only sublist are read-only too.
+// It's useful when the parent list is a snapshot, whose modifications would be silently lost.
+func (s *SubListOf[T]) ReadOnly() *SubListOf[T] {
+	s.readOnly = true
+	return s
+}
+
+func (s *SubList) ReadOnly() *SubList {
+	s.SubListOf.ReadOnly()
+	return s
+}
+
+// modified updates the bookkeeping after the sublist is structurally modified through the parent list.
+func (s *SubListOf[T]) modified(delta int) {
+	s.modCount = s.parent.modifications()
+	s.size += delta
+	s.mods++
+}
+
+func (s *SubListOf[T]) Size() int {
+	s.mustCheck()
+	return s.size
+}
+
+func (s *SubListOf[T]) IsEmpty() bool {
+	return s.Size() == 0
+}
+
+func (s *SubListOf[T]) Add(vals ...T) {
+	if err := s.AddAllAt(s.Size(), vals...); err != nil {
+		panic(err)
+	}
+}
+
+func (s *SubListOf[T]) AddTo(index int, val T) error {
+	return s.AddAllAt(index, val)
+}
+
+func (s *SubListOf[T]) AddAllAt(index int, vals ...T) error {
+	if err := s.checkWritable(); err != nil {
+		return err
+	}
+	if index < 0 || index > s.size {
+		return fmt.Errorf("index out of range, index:%d, len:%d", index, s.size)
+	}
+
+	if err := s.parent.AddAllAt(s.offset+index, vals...); err != nil {
+		return err
+	}
+	s.modified(len(vals))
+	return nil
+}
+
+func (s *SubList) Contains(val interface{}) bool {
+	return s.IndexOf(val) >= 0
+}
+
+// ContainsFunc returns true if the sublist contains an element satisfying pred.
+func (s *SubListOf[T]) ContainsFunc(pred func(val T) bool) bool {
+	return s.IndexFunc(pred) >= 0
+}
+
+func (s *SubList) IndexOf(val interface{}) int {
+	return s.IndexFunc(func(v interface{}) bool { return v == val })
+}
+
+// IndexFunc returns the index of the first element satisfying pred, or -1 if no element satisfies pred.
+func (s *SubListOf[T]) IndexFunc(pred func(val T) bool) int {
+	for i, v := range s.All() {
+		if pred(v) {
+			return i
+		}
+	}
+	return -1
+}
+
+func (s *SubList) LastIndexOf(val interface{}) int {
+	return s.LastIndexFunc(func(v interface{}) bool { return v == val })
+}
+
+// LastIndexFunc returns the index of the last element satisfying pred, or -1 if no element satisfies pred.
+func (s *SubListOf[T]) LastIndexFunc(pred func(val T) bool) int {
+	for i, v := range s.Backward() {
+		if pred(v) {
+			return i
+		}
+	}
+	return -1
+}
+
+func (s *SubListOf[T]) Get(index int) (T, error) {
+	var zero T
+	if err := s.check(); err != nil {
+		return zero, err
+	}
+	if index < 0 || index >= s.size {
+		return zero, fmt.Errorf("index out of range, index:%d, len:%d", index, s.size)
+	}
+
+	return s.parent.Get(s.offset + index)
+}
+
+func (s *SubListOf[T]) Set(index int, val T) (T, error) {
+	var zero T
+	if err := s.checkWritable(); err != nil {
+		return zero, err
+	}
+	if index < 0 || index >= s.size {
+		return zero, fmt.Errorf("index out of range, index:%d, len:%d", index, s.size)
+	}
+
+	return s.parent.Set(s.offset+index, val)
+}
+
+func (s *SubListOf[T]) Remove(index int) (T, error) {
+	if err := s.checkWritable(); err != nil {
+		var zero T
+		return zero, err
+	}
+	val, err := s.Get(index)
+	if err != nil {
+		return val, err
+	}
+
+	if err := s.parent.RemoveRange(s.offset+index, s.offset+index+1); err != nil {
+		return val, err
+	}
+	s.modified(-1)
+	return val, nil
+}
+
+func (s *SubListOf[T]) RemoveRange(fromIndex, toIndex int) error {
+	if err := s.checkWritable(); err != nil {
+		return err
+	}
+	if fromIndex < 0 || toIndex > s.size || fromIndex > toIndex {
+		return fmt.Errorf("range out of bounds, fromIndex:%d, toIndex:%d, len:%d", fromIndex, toIndex, s.size)
+	}
+
+	if err := s.parent.RemoveRange(s.offset+fromIndex, s.offset+toIndex); err != nil {
+		return err
+	}
+	s.modified(fromIndex - toIndex)
+	return nil
+}
+
+func (s *SubList) RemoveByValue(val interface{}) bool {
+	return s.RemoveFunc(func(v interface{}) bool { return v == val })
+}
+
+// RemoveFunc removes the first element satisfying pred from the sublist, if any.
+func (s *SubListOf[T]) RemoveFunc(pred func(val T) bool) bool {
+	s.mustCheckWritable()
+	index := s.IndexFunc(pred)
+	if index < 0 {
+		return false
+	}
+
+	_, err := s.Remove(index)
+	return err == nil
+}
+
+// RemoveIf removes the elements satisfying pred from the parent list. The kept elements are moved to the
+// beginning of the sublist, and the rest of the sublist is removed at once.
+func (s *SubListOf[T]) RemoveIf(pred func(val T) bool) int {
+	s.mustCheckWritable()
+	vals := s.values()
+	kept := vals[:0]
+	for _, v := range vals {
+		if !pred(v) {
+			kept = append(kept, v)
+		}
+	}
+
+	removed := s.size - len(kept)
+	if removed == 0 {
+		return 0
+	}
+	s.parent.replace(s.offset, kept)
+	if err := s.RemoveRange(len(kept), s.size); err != nil {
+		panic(err)
+	}
+	return removed
+}
+
+func (s *SubListOf[T]) ReplaceAll(fn func(val T) T) {
+	s.mustCheckWritable()
+	vals := s.values()
+	for i, v := range vals {
+		vals[i] = fn(v)
+	}
+	s.parent.replace(s.offset, vals)
+}
+
+func (s *SubListOf[T]) ForEach(fn func(val T)) {
+	for _, v := range s.All() {
+		fn(v)
+	}
+}
+
+// Clear removes all of the elements in the sublist from the parent list.
+func (s *SubListOf[T]) Clear() {
+	if err := s.RemoveRange(0, s.Size()); err != nil {
+		panic(err)
+	}
+}
+
+func (s *SubListOf[T]) Sort() {
+	s.SortWithOptions(false, nil)
+}
+
+func (s *SubListOf[T]) SortWithOptions(reverse bool, c utils.Comparator) {
+	s.mustCheckWritable()
+	vals := s.values()
+	if reverse {
+		utils.ReverseSortFunc(vals, utils.CompareFunc[T](c))
+	} else {
+		utils.SortFunc(vals, utils.CompareFunc[T](c))
+	}
+	s.parent.replace(s.offset, vals)
+}
+
+// SortFunc sorts the elements into ascending sequence according to the provided comparison function.
+func (s *SubListOf[T]) SortFunc(cmp func(v1, v2 T) int) {
+	s.mustCheckWritable()
+	vals := s.values()
+	utils.SortFunc(vals, cmp)
+	s.parent.replace(s.offset, vals)
+}
+
+func (s *SubListOf[T]) Swap(i, j int) error {
+	if err := s.checkWritable(); err != nil {
+		return err
+	}
+	if i < 0 || i >= s.size || j < 0 || j >= s.size {
+		return fmt.Errorf("index out of range, i:%d, j:%d, len:%d", i, j, s.size)
+	}
+
+	vi, _ := s.parent.Get(s.offset + i)
+	vj, _ := s.parent.Set(s.offset+j, vi)
+	s.parent.Set(s.offset+i, vj)
+	return nil
+}
+
+func (s *SubListOf[T]) Reverse() {
+	s.mustCheckWritable()
+	vals := s.values()
+	slices.Reverse(vals)
+	s.parent.replace(s.offset, vals)
+}
+
+// SubList returns a view of the portion of this sublist, which is backed by this sublist.
+func (s *SubListOf[T]) SubList(fromIndex, toIndex int) (*SubListOf[T], error) {
+	if err := s.check(); err != nil {
+		return nil, err
+	}
+	sub, err := newSubList[T](s, fromIndex, toIndex, s.size)
+	if err != nil {
+		return nil, err
+	}
+	sub.readOnly = s.readOnly
+	return sub, nil
+}
+
+func (s *SubList) SubList(fromIndex, toIndex int) (*SubList, error) {
+	sub, err := s.SubListOf.SubList(fromIndex, toIndex)
+	if err != nil {
+		return nil, err
+	}
+	return &SubList{sub}, nil
+}
+
+// Iterator returns an iterator over a snapshot of the elements in the sublist.
+func (s *SubListOf[T]) Iterator() (func() (T, bool), bool) {
+	al := &ArrayList[T]{items: s.values()}
+	return al.Iterator()
+}
+
+// ReverseIterator returns an iterator over a snapshot of the elements in the sublist in reverse sequence.
+func (s *SubListOf[T]) ReverseIterator() (func() (T, bool), bool) {
+	al := &ArrayList[T]{items: s.values()}
+	return al.ReverseIterator()
+}
+
+func (s *SubListOf[T]) ListIterator(index int) (ListIteratorOf[T], error) {
+	if err := s.check(); err != nil {
+		return nil, err
+	}
+	return newIndexedIterator[T](s, index, s.size)
+}
+
+func (s *SubListOf[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		s.mustCheck()
+		for i, v := range s.parent.span(s.offset, s.offset+s.size, false) {
+			if !yield(i-s.offset, v) {
+				return
+			}
+		}
+	}
+}
+
+func (s *SubListOf[T]) Backward() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		s.mustCheck()
+		for i, v := range s.parent.span(s.offset, s.offset+s.size, true) {
+			if !yield(i-s.offset, v) {
+				return
+			}
+		}
+	}
+}
+
+func (s *SubListOf[T]) values() []T {
+	vals := make([]T, 0, s.Size())
+	for _, v := range s.All() {
+		vals = append(vals, v)
+	}
+	return vals
+}
+
+func (s *SubListOf[T]) span(fromIndex, toIndex int, backward bool) iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i, v := range s.parent.span(s.offset+fromIndex, s.offset+toIndex, backward) {
+			if !yield(i-s.offset, v) {
+				return
+			}
+		}
+	}
+}
+
+func (s *SubListOf[T]) replace(index int, vals []T) {
+	s.parent.replace(s.offset+index, vals)
+}
+
+// modifications returns -1 once the sublist becomes invalid, so that its nested sublists become invalid too.
+func (s *SubListOf[T]) modifications() int {
+	if s.check() != nil {
+		return -1
+	}
+	return s.mods
+}
@@ -0,0 +1,311 @@
+// Copyright (c) 2019, Benjamin Wang (benjamin_wang@aliyun.com). All rights reserved.
+// Licensed under the MIT license that can be found in the LICENSE file.
+
+package list_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/ahrtr/gocontainer/list"
+)
+
+var newLists = map[string]func() list.Interface{
+	"arrayList":  list.NewArrayList,
+	"linkedList": list.NewLinkedList,
+}
+
+func values(l list.Interface) []interface{} {
+	var vals []interface{}
+	for _, v := range l.All() {
+		vals = append(vals, v)
+	}
+	return vals
+}
+
+func checkValues(t *testing.T, l list.Interface, want ...interface{}) {
+	t.Helper()
+	if got := values(l); !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected values, expect: %v, actual: %v", want, got)
+	}
+	if l.Size() != len(want) {
+		t.Fatalf("unexpected size, expect: %d, actual: %d", len(want), l.Size())
+	}
+}
+
+func TestListRandomAccess(t *testing.T) {
+	for name, newList := range newLists {
+		t.Run(name, func(t *testing.T) {
+			l := newList()
+			l.Add(1, 2, 3, 2, 1)
+
+			if old, err := l.Set(1, 5); err != nil || old != 2 {
+				t.Fatalf("Set returned %v, %v", old, err)
+			}
+			if _, err := l.Set(5, 0); err == nil {
+				t.Fatal("Set should fail with an index out of range")
+			}
+			checkValues(t, l, 1, 5, 3, 2, 1)
+
+			if i := l.IndexOf(1); i != 0 {
+				t.Fatalf("IndexOf(1), expect: 0, actual: %d", i)
+			}
+			if i := l.LastIndexOf(1); i != 4 {
+				t.Fatalf("LastIndexOf(1), expect: 4, actual: %d", i)
+			}
+			if i, j := l.IndexOf(9), l.LastIndexOf(9); i != -1 || j != -1 {
+				t.Fatalf("expect -1 for the absent value, actual: %d, %d", i, j)
+			}
+
+			if err := l.AddAllAt(2, 7, 8); err != nil {
+				t.Fatal(err)
+			}
+			checkValues(t, l, 1, 5, 7, 8, 3, 2, 1)
+			if err := l.AddAllAt(7, 9); err != nil {
+				t.Fatal(err)
+			}
+			if err := l.AddAllAt(9, 9); err == nil {
+				t.Fatal("AddAllAt should fail with an index out of range")
+			}
+			checkValues(t, l, 1, 5, 7, 8, 3, 2, 1, 9)
+
+			if err := l.RemoveRange(1, 4); err != nil {
+				t.Fatal(err)
+			}
+			checkValues(t, l, 1, 3, 2, 1, 9)
+			for _, r := range [][2]int{{-1, 2}, {3, 2}, {0, 6}} {
+				if err := l.RemoveRange(r[0], r[1]); err == nil {
+					t.Fatalf("RemoveRange(%d, %d) should fail", r[0], r[1])
+				}
+			}
+
+			if err := l.Swap(0, 4); err != nil {
+				t.Fatal(err)
+			}
+			if err := l.Swap(0, 5); err == nil {
+				t.Fatal("Swap should fail with an index out of range")
+			}
+			checkValues(t, l, 9, 3, 2, 1, 1)
+
+			l.Reverse()
+			checkValues(t, l, 1, 1, 2, 3, 9)
+			l.RemoveRange(0, 1)
+			l.Reverse()
+			checkValues(t, l, 9, 3, 2, 1)
+			l.Clear()
+			l.Reverse()
+			checkValues(t, l)
+		})
+	}
+}
+
+func TestSubList(t *testing.T) {
+	for name, newList := range newLists {
+		t.Run(name, func(t *testing.T) {
+			l := newList()
+			l.Add(0, 1, 2, 3, 4, 5, 6, 7, 8, 9)
+
+			sub, err := l.SubList(2, 7)
+			if err != nil {
+				t.Fatal(err)
+			}
+			checkValues(t, sub, 2, 3, 4, 5, 6)
+			if v, _ := sub.Get(0); v != 2 {
+				t.Fatalf("Get(0), expect: 2, actual: %v", v)
+			}
+			if _, err := sub.Get(5); err == nil {
+				t.Fatal("Get should fail with an index out of range")
+			}
+
+			// The writes go through the parent list.
+			sub.Set(0, 20)
+			sub.Add(70)
+			sub.AddTo(0, 10)
+			if v, _ := sub.Remove(2); v != 3 {
+				t.Fatalf("Remove(2), expect: 3, actual: %v", v)
+			}
+			checkValues(t, sub, 10, 20, 4, 5, 6, 70)
+			checkValues(t, l, 0, 1, 10, 20, 4, 5, 6, 70, 7, 8, 9)
+
+			sub.SortWithOptions(true, nil)
+			checkValues(t, l, 0, 1, 70, 20, 10, 6, 5, 4, 7, 8, 9)
+			sub.Reverse()
+			checkValues(t, l, 0, 1, 4, 5, 6, 10, 20, 70, 7, 8, 9)
+			if i := sub.IndexOf(20); i != 4 {
+				t.Fatalf("IndexOf(20), expect: 4, actual: %d", i)
+			}
+
+			// The non-structural changes of the parent list are visible in the sublist.
+			l.Set(2, 40)
+			if v, _ := sub.Get(0); v != 40 {
+				t.Fatalf("Get(0), expect: 40, actual: %v", v)
+			}
+
+			// A nested sublist.
+			nested, err := sub.SubList(1, 4)
+			if err != nil {
+				t.Fatal(err)
+			}
+			nested.Clear()
+			checkValues(t, sub, 40, 20, 70)
+			checkValues(t, l, 0, 1, 40, 20, 70, 7, 8, 9)
+			nested.Add(1, 2)
+			checkValues(t, l, 0, 1, 40, 1, 2, 20, 70, 7, 8, 9)
+
+			// The structural changes of the sublist invalidate the nested sublist.
+			sub.RemoveByValue(40)
+			if _, err := nested.Get(0); !errors.Is(err, list.ErrConcurrentModification) {
+				t.Fatalf("expect ErrConcurrentModification, actual: %v", err)
+			}
+
+			// The structural changes of the parent list invalidate the sublist.
+			l.Add(10)
+			if err := sub.AddTo(0, 1); !errors.Is(err, list.ErrConcurrentModification) {
+				t.Fatalf("expect ErrConcurrentModification, actual: %v", err)
+			}
+			func() {
+				defer func() {
+					if r := recover(); r != list.ErrConcurrentModification {
+						t.Fatalf("expect ErrConcurrentModification, actual: %v", r)
+					}
+				}()
+				sub.Size()
+			}()
+			if _, err := nested.Get(0); !errors.Is(err, list.ErrConcurrentModification) {
+				t.Fatalf("expect ErrConcurrentModification, actual: %v", err)
+			}
+
+			for _, r := range [][2]int{{-1, 2}, {3, 2}, {0, 12}} {
+				if _, err := l.SubList(r[0], r[1]); err == nil {
+					t.Fatalf("SubList(%d, %d) should fail", r[0], r[1])
+				}
+			}
+		})
+	}
+}
+
+func TestListFuncLookups(t *testing.T) {
+	for name, newList := range newLists {
+		t.Run(name, func(t *testing.T) {
+			// The slices aren't comparable, so they can only be looked up by a predicate.
+			l := newList()
+			l.Add([]int{1}, []int{2}, []int{3}, []int{2})
+			is := func(n int) func(v interface{}) bool {
+				return func(v interface{}) bool { return v.([]int)[0] == n }
+			}
+
+			if i, j := l.IndexFunc(is(2)), l.LastIndexFunc(is(2)); i != 1 || j != 3 {
+				t.Fatalf("IndexFunc and LastIndexFunc, expect: 1, 3, actual: %d, %d", i, j)
+			}
+			if i, j := l.IndexFunc(is(4)), l.LastIndexFunc(is(4)); i != -1 || j != -1 {
+				t.Fatalf("expect -1 for the absent value, actual: %d, %d", i, j)
+			}
+			if !l.RemoveFunc(is(2)) || l.RemoveFunc(is(4)) {
+				t.Fatal("unexpected result of RemoveFunc")
+			}
+			checkValues(t, l, []int{1}, []int{3}, []int{2})
+
+			sub, _ := l.SubList(1, 3)
+			if i := sub.IndexFunc(is(2)); i != 1 {
+				t.Fatalf("IndexFunc on the sublist, expect: 1, actual: %d", i)
+			}
+			if !sub.RemoveFunc(is(3)) || sub.ContainsFunc(is(3)) {
+				t.Fatal("RemoveFunc on the sublist should remove the element")
+			}
+			checkValues(t, l, []int{1}, []int{2})
+
+			func() {
+				defer func() {
+					if recover() == nil {
+						t.Fatal("IndexOf should panic on the non-comparable elements")
+					}
+				}()
+				l.IndexOf([]int{2})
+			}()
+		})
+	}
+}
+
+func TestSubListOf(t *testing.T) {
+	al := list.NewArrayListOf[int]()
+	al.Add(1, 2, 3, 2, 1)
+
+	sub, err := al.SubList(1, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if i, j := list.IndexOf(sub, 2), list.LastIndexOf(sub, 2); i != 0 || j != 2 {
+		t.Fatalf("IndexOf and LastIndexOf, expect: 0, 2, actual: %d, %d", i, j)
+	}
+	if i := list.IndexOf(al, 1); i != 0 || list.LastIndexOf(al, 1) != 4 {
+		t.Fatalf("IndexOf(1), expect: 0, actual: %d", i)
+	}
+	if !list.RemoveByValue(sub, 3) || list.Contains(sub, 3) || list.Contains(al, 3) {
+		t.Fatal("RemoveByValue should remove the element from the parent list")
+	}
+	if i := list.IndexOf(sub, 1); i != -1 {
+		t.Fatalf("expect -1 for the value out of the sublist, actual: %d", i)
+	}
+}
+
+func TestSubListReadOnly(t *testing.T) {
+	for name, newList := range newLists {
+		t.Run(name, func(t *testing.T) {
+			l := newList()
+			l.Add(0, 1, 2, 3, 4)
+
+			sub, _ := l.SubList(1, 4)
+			sub.ReadOnly()
+			checkValues(t, sub, 1, 2, 3)
+			if v, err := sub.Get(1); err != nil || v != 2 {
+				t.Fatalf("Get(1), expect: 2, actual: %v, %v", v, err)
+			}
+
+			if err := sub.AddTo(0, 9); !errors.Is(err, list.ErrReadOnly) {
+				t.Fatalf("AddTo, expect ErrReadOnly, actual: %v", err)
+			}
+			if _, err := sub.Set(0, 9); !errors.Is(err, list.ErrReadOnly) {
+				t.Fatalf("Set, expect ErrReadOnly, actual: %v", err)
+			}
+			if _, err := sub.Remove(0); !errors.Is(err, list.ErrReadOnly) {
+				t.Fatalf("Remove, expect ErrReadOnly, actual: %v", err)
+			}
+			if err := sub.Swap(0, 1); !errors.Is(err, list.ErrReadOnly) {
+				t.Fatalf("Swap, expect ErrReadOnly, actual: %v", err)
+			}
+			for name, write := range map[string]func(){
+				"Add":           func() { sub.Add(9) },
+				"Clear":         sub.Clear,
+				"Reverse":       sub.Reverse,
+				"Sort":          sub.Sort,
+				"RemoveByValue": func() { sub.RemoveByValue(1) },
+				"RemoveIf":      func() { sub.RemoveIf(func(interface{}) bool { return true }) },
+				"ReplaceAll":    func() { sub.ReplaceAll(func(v interface{}) interface{} { return v }) },
+			} {
+				func() {
+					defer func() {
+						if r := recover(); r != list.ErrReadOnly {
+							t.Fatalf("%s, expect ErrReadOnly, actual: %v", name, r)
+						}
+					}()
+					write()
+				}()
+			}
+
+			// The nested sublists and the ListIterators are read-only too.
+			nested, _ := sub.SubList(0, 2)
+			if err := nested.AddTo(0, 9); !errors.Is(err, list.ErrReadOnly) {
+				t.Fatalf("AddTo on the nested sublist, expect ErrReadOnly, actual: %v", err)
+			}
+			it, _ := sub.ListIterator(0)
+			if v, err := it.Next(); err != nil || v != 1 {
+				t.Fatalf("Next, expect: 1, actual: %v, %v", v, err)
+			}
+			if err := it.Set(9); !errors.Is(err, list.ErrReadOnly) {
+				t.Fatalf("Set of the iterator, expect ErrReadOnly, actual: %v", err)
+			}
+			checkValues(t, l, 0, 1, 2, 3, 4)
+		})
+	}
+}
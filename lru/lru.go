@@ -0,0 +1,240 @@
+// Copyright (c) 2019, Benjamin Wang (benjamin_wang@aliyun.com). All rights reserved.
+// Licensed under the MIT license that can be found in the LICENSE file.
+
+// Package lru implements a LRU (least recently used) cache, based on an access-order linked map.
+// When the cache is full, the least recently used entry (the eldest entry) is evicted to make room for a new entry.
+// Besides the capacity, a RemoveEldest predicate can be configured to decide whether the eldest entry should be evicted
+// after a new entry is inserted, which is the same as the removeEldestEntry method of Java's LinkedHashMap.
+// New creates a cache whose keys and values are interface{}, while NewOf creates the type-parameterized Cache[K, V].
+//
+// The cache isn't thread-safe.
+package lru
+
+import (
+	"iter"
+
+	"github.com/ahrtr/gocontainer/collection"
+	"github.com/ahrtr/gocontainer/map/linkedmap"
+)
+
+// Interface is a type of LRU cache, and cache implements this interface.
+type Interface interface {
+	collection.Interface
+
+	// WithRemoveEldest sets a predicate, which is called with the eldest entry each time a new entry is inserted into the cache.
+	// The eldest entry is evicted if the predicate returns true. The capacity is still honored if a predicate is set.
+	WithRemoveEldest(f func(k, v interface{}) bool) Interface
+	// WithOnEvict sets a callback, which is called each time an entry is evicted from the cache.
+	// It isn't called for the entries removed by Remove or Clear.
+	WithOnEvict(f func(k, v interface{})) Interface
+
+	// Put associates the specified value with the specified key in the cache, and marks the key as the most recently used one.
+	// The eldest entries are evicted if needed. It returns the previous value associated with the specified key, or nil if there was no mapping for the key.
+	Put(k, v interface{}) interface{}
+	// Get returns the value to which the specified key is mapped and true, or nil and false if the cache contains no mapping for the key.
+	// It marks the key as the most recently used one, and counts a hit or miss.
+	Get(k interface{}) (interface{}, bool)
+	// Peek is the same as Get, but it neither changes the recency of the key nor counts a hit or miss.
+	Peek(k interface{}) (interface{}, bool)
+	// ContainsKey returns true if the cache contains a mapping for the specified key, without changing the recency of the key.
+	ContainsKey(k interface{}) bool
+	// Remove removes the mapping for a key from the cache if it is present.
+	// It returns the value to which the cache previously associated the key, and true,
+	// or nil and false if the cache contained no mapping for the key.
+	Remove(k interface{}) (interface{}, bool)
+
+	// Capacity returns the capacity of the cache, 0 means the cache is unbounded.
+	Capacity() int
+	// Resize changes the capacity of the cache, and evicts the eldest entries if needed.
+	// The cache is unbounded if capacity <= 0. It returns the number of entries evicted.
+	Resize(capacity int) int
+	// Stats returns the hit, miss and eviction counters of the cache.
+	Stats() Stats
+	// ResetStats resets all the counters to 0.
+	ResetStats()
+
+	// All returns an iterator over the key-value pairs in the cache, from the least recently used one to the most recently used one.
+	// It doesn't change the recency of the keys.
+	All() iter.Seq2[interface{}, interface{}]
+}
+
+// Stats contains the counters of a cache.
+type Stats struct {
+	// Hits is the number of Get calls which found the key.
+	Hits uint64
+	// Misses is the number of Get calls which didn't find the key.
+	Misses uint64
+	// Evictions is the number of entries evicted, either because of the capacity or the RemoveEldest predicate.
+	Evictions uint64
+}
+
+// Cache is a LRU cache, whose keys are of type K and values are of type V.
+type Cache[K comparable, V any] struct {
+	lm           *linkedmap.Map[K, V]
+	capacity     int
+	removeEldest func(k K, v V) bool
+	onEvict      func(k K, v V)
+	stats        Stats
+}
+
+// cache is the cache returned by New, it implements the Interface.
+type cache struct {
+	*Cache[interface{}, interface{}]
+}
+
+// New creates a cache with the given capacity. The cache is unbounded if capacity <= 0,
+// in which case the entries are only evicted by the RemoveEldest predicate.
+func New(capacity int) Interface {
+	return &cache{NewOf[interface{}, interface{}](capacity)}
+}
+
+// NewOf creates a cache with the given capacity, whose keys are of type K and values are of type V.
+// The cache is unbounded if capacity <= 0.
+func NewOf[K comparable, V any](capacity int) *Cache[K, V] {
+	if capacity < 0 {
+		capacity = 0
+	}
+	return &Cache[K, V]{
+		lm:       linkedmap.NewOf[K, V]().WithAccessOrder(true),
+		capacity: capacity,
+	}
+}
+
+func (c *cache) WithRemoveEldest(f func(k, v interface{}) bool) Interface {
+	c.Cache.WithRemoveEldest(f)
+	return c
+}
+
+func (c *cache) WithOnEvict(f func(k, v interface{})) Interface {
+	c.Cache.WithOnEvict(f)
+	return c
+}
+
+// WithRemoveEldest sets a predicate, which is called with the eldest entry each time a new entry is inserted into the cache.
+// The eldest entry is evicted if the predicate returns true. The capacity is still honored if a predicate is set.
+func (c *Cache[K, V]) WithRemoveEldest(f func(k K, v V) bool) *Cache[K, V] {
+	c.removeEldest = f
+	return c
+}
+
+// WithOnEvict sets a callback, which is called each time an entry is evicted from the cache.
+// It isn't called for the entries removed by Remove or Clear.
+func (c *Cache[K, V]) WithOnEvict(f func(k K, v V)) *Cache[K, V] {
+	c.onEvict = f
+	return c
+}
+
+// Size returns the number of entries in the cache.
+func (c *Cache[K, V]) Size() int {
+	return c.lm.Size()
+}
+
+// IsEmpty returns true if the cache contains no entries.
+func (c *Cache[K, V]) IsEmpty() bool {
+	return c.lm.IsEmpty()
+}
+
+// Clear removes all the entries from the cache. The counters aren't reset.
+func (c *Cache[K, V]) Clear() {
+	c.lm.Clear()
+}
+
+// Put associates the specified value with the specified key in the cache, and marks the key as the most recently used one.
+// The eldest entries are evicted if needed. It returns the previous value associated with the specified key,
+// or the zero value of V if there was no mapping for the key.
+func (c *Cache[K, V]) Put(k K, v V) V {
+	if c.lm.ContainsKey(k) {
+		return c.lm.Put(k, v)
+	}
+
+	retVal := c.lm.Put(k, v)
+	if c.removeEldest != nil {
+		if ek, ev, ok := c.lm.GetFirstElement(); ok && c.removeEldest(ek, ev) {
+			c.evict()
+		}
+	}
+	c.trim()
+	return retVal
+}
+
+// Get returns the value to which the specified key is mapped and true, or the zero value of V and false if the cache contains no mapping for the key.
+// It marks the key as the most recently used one, and counts a hit or miss.
+func (c *Cache[K, V]) Get(k K) (V, bool) {
+	if !c.lm.ContainsKey(k) {
+		c.stats.Misses++
+		var zero V
+		return zero, false
+	}
+	c.stats.Hits++
+	return c.lm.Get(k), true
+}
+
+// Peek is the same as Get, but it neither changes the recency of the key nor counts a hit or miss.
+func (c *Cache[K, V]) Peek(k K) (V, bool) {
+	return c.lm.Peek(k)
+}
+
+// ContainsKey returns true if the cache contains a mapping for the specified key, without changing the recency of the key.
+func (c *Cache[K, V]) ContainsKey(k K) bool {
+	return c.lm.ContainsKey(k)
+}
+
+// Remove removes the mapping for a key from the cache if it is present.
+// It returns the value to which the cache previously associated the key, and true,
+// or the zero value of V and false if the cache contained no mapping for the key.
+func (c *Cache[K, V]) Remove(k K) (V, bool) {
+	return c.lm.Remove(k)
+}
+
+// Capacity returns the capacity of the cache, 0 means the cache is unbounded.
+func (c *Cache[K, V]) Capacity() int {
+	return c.capacity
+}
+
+// Resize changes the capacity of the cache, and evicts the eldest entries if needed.
+// The cache is unbounded if capacity <= 0. It returns the number of entries evicted.
+func (c *Cache[K, V]) Resize(capacity int) int {
+	if capacity < 0 {
+		capacity = 0
+	}
+	c.capacity = capacity
+	return c.trim()
+}
+
+// Stats returns the hit, miss and eviction counters of the cache.
+func (c *Cache[K, V]) Stats() Stats {
+	return c.stats
+}
+
+// ResetStats resets all the counters to 0.
+func (c *Cache[K, V]) ResetStats() {
+	c.stats = Stats{}
+}
+
+// All returns an iterator over the key-value pairs in the cache, from the least recently used one to the most recently used one.
+// It doesn't change the recency of the keys.
+func (c *Cache[K, V]) All() iter.Seq2[K, V] {
+	return c.lm.All()
+}
+
+// trim evicts the eldest entries until the size doesn't exceed the capacity, and returns the number of entries evicted.
+func (c *Cache[K, V]) trim() int {
+	n := 0
+	for c.capacity > 0 && c.lm.Size() > c.capacity {
+		c.evict()
+		n++
+	}
+	return n
+}
+
+// evict removes the eldest entry, and calls the onEvict callback.
+func (c *Cache[K, V]) evict() {
+	k, v, ok := c.lm.RemoveFirstElement()
+	if !ok {
+		return
+	}
+	c.stats.Evictions++
+	if c.onEvict != nil {
+		c.onEvict(k, v)
+	}
+}
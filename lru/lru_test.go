@@ -0,0 +1,124 @@
+// Copyright (c) 2019, Benjamin Wang (benjamin_wang@aliyun.com). All rights reserved.
+// Licensed under the MIT license that can be found in the LICENSE file.
+
+package lru_test
+
+import (
+	"testing"
+
+	"github.com/ahrtr/gocontainer/lru"
+)
+
+func TestLRUEviction(t *testing.T) {
+	evicted := []interface{}{}
+	c := lru.New(2).WithOnEvict(func(k, v interface{}) {
+		evicted = append(evicted, k)
+	})
+
+	c.Put(1, "a")
+	c.Put(2, "b")
+	// 1 becomes the most recently used key, so 2 is evicted
+	if v, ok := c.Get(1); v != "a" || !ok {
+		t.Errorf("Failed to get the key 1, value: %v, success: %t\n", v, ok)
+	}
+	c.Put(3, "c")
+
+	if c.Size() != 2 || c.ContainsKey(2) {
+		t.Errorf("Unexpected result, length: %d, contains key 2: %t\n", c.Size(), c.ContainsKey(2))
+	}
+	if len(evicted) != 1 || evicted[0] != 2 {
+		t.Errorf("The evicted keys aren't expected, expect: [2], actual: %v\n", evicted)
+	}
+
+	// updating an existing key doesn't evict anything
+	if old := c.Put(1, "aa"); old != "a" {
+		t.Errorf("The previous value isn't expected, expect: a, actual: %v\n", old)
+	}
+	if len(evicted) != 1 {
+		t.Errorf("The evicted keys aren't expected, expect: [2], actual: %v\n", evicted)
+	}
+
+	// removed entries aren't evicted entries
+	c.Remove(1)
+	if len(evicted) != 1 || c.Size() != 1 {
+		t.Errorf("Unexpected result, evicted keys: %v, length: %d\n", evicted, c.Size())
+	}
+}
+
+func TestLRUPeek(t *testing.T) {
+	c := lru.NewOf[int, string](2)
+	c.Put(1, "a")
+	c.Put(2, "b")
+
+	// Peek doesn't change the recency, so 1 is still the eldest key
+	if v, ok := c.Peek(1); v != "a" || !ok {
+		t.Errorf("Failed to peek the key 1, value: %v, success: %t\n", v, ok)
+	}
+	c.Put(3, "c")
+	if _, ok := c.Peek(1); ok {
+		t.Errorf("The key 1 should have been evicted\n")
+	}
+
+	if s := c.Stats(); s.Hits != 0 || s.Misses != 0 || s.Evictions != 1 {
+		t.Errorf("The stats aren't expected, actual: %+v\n", s)
+	}
+}
+
+func TestLRURemoveEldest(t *testing.T) {
+	// an unbounded cache which only keeps the entries whose value is at least 10
+	c := lru.New(0).WithRemoveEldest(func(k, v interface{}) bool {
+		return v.(int) < 10
+	})
+
+	c.Put("a", 20)
+	c.Put("b", 5)
+	c.Put("c", 30)
+	if c.Size() != 3 {
+		t.Errorf("The length isn't expected, expect: 3, actual: %d\n", c.Size())
+	}
+
+	c.Get("a")
+	c.Put("d", 40)
+	if c.ContainsKey("b") || c.Size() != 3 {
+		t.Errorf("The key b should have been evicted, length: %d\n", c.Size())
+	}
+
+	keys := []interface{}{}
+	for k := range c.All() {
+		keys = append(keys, k)
+	}
+	expected := []interface{}{"c", "a", "d"}
+	for i := range expected {
+		if keys[i] != expected[i] {
+			t.Errorf("The keys aren't expected, expect: %v, actual: %v\n", expected, keys)
+			break
+		}
+	}
+}
+
+func TestLRUResizeAndStats(t *testing.T) {
+	c := lru.New(10)
+	for i := 0; i < 10; i++ {
+		c.Put(i, i)
+	}
+	for i := 0; i < 15; i++ {
+		c.Get(i)
+	}
+
+	if n := c.Resize(4); n != 6 || c.Size() != 4 || c.Capacity() != 4 {
+		t.Errorf("Unexpected result, evicted: %d, length: %d, capacity: %d\n", n, c.Size(), c.Capacity())
+	}
+	for i := 6; i < 10; i++ {
+		if !c.ContainsKey(i) {
+			t.Errorf("The key %d should be contained in the cache\n", i)
+		}
+	}
+
+	if s := c.Stats(); s.Hits != 10 || s.Misses != 5 || s.Evictions != 6 {
+		t.Errorf("The stats aren't expected, actual: %+v\n", s)
+	}
+	c.ResetStats()
+	if s := c.Stats(); s != (lru.Stats{}) {
+		t.Errorf("The stats should have been reset, actual: %+v\n", s)
+	}
+}
@@ -6,6 +6,8 @@
 // If a linkedMap is configured as access-order, then the first element in the list is the eldest element, which means it's the least recently inserted
 // or accessed element; while the last element is the newest element, which means it's the most recently inserted or accessed element.
 //
+// New creates a linked map whose keys and values are interface{}, while NewOf creates the type-parameterized Map[K, V].
+//
 // To iterate over an linkedMap (where lm is an instance of linkedmap.Interface):
 //	it, hasNext := lm.Iterator()
 //  var k, v interface{}
@@ -22,9 +24,21 @@
 //		// do something with k & v
 //	}
 //
+// Or range over an linkedMap:
+//	for k, v := range lm.All() {
+//		// do something with k & v
+//	}
+//
+// The entries can optionally expire after a time-to-live (TTL), which is set per entry by PutWithTTL, or for all entries
+// added by Put using WithDefaultTTL. The expired entries are removed lazily when they are looked up (Get, GetOrDefault, Peek,
+// ContainsKey, Put and Remove), or explicitly by PurgeExpired. Until then they are still counted by Size and returned
+// by the iterators and the GetFirstElement/GetLastElement methods.
 package linkedmap
 
 import (
+	"iter"
+	"time"
+
 	"github.com/ahrtr/gocontainer/collection"
 )
 
@@ -41,11 +55,30 @@ type Interface interface {
 	// WithAccessOrder configures the iteration ordering for this linked map,
 	// true for access-order, and false for insertion-order.
 	WithAccessOrder(accessOrder bool) Interface
+	// AccessOrder returns true if this map is configured as access-order, or false if it's insertion-order.
+	AccessOrder() bool
+
+	// WithDefaultTTL sets the time-to-live of the entries added or updated by Put. The entries never expire if ttl <= 0, which is the default.
+	WithDefaultTTL(ttl time.Duration) Interface
+	// WithClock sets the function used to get the current time, which is time.Now by default.
+	WithClock(now func() time.Time) Interface
+	// WithOnExpire sets a callback, which is called each time an expired entry is removed from this map.
+	// The callback must not modify the map.
+	WithOnExpire(f func(k, v interface{})) Interface
+	// PutWithTTL is the same as Put, but the entry expires after the specified ttl. It never expires if ttl <= 0.
+	PutWithTTL(k, v interface{}, ttl time.Duration) interface{}
+	// PurgeExpired removes all the expired entries from this map, and returns the number of entries removed.
+	PurgeExpired() int
+	// Expiring returns true if any entry in this map has a time-to-live, in which case looking up a key may remove an expired entry.
+	Expiring() bool
 
 	// Get returns the value to which the specified key is mapped, or nil if this map contains no mapping for the key.
 	Get(k interface{}) interface{}
 	// GetOrDefault returns the value to which the specified key is mapped, or the defaultValue if this map contains no mapping for the key.
 	GetOrDefault(k, defaultValue interface{}) interface{}
+	// Peek returns the value to which the specified key is mapped and true, or nil and false if this map contains no mapping for the key.
+	// Unlike Get, it never changes the iteration ordering, even if the map is configured as access-order.
+	Peek(k interface{}) (interface{}, bool)
 	// GetFirstElement gets the first element from this map, which is the head of the list.
 	// It returns the (key, value, true) if the map isn't empty, or (nil, nil, false) if the map is empty.
 	GetFirstElement() (interface{}, interface{}, bool)
@@ -55,8 +88,11 @@ type Interface interface {
 
 	// ContainsKey returns true if this map contains a mapping for the specified key.
 	ContainsKey(k interface{}) bool
-	// ContainsValue returns true if this map maps one or more keys to the specified value.
+	// ContainsValue returns true if this map maps one or more keys to the specified value. The values are compared
+	// with ==, which panics if they have identical dynamic types which aren't comparable; use ContainsValueFunc instead.
 	ContainsValue(v interface{}) bool
+	// ContainsValueFunc returns true if this map maps one or more keys to a value satisfying pred.
+	ContainsValueFunc(pred func(v interface{}) bool) bool
 
 	// Remove removes the mapping for a key from this map if it is present.
 	// It returns the value to which this map previously associated the key, and true,
@@ -73,62 +109,116 @@ type Interface interface {
 	Iterator() (func() (interface{}, interface{}, bool), bool)
 	// ReverseIterator returns an iterator over the elements in this map in reverse sequence as Iterator.
 	ReverseIterator() (func() (interface{}, interface{}, bool), bool)
+
+	// All returns an iterator over the key-value pairs in this map in proper sequence.
+	// It's safe to remove the current key-value pair during the iteration.
+	All() iter.Seq2[interface{}, interface{}]
+	// Backward returns an iterator over the key-value pairs in this map in reverse sequence as All.
+	Backward() iter.Seq2[interface{}, interface{}]
 }
 
-type element struct {
-	key   interface{}
-	value interface{}
-	prev  *element
-	next  *element
+type element[K comparable, V any] struct {
+	key   K
+	value V
+	prev  *element[K, V]
+	next  *element[K, V]
+	// expireAt is the time after which the element expires, the zero value means it never expires.
+	expireAt time.Time
 }
 
-// linkedMap implements the Interface.
-type linkedMap struct {
-	data        map[interface{}]*element
+// Map is a linked hashmap, whose keys are of type K and values are of type V.
+type Map[K comparable, V any] struct {
+	data        map[K]*element[K, V]
 	accessOrder bool
-	head        *element
-	tail        *element
+	head        *element[K, V]
+	tail        *element[K, V]
 	length      int
+
+	defaultTTL time.Duration
+	now        func() time.Time
+	onExpire   func(k K, v V)
+	// expiring is the number of elements which have an expiration time.
+	expiring int
+	// expiryOrdered is true if the expiration times are non-decreasing from the head to the tail,
+	// so that PurgeExpired can stop at the first element which isn't expired.
+	expiryOrdered bool
+}
+
+// linkedMap implements the Interface.
+type linkedMap struct {
+	*Map[interface{}, interface{}]
 }
 
 // New creates a linkedMap.
 func New() Interface {
-	return &linkedMap{
-		data:        map[interface{}]*element{},
-		accessOrder: false,
-		head:        nil,
-		tail:        nil,
-		length:      0,
+	return &linkedMap{NewOf[interface{}, interface{}]()}
+}
+
+// NewOf creates a Map, whose keys are of type K and values are of type V.
+func NewOf[K comparable, V any]() *Map[K, V] {
+	return &Map[K, V]{
+		data:          map[K]*element[K, V]{},
+		accessOrder:   false,
+		head:          nil,
+		tail:          nil,
+		length:        0,
+		now:           time.Now,
+		expiryOrdered: true,
 	}
 }
 
 func (lm *linkedMap) WithAccessOrder(accessOrder bool) Interface {
+	lm.Map.WithAccessOrder(accessOrder)
+	return lm
+}
+
+// WithAccessOrder configures the iteration ordering for this linked map,
+// true for access-order, and false for insertion-order.
+func (lm *Map[K, V]) WithAccessOrder(accessOrder bool) *Map[K, V] {
 	lm.accessOrder = accessOrder
 	return lm
 }
 
-func (lm *linkedMap) Size() int {
+// AccessOrder returns true if this map is configured as access-order, or false if it's insertion-order.
+func (lm *Map[K, V]) AccessOrder() bool {
+	return lm.accessOrder
+}
+
+func (lm *Map[K, V]) Size() int {
 	return lm.length
 }
 
-func (lm *linkedMap) IsEmpty() bool {
+func (lm *Map[K, V]) IsEmpty() bool {
 	return lm.Size() == 0
 }
 
-func (lm *linkedMap) Put(k, v interface{}) interface{} {
-	var retVal interface{}
-	if oldElement, ok := lm.data[k]; ok {
+func (lm *Map[K, V]) Put(k K, v V) V {
+	return lm.PutWithTTL(k, v, lm.defaultTTL)
+}
+
+func (lm *Map[K, V]) PutWithTTL(k K, v V, ttl time.Duration) V {
+	var expireAt time.Time
+	if ttl > 0 {
+		expireAt = lm.now().Add(ttl)
+	}
+
+	var retVal V
+	if oldElement, ok := lm.lookup(k); ok {
 		retVal = oldElement.value
 		oldElement.value = v
 		// move the element to the end of the list
 		if lm.accessOrder {
 			lm.unlink(oldElement)
+			oldElement.expireAt = expireAt
 			lm.linkLast(oldElement)
+		} else {
+			lm.setExpireAt(oldElement, expireAt)
 		}
 	} else {
-		e := &element{
-			key:   k,
-			value: v,
+		e := &element[K, V]{
+			key:      k,
+			value:    v,
+			expireAt: expireAt,
 		}
 		lm.data[k] = e
 		lm.linkLast(e)
@@ -137,8 +227,8 @@ func (lm *linkedMap) Put(k, v interface{}) interface{} {
 	return retVal
 }
 
-func (lm *linkedMap) Get(k interface{}) interface{} {
-	if oldElement, ok := lm.data[k]; ok {
+func (lm *Map[K, V]) Get(k K) V {
+	if oldElement, ok := lm.lookup(k); ok {
 		// move the element to the end of the list
 		if lm.accessOrder {
 			lm.unlink(oldElement)
@@ -147,11 +237,12 @@ func (lm *linkedMap) Get(k interface{}) interface{} {
 		return oldElement.value
 	}
 
-	return nil
+	var zero V
+	return zero
 }
 
-func (lm *linkedMap) GetOrDefault(k, defaultValue interface{}) interface{} {
-	if oldElement, ok := lm.data[k]; ok {
+func (lm *Map[K, V]) GetOrDefault(k K, defaultValue V) V {
+	if oldElement, ok := lm.lookup(k); ok {
 		// move the element to the end of the list
 		if lm.accessOrder {
 			lm.unlink(oldElement)
@@ -163,7 +254,16 @@ func (lm *linkedMap) GetOrDefault(k, defaultValue interface{}) interface{} {
 	return defaultValue
 }
 
-func (lm *linkedMap) GetFirstElement() (interface{}, interface{}, bool) {
+func (lm *Map[K, V]) Peek(k K) (V, bool) {
+	if e, ok := lm.lookup(k); ok {
+		return e.value, true
+	}
+
+	var zero V
+	return zero, false
+}
+
+func (lm *Map[K, V]) GetFirstElement() (K, V, bool) {
 	if lm.head != nil {
 		e := lm.head
 		k, v := e.key, e.value
@@ -171,10 +271,12 @@ func (lm *linkedMap) GetFirstElement() (interface{}, interface{}, bool) {
 		return k, v, true
 	}
 
-	return nil, nil, false
+	var zeroK K
+	var zeroV V
+	return zeroK, zeroV, false
 }
 
-func (lm *linkedMap) GetLastElement() (interface{}, interface{}, bool) {
+func (lm *Map[K, V]) GetLastElement() (K, V, bool) {
 	if lm.tail != nil {
 		e := lm.tail
 		k, v := e.key, e.value
@@ -182,18 +284,32 @@ func (lm *linkedMap) GetLastElement() (interface{}, interface{}, bool) {
 		return k, v, true
 	}
 
-	return nil, nil, false
+	var zeroK K
+	var zeroV V
+	return zeroK, zeroV, false
 }
 
-func (lm *linkedMap) ContainsKey(k interface{}) bool {
-	_, ok := lm.data[k]
+func (lm *Map[K, V]) ContainsKey(k K) bool {
+	_, ok := lm.lookup(k)
 	return ok
 }
 
 func (lm *linkedMap) ContainsValue(v interface{}) bool {
+	return lm.ContainsValueFunc(func(val interface{}) bool { return val == v })
+}
+
+// ContainsValue returns true if the map maps one or more keys to the specified value. Unlike the ContainsValue
+// method of the maps created by New, it only accepts the maps whose values are comparable, which is checked at
+// compile time, otherwise the values are looked up by ContainsValueFunc.
+func ContainsValue[K, V comparable](lm *Map[K, V], v V) bool {
+	return lm.ContainsValueFunc(func(val V) bool { return val == v })
+}
+
+// ContainsValueFunc returns true if the map maps one or more keys to a value satisfying pred.
+func (lm *Map[K, V]) ContainsValueFunc(pred func(v V) bool) bool {
 	e := lm.head
 	for e != nil {
-		if e.value == v {
+		if pred(e.value) {
 			return true
 		}
 		e = e.next
@@ -202,92 +318,131 @@ func (lm *linkedMap) ContainsValue(v interface{}) bool {
 	return false
 }
 
-func (lm *linkedMap) Remove(k interface{}) (interface{}, bool) {
-	if oldElement, ok := lm.data[k]; ok {
+func (lm *Map[K, V]) Remove(k K) (V, bool) {
+	var zeroK K
+	var zeroV V
+	if oldElement, ok := lm.lookup(k); ok {
 		retVal := oldElement.value
 		delete(lm.data, k)
 		lm.unlink(oldElement)
-		oldElement.key, oldElement.value = nil, nil
+		oldElement.key, oldElement.value = zeroK, zeroV
 		return retVal, true
 	}
 
-	return nil, false
+	return zeroV, false
 }
 
-func (lm *linkedMap) RemoveFirstElement() (interface{}, interface{}, bool) {
+func (lm *Map[K, V]) RemoveFirstElement() (K, V, bool) {
 	if lm.head != nil {
+		var zeroK K
+		var zeroV V
 		e := lm.head
 		k, v := e.key, e.value
 
+		delete(lm.data, k)
 		lm.unlink(e)
-		e.key, e.value = nil, nil
+		e.key, e.value = zeroK, zeroV
 
 		return k, v, true
 	}
 
-	return nil, nil, false
+	var zeroK K
+	var zeroV V
+	return zeroK, zeroV, false
 }
 
-func (lm *linkedMap) RemoveLastElement() (interface{}, interface{}, bool) {
+func (lm *Map[K, V]) RemoveLastElement() (K, V, bool) {
 	if lm.tail != nil {
+		var zeroK K
+		var zeroV V
 		e := lm.tail
 		k, v := e.key, e.value
 
+		delete(lm.data, k)
 		lm.unlink(e)
-		e.key, e.value = nil, nil
+		e.key, e.value = zeroK, zeroV
 
 		return k, v, true
 	}
 
-	return nil, nil, false
+	var zeroK K
+	var zeroV V
+	return zeroK, zeroV, false
 }
 
-func (lm *linkedMap) Clear() {
-	lm.data = map[interface{}]*element{}
+func (lm *Map[K, V]) Clear() {
+	var zeroK K
+	var zeroV V
+	lm.data = map[K]*element[K, V]{}
 
 	for e := lm.head; e != nil; {
 		next := e.next
-		e.prev, e.next, e.key, e.value = nil, nil, nil, nil
+		e.prev, e.next, e.key, e.value = nil, nil, zeroK, zeroV
 		e = next
 	}
 
 	lm.head, lm.tail, lm.length = nil, nil, 0
+	lm.expiring, lm.expiryOrdered = 0, true
 }
 
-func (lm *linkedMap) Iterator() (func() (interface{}, interface{}, bool), bool) {
+func (lm *Map[K, V]) Iterator() (func() (K, V, bool), bool) {
 	e := lm.head
 
-	return func() (interface{}, interface{}, bool) {
-		var k, v interface{}
+	return func() (K, V, bool) {
+		var k K
+		var v V
 		if e != nil {
 			k = e.key
 			v = e.value
 			e = e.next
-		} else {
-			k, v = nil, nil
 		}
 		return k, v, e != nil
 	}, e != nil
 }
 
-func (lm *linkedMap) ReverseIterator() (func() (interface{}, interface{}, bool), bool) {
+func (lm *Map[K, V]) ReverseIterator() (func() (K, V, bool), bool) {
 	e := lm.tail
 
-	return func() (interface{}, interface{}, bool) {
-		var k, v interface{}
+	return func() (K, V, bool) {
+		var k K
+		var v V
 		if e != nil {
 			k = e.key
 			v = e.value
 			e = e.prev
-		} else {
-			k, v = nil, nil
 		}
 		return k, v, e != nil
 	}, e != nil
 }
 
+func (lm *Map[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for e := lm.head; e != nil; {
+			// save the next element in advance, so that the current element can be removed by yield
+			next := e.next
+			if !yield(e.key, e.value) {
+				return
+			}
+			e = next
+		}
+	}
+}
+
+func (lm *Map[K, V]) Backward() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for e := lm.tail; e != nil; {
+			// save the prev element in advance, so that the current element can be removed by yield
+			prev := e.prev
+			if !yield(e.key, e.value) {
+				return
+			}
+			e = prev
+		}
+	}
+}
+
 // linkLast links val as last element.
-func (lm *linkedMap) linkLast(e *element) {
+func (lm *Map[K, V]) linkLast(e *element[K, V]) {
 	e.prev, e.next = lm.tail, nil
 
 	if nil == lm.tail {
@@ -298,10 +453,14 @@ func (lm *linkedMap) linkLast(e *element) {
 	}
 
 	lm.length++
+	if !e.expireAt.IsZero() {
+		lm.expiring++
+		lm.checkExpiryOrder(e)
+	}
 }
 
 // unlink removes the specified element e in this list.
-func (lm *linkedMap) unlink(e *element) {
+func (lm *Map[K, V]) unlink(e *element[K, V]) {
 	ePrev, eNext := e.prev, e.next
 	e.prev, e.next = nil, nil
 
@@ -318,4 +477,7 @@ func (lm *linkedMap) unlink(e *element) {
 	}
 
 	lm.length--
+	if !e.expireAt.IsZero() {
+		lm.expiring--
+	}
 }
@@ -96,6 +96,32 @@ func TestLinkedMapValue(t *testing.T) {
 	if lm.Size() != 2 {
 		t.Errorf("The length isn't expected, expect: 2, actual: %d\n", lm.Size())
 	}
+
+	if lm.ContainsKey(24) || lm.ContainsKey(35) {
+		t.Errorf("The removed keys shouldn't be contained in the map\n")
+	}
+	// re-adding a removed key should add a new element
+	lm.Put(24, "benjamin")
+	if k, _, _ := lm.GetLastElement(); k != 24 || lm.Size() != 3 {
+		t.Errorf("Failed to re-add the key 24, last key: %v, length: %d\n", k, lm.Size())
+	}
+}
+
+func TestLinkedMapPeek(t *testing.T) {
+	lm := linkedmap.New().WithAccessOrder(true)
+	lm.Put(24, "benjamin")
+	lm.Put(43, "alice")
+
+	if v, ok := lm.Peek(24); v != "benjamin" || !ok {
+		t.Errorf("Failed to peek the key 24, value: %v, success: %t\n", v, ok)
+	}
+	if v, ok := lm.Peek(18); v != nil || ok {
+		t.Errorf("Peeked a key which doesn't exist, value: %v, success: %t\n", v, ok)
+	}
+	// Peek doesn't change the access order
+	if k, _, _ := lm.GetFirstElement(); k != 24 {
+		t.Errorf("The first key isn't expected, expect: 24, actual: %v\n", k)
+	}
 }
 
 func TestLinkedMapIterate(t *testing.T) {
@@ -169,3 +195,80 @@ func checkReverseIterateResult(t *testing.T, lm linkedmap.Interface, expectedKey
 		t.Error("The iterator should have already reached the end")
 	}
 }
+
+func TestLinkedMapOf(t *testing.T) {
+	lm := linkedmap.NewOf[string, int]().WithAccessOrder(true)
+	lm.Put("benjamin", 24)
+	lm.Put("alice", 43)
+	lm.Put("john", 18)
+
+	if v := lm.Get("benjamin"); v != 24 {
+		t.Errorf("The value isn't expected, expect: 24, actual: %d\n", v)
+	}
+	if v := lm.Get("tom"); v != 0 {
+		t.Errorf("The zero value is expected for a missing key, actual: %d\n", v)
+	}
+
+	// benjamin was accessed most recently, so alice is the eldest element now.
+	k, v, ok := lm.GetFirstElement()
+	if !ok || k != "alice" || v != 43 {
+		t.Errorf("The first element isn't expected, expect: (alice, 43, true), actual: (%s, %d, %t)\n", k, v, ok)
+	}
+	k, v, ok = lm.GetLastElement()
+	if !ok || k != "benjamin" || v != 24 {
+		t.Errorf("The last element isn't expected, expect: (benjamin, 24, true), actual: (%s, %d, %t)\n", k, v, ok)
+	}
+}
+
+func TestLinkedMapContainsValue(t *testing.T) {
+	lm := linkedmap.NewOf[string, int]()
+	lm.Put("benjamin", 24)
+	lm.Put("alice", 43)
+	if !linkedmap.ContainsValue(lm, 43) || linkedmap.ContainsValue(lm, 18) {
+		t.Error("Unexpected result of ContainsValue")
+	}
+
+	// The slices aren't comparable, so they can only be looked up by a predicate.
+	slm := linkedmap.NewOf[string, []int]()
+	slm.Put("benjamin", []int{24})
+	slm.Put("alice", []int{43})
+	if !slm.ContainsValueFunc(func(v []int) bool { return v[0] == 43 }) {
+		t.Error("The value 43 should be found")
+	}
+	if slm.ContainsValueFunc(func(v []int) bool { return v[0] == 18 }) {
+		t.Error("The value 18 shouldn't be found")
+	}
+}
+
+func TestLinkedMapAll(t *testing.T) {
+	lm := linkedmap.New()
+	keys := []int{24, 43, 18}
+	values := []string{"benjamin", "alice", "john"}
+	for i := 0; i < len(keys); i++ {
+		lm.Put(keys[i], values[i])
+	}
+
+	i := 0
+	for k, v := range lm.All() {
+		if k != keys[i] || v != values[i] {
+			t.Errorf("The element isn't expected, expect: (%d, %s), actual: (%v, %v)\n", keys[i], values[i], k, v)
+		}
+		i++
+	}
+
+	i = len(keys) - 1
+	for k, v := range lm.Backward() {
+		if k != keys[i] || v != values[i] {
+			t.Errorf("The element isn't expected, expect: (%d, %s), actual: (%v, %v)\n", keys[i], values[i], k, v)
+		}
+		i--
+	}
+
+	// remove the elements during the iteration
+	for k := range lm.All() {
+		lm.Remove(k)
+	}
+	if !lm.IsEmpty() {
+		t.Errorf("The map should be empty, actual length: %d\n", lm.Size())
+	}
+}
@@ -0,0 +1,138 @@
+// Copyright (c) 2019, Benjamin Wang (benjamin_wang@aliyun.com). All rights reserved.
+// Licensed under the MIT license that can be found in the LICENSE file.
+
+package linkedmap
+
+import "time"
+
+func (lm *linkedMap) WithDefaultTTL(ttl time.Duration) Interface {
+	lm.Map.WithDefaultTTL(ttl)
+	return lm
+}
+
+func (lm *linkedMap) WithClock(now func() time.Time) Interface {
+	lm.Map.WithClock(now)
+	return lm
+}
+
+func (lm *linkedMap) WithOnExpire(f func(k, v interface{})) Interface {
+	lm.Map.WithOnExpire(f)
+	return lm
+}
+
+// WithDefaultTTL sets the time-to-live of the entries added or updated by Put. The entries never expire if ttl <= 0, which is the default.
+func (lm *Map[K, V]) WithDefaultTTL(ttl time.Duration) *Map[K, V] {
+	lm.defaultTTL = ttl
+	return lm
+}
+
+// WithClock sets the function used to get the current time, which is time.Now by default.
+func (lm *Map[K, V]) WithClock(now func() time.Time) *Map[K, V] {
+	if now == nil {
+		now = time.Now
+	}
+	lm.now = now
+	return lm
+}
+
+// WithOnExpire sets a callback, which is called each time an expired entry is removed from this map.
+// The callback must not modify the map.
+func (lm *Map[K, V]) WithOnExpire(f func(k K, v V)) *Map[K, V] {
+	lm.onExpire = f
+	return lm
+}
+
+// Expiring returns true if any entry in this map has a time-to-live, in which case looking up a key may remove an expired entry.
+func (lm *Map[K, V]) Expiring() bool {
+	return lm.expiring > 0
+}
+
+// PurgeExpired removes all the expired entries from this map, and returns the number of entries removed.
+// It walks the list from the head, and stops at the first entry which isn't expired if the expiration times are
+// known to be in order, which is the case when all the entries are added with the same TTL in insertion-order.
+func (lm *Map[K, V]) PurgeExpired() int {
+	if lm.expiring == 0 {
+		return 0
+	}
+
+	now := lm.now()
+	n := 0
+	ordered := true
+	var last *element[K, V]
+	for e := lm.head; e != nil; {
+		// save the next element in advance, because e is unlinked if it's expired
+		next := e.next
+		if expired(e, now) {
+			lm.expire(e)
+			n++
+		} else {
+			if lm.expiryOrdered {
+				return n
+			}
+			if last != nil && expiresBefore(e, last) {
+				ordered = false
+			}
+			last = e
+		}
+		e = next
+	}
+	// all the remaining elements have been checked, so the order is known now.
+	lm.expiryOrdered = ordered
+	return n
+}
+
+// lookup returns the element associated with the key k, the element is removed if it's expired.
+func (lm *Map[K, V]) lookup(k K) (*element[K, V], bool) {
+	e, ok := lm.data[k]
+	if !ok {
+		return nil, false
+	}
+	if lm.expiring > 0 && expired(e, lm.now()) {
+		lm.expire(e)
+		return nil, false
+	}
+	return e, true
+}
+
+// expire removes the expired element e, and calls the onExpire callback.
+func (lm *Map[K, V]) expire(e *element[K, V]) {
+	var zeroK K
+	var zeroV V
+	k, v := e.key, e.value
+
+	delete(lm.data, k)
+	lm.unlink(e)
+	e.key, e.value = zeroK, zeroV
+
+	if lm.onExpire != nil {
+		lm.onExpire(k, v)
+	}
+}
+
+// setExpireAt updates the expiration time of the element e, which is linked in the list.
+func (lm *Map[K, V]) setExpireAt(e *element[K, V], expireAt time.Time) {
+	if e.expireAt.IsZero() && !expireAt.IsZero() {
+		lm.expiring++
+	} else if !e.expireAt.IsZero() && expireAt.IsZero() {
+		lm.expiring--
+	}
+	e.expireAt = expireAt
+	lm.checkExpiryOrder(e)
+}
+
+// checkExpiryOrder clears the expiryOrdered flag if the expiration time of the element e is out of order with its neighbours.
+func (lm *Map[K, V]) checkExpiryOrder(e *element[K, V]) {
+	if (e.prev != nil && expiresBefore(e, e.prev)) || (e.next != nil && expiresBefore(e.next, e)) {
+		lm.expiryOrdered = false
+	}
+}
+
+// expired returns true if the element e is expired at the specified time.
+func expired[K comparable, V any](e *element[K, V], now time.Time) bool {
+	return !e.expireAt.IsZero() && !now.Before(e.expireAt)
+}
+
+// expiresBefore returns true if the element e1 expires before e2, an element without an expiration time never expires.
+func expiresBefore[K comparable, V any](e1, e2 *element[K, V]) bool {
+	return !e1.expireAt.IsZero() && (e2.expireAt.IsZero() || e1.expireAt.Before(e2.expireAt))
+}
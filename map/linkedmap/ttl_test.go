@@ -0,0 +1,110 @@
+// Copyright (c) 2019, Benjamin Wang (benjamin_wang@aliyun.com). All rights reserved.
+// Licensed under the MIT license that can be found in the LICENSE file.
+
+package linkedmap_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ahrtr/gocontainer/map/linkedmap"
+)
+
+// fakeClock is a clock which only moves forward when it's advanced explicitly.
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time { return c.now }
+
+func (c *fakeClock) Advance(d time.Duration) { c.now = c.now.Add(d) }
+
+func TestLinkedMapLazyExpiry(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	expired := []interface{}{}
+	lm := linkedmap.New().WithClock(clock.Now).WithOnExpire(func(k, v interface{}) {
+		expired = append(expired, k)
+	})
+
+	lm.PutWithTTL(24, "benjamin", time.Second)
+	lm.PutWithTTL(43, "alice", 3*time.Second)
+	lm.Put(18, "john")
+	if !lm.Expiring() {
+		t.Errorf("The map should have expiring entries\n")
+	}
+
+	clock.Advance(2 * time.Second)
+	if lm.Size() != 3 {
+		t.Errorf("The expired entries should be removed lazily, length: %d\n", lm.Size())
+	}
+	if lm.ContainsKey(24) || lm.Get(24) != nil {
+		t.Errorf("The key 24 should have expired\n")
+	}
+	if v := lm.Get(43); v != "alice" {
+		t.Errorf("The value isn't expected, expect: alice, actual: %v\n", v)
+	}
+	if lm.Size() != 2 || len(expired) != 1 || expired[0] != 24 {
+		t.Errorf("Unexpected result, length: %d, expired keys: %v\n", lm.Size(), expired)
+	}
+
+	// updating an entry with Put resets its TTL to the default one, which never expires.
+	lm.Put(43, "alice")
+	clock.Advance(time.Hour)
+	if v, ok := lm.Peek(43); v != "alice" || !ok {
+		t.Errorf("The key 43 shouldn't expire, value: %v, success: %t\n", v, ok)
+	}
+	if lm.Expiring() {
+		t.Errorf("The map shouldn't have expiring entries\n")
+	}
+}
+
+func TestLinkedMapPurgeExpired(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	lm := linkedmap.NewOf[int, string]().WithClock(clock.Now).WithDefaultTTL(time.Minute)
+
+	for i := 0; i < 10; i++ {
+		lm.Put(i, "v")
+		clock.Advance(time.Second)
+	}
+	clock.Advance(54 * time.Second)
+	if n := lm.PurgeExpired(); n != 5 || lm.Size() != 5 {
+		t.Errorf("Unexpected result, purged: %d, length: %d\n", n, lm.Size())
+	}
+	if k, _, _ := lm.GetFirstElement(); k != 5 {
+		t.Errorf("The first key isn't expected, expect: 5, actual: %d\n", k)
+	}
+
+	// the expiration times are out of order, so the whole list must be walked.
+	lm.PutWithTTL(100, "v", time.Second)
+	lm.Put(101, "v")
+	lm.PutWithTTL(102, "v", 0)
+	clock.Advance(2 * time.Second)
+	// the keys 5, 6 and 100 expire
+	if n := lm.PurgeExpired(); n != 3 || lm.Size() != 5 {
+		t.Errorf("Unexpected result, purged: %d, length: %d\n", n, lm.Size())
+	}
+	if lm.ContainsKey(100) || !lm.ContainsKey(101) || !lm.ContainsKey(102) {
+		t.Errorf("Unexpected result, keys: 100: %t, 101: %t, 102: %t\n", lm.ContainsKey(100), lm.ContainsKey(101), lm.ContainsKey(102))
+	}
+
+	lm.Clear()
+	if lm.Expiring() || lm.PurgeExpired() != 0 {
+		t.Errorf("A cleared map shouldn't have expiring entries\n")
+	}
+}
+
+func TestLinkedMapAccessOrderTTL(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	lm := linkedmap.New().WithAccessOrder(true).WithClock(clock.Now).WithDefaultTTL(time.Minute)
+
+	lm.Put(1, "a")
+	clock.Advance(30 * time.Second)
+	lm.Put(2, "b")
+	// the access doesn't refresh the TTL, but it moves the key 1 after the key 2
+	lm.Get(1)
+	clock.Advance(45 * time.Second)
+
+	if n := lm.PurgeExpired(); n != 1 || lm.ContainsKey(1) || !lm.ContainsKey(2) {
+		t.Errorf("Unexpected result, purged: %d, length: %d\n", n, lm.Size())
+	}
+}
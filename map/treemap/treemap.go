@@ -0,0 +1,532 @@
+// Copyright (c) 2019, Benjamin Wang (benjamin_wang@aliyun.com). All rights reserved.
+// Licensed under the MIT license that can be found in the LICENSE file.
+
+// Package treemap implements a sorted map based on a btree. The keys are ordered according to their natural ordering,
+// or by a utils.Comparator provided by WithComparator, which should be called before any entry is put into the map.
+//
+// Besides the common map operations, a treeMap supports the navigation methods (Floor, Ceiling, Lower, Higher,
+// FirstKey, LastKey, PollFirst and PollLast), and views (HeadMap, TailMap, SubMap and DescendingMap). A view is backed
+// by the map, so changes to the map are reflected in the view, and vice-versa. Putting a key out of the range of
+// a view panics. The navigation methods of a descending view work on the reversed order, e.g. its FirstKey is the
+// highest key, and its Floor returns the least key greater than or equal to the given key.
+//
+// New creates a sorted map whose keys and values are interface{}, while NewOf creates the type-parameterized TreeMap[K, V].
+//
+// To iterate over a treeMap in ascending order of the keys (where tm is an instance of treemap.Interface):
+//	for k, v := range tm.All() {
+//		// do something with k & v
+//	}
+package treemap
+
+import (
+	"iter"
+
+	"github.com/ahrtr/gocontainer/btree"
+	"github.com/ahrtr/gocontainer/collection"
+	"github.com/ahrtr/gocontainer/utils"
+)
+
+// degree is the degree of the underlying btree.
+const degree = 32
+
+// Interface is a type of sorted map, and treeMap implements this interface.
+type Interface interface {
+	collection.Interface
+
+	// WithComparator sets an utils.Comparator instance for the map, which is used to impose a total ordering on the keys.
+	// It should be called before any entry is put into the map.
+	WithComparator(c utils.Comparator) Interface
+
+	// Put associates the specified value with the specified key in this map. If the map previously contained a mapping for the key,
+	// the old value is replaced by the specified value.
+	// It returns the previous value associated with the specified key, or nil if there was no mapping for the key.
+	// It panics if the key is out of the range of a view.
+	Put(k, v interface{}) interface{}
+	// Get returns the value to which the specified key is mapped, or nil if this map contains no mapping for the key.
+	Get(k interface{}) interface{}
+	// GetOrDefault returns the value to which the specified key is mapped, or the defaultValue if this map contains no mapping for the key.
+	GetOrDefault(k, defaultValue interface{}) interface{}
+	// ContainsKey returns true if this map contains a mapping for the specified key.
+	ContainsKey(k interface{}) bool
+	// Remove removes the mapping for a key from this map if it is present.
+	// It returns the value to which this map previously associated the key, and true,
+	// or nil and false if the map contained no mapping for the key.
+	Remove(k interface{}) (interface{}, bool)
+
+	// Floor returns the entry with the greatest key less than or equal to the given key.
+	// It returns (key, value, true), or (nil, nil, false) if there is no such key.
+	Floor(k interface{}) (interface{}, interface{}, bool)
+	// Ceiling returns the entry with the least key greater than or equal to the given key.
+	// It returns (key, value, true), or (nil, nil, false) if there is no such key.
+	Ceiling(k interface{}) (interface{}, interface{}, bool)
+	// Lower returns the entry with the greatest key strictly less than the given key.
+	// It returns (key, value, true), or (nil, nil, false) if there is no such key.
+	Lower(k interface{}) (interface{}, interface{}, bool)
+	// Higher returns the entry with the least key strictly greater than the given key.
+	// It returns (key, value, true), or (nil, nil, false) if there is no such key.
+	Higher(k interface{}) (interface{}, interface{}, bool)
+	// FirstKey returns the first (lowest) key in this map. It returns (key, true), or (nil, false) if this map is empty.
+	FirstKey() (interface{}, bool)
+	// LastKey returns the last (highest) key in this map. It returns (key, true), or (nil, false) if this map is empty.
+	LastKey() (interface{}, bool)
+	// PollFirst removes and returns the entry with the first (lowest) key in this map.
+	// It returns (key, value, true), or (nil, nil, false) if this map is empty.
+	PollFirst() (interface{}, interface{}, bool)
+	// PollLast removes and returns the entry with the last (highest) key in this map.
+	// It returns (key, value, true), or (nil, nil, false) if this map is empty.
+	PollLast() (interface{}, interface{}, bool)
+
+	// HeadMap returns a view of the portion of this map whose keys are less than (or equal to, if inclusive is true) toKey.
+	HeadMap(toKey interface{}, inclusive bool) Interface
+	// TailMap returns a view of the portion of this map whose keys are greater than (or equal to, if inclusive is true) fromKey.
+	TailMap(fromKey interface{}, inclusive bool) Interface
+	// SubMap returns a view of the portion of this map whose keys range from fromKey to toKey.
+	SubMap(fromKey interface{}, fromInclusive bool, toKey interface{}, toInclusive bool) Interface
+	// DescendingMap returns a reverse order view of this map.
+	DescendingMap() Interface
+
+	// All returns an iterator over the entries in this map in ascending order of the keys.
+	All() iter.Seq2[interface{}, interface{}]
+	// Backward returns an iterator over the entries in this map in descending order of the keys.
+	Backward() iter.Seq2[interface{}, interface{}]
+}
+
+// entry is a key-value pair stored in the btree.
+type entry[K, V any] struct {
+	key   K
+	value V
+}
+
+// tree is the btree shared by a map and all its views.
+type tree[K, V any] struct {
+	bt  *btree.BTree[entry[K, V]]
+	cmp func(a, b K) int
+}
+
+// bound is a lower or upper bound of a view.
+type bound[K any] struct {
+	key       K
+	set       bool
+	inclusive bool
+}
+
+// TreeMap is a sorted map, whose keys are of type K and values are of type V.
+type TreeMap[K, V any] struct {
+	t *tree[K, V]
+	// lo and hi are the bounds of the keys in ascending order, which are set for the views only.
+	lo, hi bound[K]
+	// desc is true for a descending view.
+	desc bool
+}
+
+// treeMap is the sorted map returned by New, it implements the Interface.
+type treeMap struct {
+	*TreeMap[interface{}, interface{}]
+}
+
+// New creates a treeMap.
+func New() Interface {
+	return &treeMap{NewOf[interface{}, interface{}](nil)}
+}
+
+// NewOf creates a TreeMap, whose keys are of type K and values are of type V.
+// The keys are ordered by cmp, or according to their natural ordering if cmp is nil.
+func NewOf[K, V any](cmp func(a, b K) int) *TreeMap[K, V] {
+	t := &tree[K, V]{}
+	t.bt = btree.NewOf(degree, func(a, b entry[K, V]) int {
+		return t.cmp(a.key, b.key)
+	})
+	tm := &TreeMap[K, V]{t: t}
+	return tm.WithComparator(cmp)
+}
+
+func (tm *treeMap) WithComparator(c utils.Comparator) Interface {
+	tm.TreeMap.WithComparator(utils.CompareFunc[interface{}](c))
+	return tm
+}
+
+func (tm *treeMap) HeadMap(toKey interface{}, inclusive bool) Interface {
+	return &treeMap{tm.TreeMap.HeadMap(toKey, inclusive)}
+}
+
+func (tm *treeMap) TailMap(fromKey interface{}, inclusive bool) Interface {
+	return &treeMap{tm.TreeMap.TailMap(fromKey, inclusive)}
+}
+
+func (tm *treeMap) SubMap(fromKey interface{}, fromInclusive bool, toKey interface{}, toInclusive bool) Interface {
+	return &treeMap{tm.TreeMap.SubMap(fromKey, fromInclusive, toKey, toInclusive)}
+}
+
+func (tm *treeMap) DescendingMap() Interface {
+	return &treeMap{tm.TreeMap.DescendingMap()}
+}
+
+// WithComparator sets the comparison function for the map, which is used to impose a total ordering on the keys.
+// The keys are ordered according to their natural ordering if cmp is nil. It should be called before any entry is put into the map.
+func (tm *TreeMap[K, V]) WithComparator(cmp func(a, b K) int) *TreeMap[K, V] {
+	if cmp == nil {
+		cmp = utils.CompareFunc[K](nil)
+	}
+	tm.t.cmp = cmp
+	return tm
+}
+
+// Size returns the number of entries in this map. It takes O(n) for a view, where n is the number of entries in the view.
+func (tm *TreeMap[K, V]) Size() int {
+	if !tm.lo.set && !tm.hi.set {
+		return tm.t.bt.Size()
+	}
+	n := 0
+	for range tm.entries(false) {
+		n++
+	}
+	return n
+}
+
+// IsEmpty returns true if this map contains no entries.
+func (tm *TreeMap[K, V]) IsEmpty() bool {
+	_, ok := tm.lowest()
+	return !ok
+}
+
+// Clear removes all the entries from this map.
+func (tm *TreeMap[K, V]) Clear() {
+	if !tm.lo.set && !tm.hi.set {
+		tm.t.bt.Clear()
+		return
+	}
+	var keys []entry[K, V]
+	for e := range tm.entries(false) {
+		keys = append(keys, e)
+	}
+	for _, e := range keys {
+		tm.t.bt.Delete(e)
+	}
+}
+
+// Put associates the specified value with the specified key in this map. If the map previously contained a mapping for the key,
+// the old value is replaced by the specified value.
+// It returns the previous value associated with the specified key, or the zero value of V if there was no mapping for the key.
+// It panics if the key is out of the range of a view.
+func (tm *TreeMap[K, V]) Put(k K, v V) V {
+	if !tm.inRange(k) {
+		panic("key out of range")
+	}
+	old, _ := tm.t.bt.ReplaceOrInsert(entry[K, V]{k, v})
+	return old.value
+}
+
+// Get returns the value to which the specified key is mapped, or the zero value of V if this map contains no mapping for the key.
+func (tm *TreeMap[K, V]) Get(k K) V {
+	var zero V
+	return tm.GetOrDefault(k, zero)
+}
+
+// GetOrDefault returns the value to which the specified key is mapped, or the defaultValue if this map contains no mapping for the key.
+func (tm *TreeMap[K, V]) GetOrDefault(k K, defaultValue V) V {
+	if !tm.inRange(k) {
+		return defaultValue
+	}
+	if e, ok := tm.t.bt.Get(entry[K, V]{key: k}); ok {
+		return e.value
+	}
+	return defaultValue
+}
+
+// ContainsKey returns true if this map contains a mapping for the specified key.
+func (tm *TreeMap[K, V]) ContainsKey(k K) bool {
+	return tm.inRange(k) && tm.t.bt.Has(entry[K, V]{key: k})
+}
+
+// Remove removes the mapping for a key from this map if it is present.
+// It returns the value to which this map previously associated the key, and true,
+// or the zero value of V and false if the map contained no mapping for the key.
+func (tm *TreeMap[K, V]) Remove(k K) (V, bool) {
+	if !tm.inRange(k) {
+		var zero V
+		return zero, false
+	}
+	e, ok := tm.t.bt.Delete(entry[K, V]{key: k})
+	return e.value, ok
+}
+
+// Floor returns the entry with the greatest key less than or equal to the given key.
+// It returns (key, value, true), or (zero value of K, zero value of V, false) if there is no such key.
+func (tm *TreeMap[K, V]) Floor(k K) (K, V, bool) {
+	if tm.desc {
+		return unpack(tm.above(k, true))
+	}
+	return unpack(tm.below(k, true))
+}
+
+// Ceiling returns the entry with the least key greater than or equal to the given key.
+// It returns (key, value, true), or (zero value of K, zero value of V, false) if there is no such key.
+func (tm *TreeMap[K, V]) Ceiling(k K) (K, V, bool) {
+	if tm.desc {
+		return unpack(tm.below(k, true))
+	}
+	return unpack(tm.above(k, true))
+}
+
+// Lower returns the entry with the greatest key strictly less than the given key.
+// It returns (key, value, true), or (zero value of K, zero value of V, false) if there is no such key.
+func (tm *TreeMap[K, V]) Lower(k K) (K, V, bool) {
+	if tm.desc {
+		return unpack(tm.above(k, false))
+	}
+	return unpack(tm.below(k, false))
+}
+
+// Higher returns the entry with the least key strictly greater than the given key.
+// It returns (key, value, true), or (zero value of K, zero value of V, false) if there is no such key.
+func (tm *TreeMap[K, V]) Higher(k K) (K, V, bool) {
+	if tm.desc {
+		return unpack(tm.below(k, false))
+	}
+	return unpack(tm.above(k, false))
+}
+
+// FirstKey returns the first (lowest) key in this map. It returns (key, true), or (zero value of K, false) if this map is empty.
+func (tm *TreeMap[K, V]) FirstKey() (K, bool) {
+	e, ok := tm.first()
+	return e.key, ok
+}
+
+// LastKey returns the last (highest) key in this map. It returns (key, true), or (zero value of K, false) if this map is empty.
+func (tm *TreeMap[K, V]) LastKey() (K, bool) {
+	e, ok := tm.last()
+	return e.key, ok
+}
+
+// PollFirst removes and returns the entry with the first (lowest) key in this map.
+// It returns (key, value, true), or (zero value of K, zero value of V, false) if this map is empty.
+func (tm *TreeMap[K, V]) PollFirst() (K, V, bool) {
+	e, ok := tm.first()
+	if ok {
+		tm.t.bt.Delete(e)
+	}
+	return unpack(e, ok)
+}
+
+// PollLast removes and returns the entry with the last (highest) key in this map.
+// It returns (key, value, true), or (zero value of K, zero value of V, false) if this map is empty.
+func (tm *TreeMap[K, V]) PollLast() (K, V, bool) {
+	e, ok := tm.last()
+	if ok {
+		tm.t.bt.Delete(e)
+	}
+	return unpack(e, ok)
+}
+
+// HeadMap returns a view of the portion of this map whose keys are less than (or equal to, if inclusive is true) toKey.
+func (tm *TreeMap[K, V]) HeadMap(toKey K, inclusive bool) *TreeMap[K, V] {
+	view := *tm
+	b := bound[K]{key: toKey, set: true, inclusive: inclusive}
+	if tm.desc {
+		view.lo = tm.tighterLo(b)
+	} else {
+		view.hi = tm.tighterHi(b)
+	}
+	return &view
+}
+
+// TailMap returns a view of the portion of this map whose keys are greater than (or equal to, if inclusive is true) fromKey.
+func (tm *TreeMap[K, V]) TailMap(fromKey K, inclusive bool) *TreeMap[K, V] {
+	view := *tm
+	b := bound[K]{key: fromKey, set: true, inclusive: inclusive}
+	if tm.desc {
+		view.hi = tm.tighterHi(b)
+	} else {
+		view.lo = tm.tighterLo(b)
+	}
+	return &view
+}
+
+// SubMap returns a view of the portion of this map whose keys range from fromKey to toKey.
+func (tm *TreeMap[K, V]) SubMap(fromKey K, fromInclusive bool, toKey K, toInclusive bool) *TreeMap[K, V] {
+	return tm.TailMap(fromKey, fromInclusive).HeadMap(toKey, toInclusive)
+}
+
+// DescendingMap returns a reverse order view of this map.
+func (tm *TreeMap[K, V]) DescendingMap() *TreeMap[K, V] {
+	view := *tm
+	view.desc = !tm.desc
+	return &view
+}
+
+// All returns an iterator over the entries in this map in ascending order of the keys.
+func (tm *TreeMap[K, V]) All() iter.Seq2[K, V] {
+	return tm.seq(tm.desc)
+}
+
+// Backward returns an iterator over the entries in this map in descending order of the keys.
+func (tm *TreeMap[K, V]) Backward() iter.Seq2[K, V] {
+	return tm.seq(!tm.desc)
+}
+
+func (tm *TreeMap[K, V]) seq(desc bool) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for e := range tm.entries(desc) {
+			if !yield(e.key, e.value) {
+				return
+			}
+		}
+	}
+}
+
+// entries returns an iterator over the entries within the bounds, in ascending order of the keys if desc is false.
+func (tm *TreeMap[K, V]) entries(desc bool) iter.Seq[entry[K, V]] {
+	return func(yield func(entry[K, V]) bool) {
+		var it iter.Seq[entry[K, V]]
+		switch {
+		case !desc && tm.lo.set:
+			it = tm.t.bt.AllGreaterOrEqual(entry[K, V]{key: tm.lo.key})
+		case !desc:
+			it = tm.t.bt.All()
+		case tm.hi.set:
+			it = tm.t.bt.BackwardLessOrEqual(entry[K, V]{key: tm.hi.key})
+		default:
+			it = tm.t.bt.Backward()
+		}
+
+		for e := range it {
+			if !tm.inRange(e.key) {
+				if (desc && tm.tooLow(e.key)) || (!desc && tm.tooHigh(e.key)) {
+					return
+				}
+				// it's the excluded start key
+				continue
+			}
+			if !yield(e) {
+				return
+			}
+		}
+	}
+}
+
+// first returns the first entry in the order of this map.
+func (tm *TreeMap[K, V]) first() (entry[K, V], bool) {
+	if tm.desc {
+		return tm.highest()
+	}
+	return tm.lowest()
+}
+
+// last returns the last entry in the order of this map.
+func (tm *TreeMap[K, V]) last() (entry[K, V], bool) {
+	if tm.desc {
+		return tm.lowest()
+	}
+	return tm.highest()
+}
+
+// lowest returns the entry with the lowest key within the bounds.
+func (tm *TreeMap[K, V]) lowest() (entry[K, V], bool) {
+	for e := range tm.entries(false) {
+		return e, true
+	}
+	return entry[K, V]{}, false
+}
+
+// highest returns the entry with the highest key within the bounds.
+func (tm *TreeMap[K, V]) highest() (entry[K, V], bool) {
+	for e := range tm.entries(true) {
+		return e, true
+	}
+	return entry[K, V]{}, false
+}
+
+// below returns the entry with the greatest key less than (or equal to, if inclusive is true) k within the bounds.
+func (tm *TreeMap[K, V]) below(k K, inclusive bool) (entry[K, V], bool) {
+	// start from the upper bound if k is beyond it
+	if tm.hi.set {
+		if c := tm.t.cmp(k, tm.hi.key); c > 0 {
+			k, inclusive = tm.hi.key, tm.hi.inclusive
+		} else if c == 0 {
+			inclusive = inclusive && tm.hi.inclusive
+		}
+	}
+	for e := range tm.t.bt.BackwardLessOrEqual(entry[K, V]{key: k}) {
+		if !inclusive && tm.t.cmp(e.key, k) == 0 {
+			continue
+		}
+		if tm.tooLow(e.key) {
+			break
+		}
+		return e, true
+	}
+	return entry[K, V]{}, false
+}
+
+// above returns the entry with the least key greater than (or equal to, if inclusive is true) k within the bounds.
+func (tm *TreeMap[K, V]) above(k K, inclusive bool) (entry[K, V], bool) {
+	// start from the lower bound if k is beyond it
+	if tm.lo.set {
+		if c := tm.t.cmp(k, tm.lo.key); c < 0 {
+			k, inclusive = tm.lo.key, tm.lo.inclusive
+		} else if c == 0 {
+			inclusive = inclusive && tm.lo.inclusive
+		}
+	}
+	for e := range tm.t.bt.AllGreaterOrEqual(entry[K, V]{key: k}) {
+		if !inclusive && tm.t.cmp(e.key, k) == 0 {
+			continue
+		}
+		if tm.tooHigh(e.key) {
+			break
+		}
+		return e, true
+	}
+	return entry[K, V]{}, false
+}
+
+// inRange returns true if k is within the bounds.
+func (tm *TreeMap[K, V]) inRange(k K) bool {
+	return !tm.tooLow(k) && !tm.tooHigh(k)
+}
+
+// tooLow returns true if k is below the lower bound.
+func (tm *TreeMap[K, V]) tooLow(k K) bool {
+	if !tm.lo.set {
+		return false
+	}
+	c := tm.t.cmp(k, tm.lo.key)
+	return c < 0 || (c == 0 && !tm.lo.inclusive)
+}
+
+// tooHigh returns true if k is above the upper bound.
+func (tm *TreeMap[K, V]) tooHigh(k K) bool {
+	if !tm.hi.set {
+		return false
+	}
+	c := tm.t.cmp(k, tm.hi.key)
+	return c > 0 || (c == 0 && !tm.hi.inclusive)
+}
+
+// tighterLo returns the tighter one of the lower bound b and the current lower bound.
+func (tm *TreeMap[K, V]) tighterLo(b bound[K]) bound[K] {
+	if !tm.lo.set {
+		return b
+	}
+	c := tm.t.cmp(b.key, tm.lo.key)
+	if c > 0 || (c == 0 && !b.inclusive) {
+		return b
+	}
+	return tm.lo
+}
+
+// tighterHi returns the tighter one of the upper bound b and the current upper bound.
+func (tm *TreeMap[K, V]) tighterHi(b bound[K]) bound[K] {
+	if !tm.hi.set {
+		return b
+	}
+	c := tm.t.cmp(b.key, tm.hi.key)
+	if c < 0 || (c == 0 && !b.inclusive) {
+		return b
+	}
+	return tm.hi
+}
+
+func unpack[K, V any](e entry[K, V], ok bool) (K, V, bool) {
+	return e.key, e.value, ok
+}
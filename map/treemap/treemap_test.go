@@ -0,0 +1,194 @@
+// Copyright (c) 2019, Benjamin Wang (benjamin_wang@aliyun.com). All rights reserved.
+// Licensed under the MIT license that can be found in the LICENSE file.
+
+package treemap_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/ahrtr/gocontainer/map/treemap"
+)
+
+func newTreeMap(keys ...int) treemap.Interface {
+	tm := treemap.New()
+	for _, k := range keys {
+		tm.Put(k, k*10)
+	}
+	return tm
+}
+
+func keysOf(tm treemap.Interface) []interface{} {
+	keys := []interface{}{}
+	for k := range tm.All() {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+func TestTreeMapBasic(t *testing.T) {
+	tm := newTreeMap(30, 10, 20)
+
+	if tm.Size() != 3 {
+		t.Errorf("The length isn't expected, expect: 3, actual: %d\n", tm.Size())
+	}
+	if old := tm.Put(20, "twenty"); old != 200 {
+		t.Errorf("The previous value isn't expected, expect: 200, actual: %v\n", old)
+	}
+	if v := tm.Get(20); v != "twenty" {
+		t.Errorf("The value isn't expected, expect: twenty, actual: %v\n", v)
+	}
+	if v := tm.Get(40); v != nil || tm.GetOrDefault(40, -1) != -1 || tm.ContainsKey(40) {
+		t.Errorf("The key 40 shouldn't be contained in the map, value: %v\n", v)
+	}
+	if v, ok := tm.Remove(10); v != 100 || !ok {
+		t.Errorf("Failed to remove the key 10, value: %v, success: %t\n", v, ok)
+	}
+	if keys := keysOf(tm); !slices.Equal(keys, []interface{}{20, 30}) {
+		t.Errorf("The keys aren't expected, expect: [20 30], actual: %v\n", keys)
+	}
+
+	tm.Clear()
+	if !tm.IsEmpty() {
+		t.Errorf("The map should be empty\n")
+	}
+}
+
+func TestTreeMapNavigation(t *testing.T) {
+	tm := newTreeMap(10, 20, 30, 40)
+
+	checkKey := func(name string, k interface{}, ok bool, expected interface{}) {
+		t.Helper()
+		if (expected == nil) == ok || (ok && k != expected) {
+			t.Errorf("%s isn't expected, expect: %v, actual: %v (%t)\n", name, expected, k, ok)
+		}
+	}
+
+	k, _, ok := tm.Floor(25)
+	checkKey("Floor(25)", k, ok, 20)
+	k, _, ok = tm.Floor(20)
+	checkKey("Floor(20)", k, ok, 20)
+	k, _, ok = tm.Floor(5)
+	checkKey("Floor(5)", k, ok, nil)
+	k, _, ok = tm.Ceiling(25)
+	checkKey("Ceiling(25)", k, ok, 30)
+	k, _, ok = tm.Ceiling(45)
+	checkKey("Ceiling(45)", k, ok, nil)
+	k, _, ok = tm.Lower(20)
+	checkKey("Lower(20)", k, ok, 10)
+	k, v, ok := tm.Higher(20)
+	checkKey("Higher(20)", k, ok, 30)
+	if v != 300 {
+		t.Errorf("The value of Higher(20) isn't expected, expect: 300, actual: %v\n", v)
+	}
+
+	k, ok = tm.FirstKey()
+	checkKey("FirstKey", k, ok, 10)
+	k, ok = tm.LastKey()
+	checkKey("LastKey", k, ok, 40)
+
+	k, _, ok = tm.PollFirst()
+	checkKey("PollFirst", k, ok, 10)
+	k, _, ok = tm.PollLast()
+	checkKey("PollLast", k, ok, 40)
+	if tm.Size() != 2 {
+		t.Errorf("The length isn't expected, expect: 2, actual: %d\n", tm.Size())
+	}
+}
+
+func TestTreeMapViews(t *testing.T) {
+	tm := newTreeMap(10, 20, 30, 40, 50)
+
+	head := tm.HeadMap(30, false)
+	if keys := keysOf(head); !slices.Equal(keys, []interface{}{10, 20}) {
+		t.Errorf("The keys of HeadMap aren't expected, expect: [10 20], actual: %v\n", keys)
+	}
+	tail := tm.TailMap(30, true)
+	if keys := keysOf(tail); !slices.Equal(keys, []interface{}{30, 40, 50}) {
+		t.Errorf("The keys of TailMap aren't expected, expect: [30 40 50], actual: %v\n", keys)
+	}
+	sub := tm.SubMap(15, true, 40, true)
+	if keys := keysOf(sub); !slices.Equal(keys, []interface{}{20, 30, 40}) || sub.Size() != 3 {
+		t.Errorf("The keys of SubMap aren't expected, expect: [20 30 40], actual: %v\n", keys)
+	}
+
+	// the views are backed by the map
+	tm.Put(25, 250)
+	sub.Remove(20)
+	if keys := keysOf(sub); !slices.Equal(keys, []interface{}{25, 30, 40}) || tm.ContainsKey(20) {
+		t.Errorf("The keys of SubMap aren't expected, expect: [25 30 40], actual: %v\n", keys)
+	}
+	if sub.ContainsKey(10) || sub.Get(50) != nil {
+		t.Errorf("The keys out of range shouldn't be visible in the view\n")
+	}
+	if k, _, ok := sub.Floor(100); k != 40 || !ok {
+		t.Errorf("Floor(100) of SubMap isn't expected, expect: 40, actual: %v\n", k)
+	}
+	if k, _, ok := sub.Higher(40); ok {
+		t.Errorf("Higher(40) of SubMap should be absent, actual: %v\n", k)
+	}
+	if k, _, ok := sub.PollFirst(); k != 25 || !ok {
+		t.Errorf("PollFirst of SubMap isn't expected, expect: 25, actual: %v\n", k)
+	}
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Errorf("Putting a key out of range should panic\n")
+			}
+		}()
+		sub.Put(50, 500)
+	}()
+
+	sub.Clear()
+	if keys := keysOf(tm); !slices.Equal(keys, []interface{}{10, 50}) {
+		t.Errorf("The keys aren't expected, expect: [10 50], actual: %v\n", keys)
+	}
+}
+
+func TestTreeMapDescendingMap(t *testing.T) {
+	tm := newTreeMap(10, 20, 30, 40)
+	desc := tm.DescendingMap()
+
+	if keys := keysOf(desc); !slices.Equal(keys, []interface{}{40, 30, 20, 10}) {
+		t.Errorf("The keys aren't expected, expect: [40 30 20 10], actual: %v\n", keys)
+	}
+	if k, _ := desc.FirstKey(); k != 40 {
+		t.Errorf("FirstKey isn't expected, expect: 40, actual: %v\n", k)
+	}
+	if k, _, _ := desc.Floor(25); k != 30 {
+		t.Errorf("Floor(25) isn't expected, expect: 30, actual: %v\n", k)
+	}
+	if k, _, _ := desc.Higher(30); k != 20 {
+		t.Errorf("Higher(30) isn't expected, expect: 20, actual: %v\n", k)
+	}
+
+	head := desc.HeadMap(20, false)
+	if keys := keysOf(head); !slices.Equal(keys, []interface{}{40, 30}) {
+		t.Errorf("The keys of HeadMap aren't expected, expect: [40 30], actual: %v\n", keys)
+	}
+	if keys := keysOf(head.DescendingMap()); !slices.Equal(keys, []interface{}{30, 40}) {
+		t.Errorf("The keys aren't expected, expect: [30 40], actual: %v\n", keys)
+	}
+	if k, _, _ := head.PollLast(); k != 30 {
+		t.Errorf("PollLast isn't expected, expect: 30, actual: %v\n", k)
+	}
+}
+
+func TestTreeMapOf(t *testing.T) {
+	tm := treemap.NewOf[string, int](func(a, b string) int { return len(a) - len(b) })
+	tm.Put("ccc", 3)
+	tm.Put("a", 1)
+	tm.Put("bb", 2)
+
+	keys := []string{}
+	for k := range tm.Backward() {
+		keys = append(keys, k)
+	}
+	if !slices.Equal(keys, []string{"ccc", "bb", "a"}) {
+		t.Errorf("The keys aren't expected, expect: [ccc bb a], actual: %v\n", keys)
+	}
+	if k, v, ok := tm.Ceiling("xx"); k != "bb" || v != 2 || !ok {
+		t.Errorf("Ceiling isn't expected, key: %v, value: %v\n", k, v)
+	}
+}
@@ -0,0 +1,299 @@
+// Copyright (c) 2019, Benjamin Wang (benjamin_wang@aliyun.com). All rights reserved.
+// Licensed under the MIT license that can be found in the LICENSE file.
+
+package queue
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"sync"
+	"time"
+
+	"github.com/ahrtr/gocontainer/list"
+)
+
+// ErrClosed is returned when putting an element into a closed blocking queue,
+// or taking an element from a closed blocking queue which is empty.
+var ErrClosed = errors.New("queue: the queue is closed")
+
+// BlockingInterface is a type of bounded FIFO queue, which is safe for concurrent use by multiple goroutines.
+// It additionally supports the operations that wait for the queue to become non-empty when retrieving an element,
+// and wait for space to become available in the queue when storing an element.
+//
+// Add blocks until all the elements are inserted, and it panics if the queue is closed, just like sending on a closed channel.
+// Peek and Poll never block.
+type BlockingInterface interface {
+	Interface
+
+	// Put inserts the specified element into the tail of this queue, waiting if necessary for space to become available.
+	// It returns ErrClosed if the queue is closed, or ctx.Err() if the ctx is done before the element is inserted.
+	Put(ctx context.Context, val interface{}) error
+	// Take retrieves and removes the head of this queue, waiting if necessary until an element becomes available.
+	// It returns ErrClosed if the queue is closed and empty, or ctx.Err() if the ctx is done before an element is available.
+	Take(ctx context.Context) (interface{}, error)
+	// Offer inserts the specified element into the tail of this queue, waiting up to the specified timeout if necessary
+	// for space to become available. It returns true if the element is inserted, or false if the timeout elapses or the queue is closed.
+	// It doesn't wait at all if timeout <= 0.
+	Offer(val interface{}, timeout time.Duration) bool
+	// PollTimeout retrieves and removes the head of this queue, waiting up to the specified timeout if necessary
+	// for an element to become available. It returns (head, true), or (nil, false) if the timeout elapses or the queue is closed and empty.
+	// It doesn't wait at all if timeout <= 0.
+	PollTimeout(timeout time.Duration) (interface{}, bool)
+	// DrainTo removes at most max elements from this queue and adds them to the specified list, and returns the number of elements transferred.
+	// All available elements are transferred if max is negative. It never blocks.
+	DrainTo(l list.Interface, max int) int
+	// Close closes this queue. No element can be inserted into a closed queue, while the remaining elements can still be retrieved.
+	// All the goroutines waiting on the queue are woken up. Closing a closed queue has no effect.
+	Close()
+	// IsClosed returns true if this queue is closed.
+	IsClosed() bool
+	// Capacity returns the capacity of this queue.
+	Capacity() int
+	// RemainingCapacity returns the number of elements that this queue can accept without blocking.
+	RemainingCapacity() int
+}
+
+// BlockingQueue represents a bounded blocking queue, whose elements are of type T.
+type BlockingQueue[T any] struct {
+	mu       sync.Mutex
+	q        *Queue[T]
+	capacity int
+	closed   bool
+	// notEmpty and notFull are closed and replaced to wake up all the waiting goroutines,
+	// when an element is inserted and removed respectively.
+	notEmpty chan struct{}
+	notFull  chan struct{}
+}
+
+// blockingQueue is the blocking queue returned by NewBlocking, it implements the BlockingInterface.
+type blockingQueue struct {
+	*BlockingQueue[interface{}]
+}
+
+// NewBlocking creates a blocking queue with the given capacity. It panics if capacity <= 0.
+func NewBlocking(capacity int) BlockingInterface {
+	return &blockingQueue{NewBlockingOf[interface{}](capacity)}
+}
+
+// NewBlockingOf creates a blocking queue with the given capacity, whose elements are of type T. It panics if capacity <= 0.
+func NewBlockingOf[T any](capacity int) *BlockingQueue[T] {
+	if capacity <= 0 {
+		panic("bad capacity")
+	}
+	return &BlockingQueue[T]{
+		q:        NewOf[T](),
+		capacity: capacity,
+		notEmpty: make(chan struct{}),
+		notFull:  make(chan struct{}),
+	}
+}
+
+func (bq *blockingQueue) DrainTo(l list.Interface, max int) int {
+	return bq.BlockingQueue.DrainTo(l, max)
+}
+
+// Size returns the number of elements in this queue.
+func (bq *BlockingQueue[T]) Size() int {
+	bq.mu.Lock()
+	defer bq.mu.Unlock()
+	return bq.q.Size()
+}
+
+// IsEmpty returns true if this queue contains no elements.
+func (bq *BlockingQueue[T]) IsEmpty() bool {
+	return bq.Size() == 0
+}
+
+// Capacity returns the capacity of this queue.
+func (bq *BlockingQueue[T]) Capacity() int {
+	return bq.capacity
+}
+
+// RemainingCapacity returns the number of elements that this queue can accept without blocking.
+func (bq *BlockingQueue[T]) RemainingCapacity() int {
+	bq.mu.Lock()
+	defer bq.mu.Unlock()
+	return bq.capacity - bq.q.Size()
+}
+
+// Clear removes all the elements from this queue.
+func (bq *BlockingQueue[T]) Clear() {
+	bq.mu.Lock()
+	defer bq.mu.Unlock()
+	if bq.q.Size() > 0 {
+		bq.q.Clear()
+		broadcast(&bq.notFull)
+	}
+}
+
+// Add inserts the elements into the tail of this queue, waiting if necessary for space to become available.
+// It panics if this queue is closed.
+func (bq *BlockingQueue[T]) Add(vals ...T) {
+	for _, v := range vals {
+		if err := bq.Put(context.Background(), v); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// Peek retrieves, but does not remove, the head of this queue, or returns the zero value of T if this queue is empty.
+func (bq *BlockingQueue[T]) Peek() T {
+	bq.mu.Lock()
+	defer bq.mu.Unlock()
+	return bq.q.Peek()
+}
+
+// Poll retrieves and removes the head of this queue, or returns the zero value of T if this queue is empty.
+func (bq *BlockingQueue[T]) Poll() T {
+	bq.mu.Lock()
+	defer bq.mu.Unlock()
+	return bq.poll()
+}
+
+// Put inserts the specified element into the tail of this queue, waiting if necessary for space to become available.
+// It returns ErrClosed if the queue is closed, or ctx.Err() if the ctx is done before the element is inserted.
+func (bq *BlockingQueue[T]) Put(ctx context.Context, val T) error {
+	bq.mu.Lock()
+	for {
+		if bq.closed {
+			bq.mu.Unlock()
+			return ErrClosed
+		}
+		if bq.q.Size() < bq.capacity {
+			bq.q.Add(val)
+			broadcast(&bq.notEmpty)
+			bq.mu.Unlock()
+			return nil
+		}
+
+		if err := bq.wait(ctx, bq.notFull); err != nil {
+			return err
+		}
+	}
+}
+
+// Take retrieves and removes the head of this queue, waiting if necessary until an element becomes available.
+// It returns ErrClosed if the queue is closed and empty, or ctx.Err() if the ctx is done before an element is available.
+func (bq *BlockingQueue[T]) Take(ctx context.Context) (T, error) {
+	var zero T
+	bq.mu.Lock()
+	for {
+		if bq.q.Size() > 0 {
+			val := bq.poll()
+			bq.mu.Unlock()
+			return val, nil
+		}
+		if bq.closed {
+			bq.mu.Unlock()
+			return zero, ErrClosed
+		}
+
+		if err := bq.wait(ctx, bq.notEmpty); err != nil {
+			return zero, err
+		}
+	}
+}
+
+// Offer inserts the specified element into the tail of this queue, waiting up to the specified timeout if necessary
+// for space to become available. It returns true if the element is inserted, or false if the timeout elapses or the queue is closed.
+// It doesn't wait at all if timeout <= 0.
+func (bq *BlockingQueue[T]) Offer(val T, timeout time.Duration) bool {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	return bq.Put(ctx, val) == nil
+}
+
+// PollTimeout retrieves and removes the head of this queue, waiting up to the specified timeout if necessary
+// for an element to become available. It returns (head, true), or (zero value of T, false) if the timeout elapses or the queue is closed and empty.
+// It doesn't wait at all if timeout <= 0.
+func (bq *BlockingQueue[T]) PollTimeout(timeout time.Duration) (T, bool) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	val, err := bq.Take(ctx)
+	return val, err == nil
+}
+
+// DrainTo removes at most max elements from this queue and adds them to l, and returns the number of elements transferred.
+// All available elements are transferred if max is negative. It never blocks.
+func (bq *BlockingQueue[T]) DrainTo(l interface{ Add(vals ...T) }, max int) int {
+	bq.mu.Lock()
+	defer bq.mu.Unlock()
+
+	n := bq.q.Size()
+	if max >= 0 && max < n {
+		n = max
+	}
+	for i := 0; i < n; i++ {
+		l.Add(bq.poll())
+	}
+	return n
+}
+
+// Close closes this queue. No element can be inserted into a closed queue, while the remaining elements can still be retrieved.
+// All the goroutines waiting on the queue are woken up. Closing a closed queue has no effect.
+func (bq *BlockingQueue[T]) Close() {
+	bq.mu.Lock()
+	defer bq.mu.Unlock()
+	if !bq.closed {
+		bq.closed = true
+		broadcast(&bq.notEmpty)
+		broadcast(&bq.notFull)
+	}
+}
+
+// IsClosed returns true if this queue is closed.
+func (bq *BlockingQueue[T]) IsClosed() bool {
+	bq.mu.Lock()
+	defer bq.mu.Unlock()
+	return bq.closed
+}
+
+// All returns an iterator over the elements in this queue, from the head to the tail.
+// It ranges over a snapshot of the queue taken when the iteration starts, so it's safe to modify the queue during the iteration.
+func (bq *BlockingQueue[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		bq.mu.Lock()
+		vals := make([]T, 0, bq.q.Size())
+		for v := range bq.q.All() {
+			vals = append(vals, v)
+		}
+		bq.mu.Unlock()
+
+		for _, v := range vals {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// poll removes the head of the queue, and wakes up the goroutines waiting for space. It must be called with bq.mu held.
+func (bq *BlockingQueue[T]) poll() T {
+	if bq.q.Size() == 0 {
+		var zero T
+		return zero
+	}
+	val := bq.q.Poll()
+	broadcast(&bq.notFull)
+	return val
+}
+
+// wait releases bq.mu and waits until ch is closed or the ctx is done. It reacquires bq.mu
+// and returns nil in the former case, otherwise returns ctx.Err() without holding bq.mu.
+func (bq *BlockingQueue[T]) wait(ctx context.Context, ch chan struct{}) error {
+	bq.mu.Unlock()
+	select {
+	case <-ch:
+		bq.mu.Lock()
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// broadcast wakes up all the goroutines waiting on *ch, and replaces it with a new channel.
+func broadcast(ch *chan struct{}) {
+	close(*ch)
+	*ch = make(chan struct{})
+}
@@ -0,0 +1,142 @@
+// Copyright (c) 2019, Benjamin Wang (benjamin_wang@aliyun.com). All rights reserved.
+// Licensed under the MIT license that can be found in the LICENSE file.
+
+package queue_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ahrtr/gocontainer/list"
+	"github.com/ahrtr/gocontainer/queue"
+)
+
+func TestBlockingQueueOfferPoll(t *testing.T) {
+	q := queue.NewBlocking(2)
+
+	if !q.Offer(5, 0) || !q.Offer(6, 0) {
+		t.Errorf("Failed to offer elements to a queue which isn't full\n")
+	}
+	if q.Offer(7, 10*time.Millisecond) {
+		t.Errorf("Offered an element to a full queue\n")
+	}
+	if q.RemainingCapacity() != 0 || q.Size() != 2 {
+		t.Errorf("Unexpected result, remaining capacity: %d, length: %d\n", q.RemainingCapacity(), q.Size())
+	}
+
+	if v, ok := q.PollTimeout(0); !ok || v != 5 {
+		t.Errorf("The value polled isn't expected, expect: 5, actual: %v\n", v)
+	}
+	if v := q.Poll(); v != 6 {
+		t.Errorf("The value polled isn't expected, expect: 6, actual: %v\n", v)
+	}
+	if v, ok := q.PollTimeout(10 * time.Millisecond); ok || v != nil {
+		t.Errorf("Polled a value from an empty queue: %v\n", v)
+	}
+}
+
+func TestBlockingQueuePutTake(t *testing.T) {
+	q := queue.NewBlockingOf[int](3)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				if err := q.Put(context.Background(), i*100+j); err != nil {
+					t.Errorf("Failed to put an element, error: %v\n", err)
+				}
+			}
+		}(i)
+	}
+
+	seen := make(map[int]bool)
+	for i := 0; i < 400; i++ {
+		v, err := q.Take(context.Background())
+		if err != nil {
+			t.Fatalf("Failed to take an element, error: %v\n", err)
+		}
+		if q.Size() > q.Capacity() {
+			t.Errorf("The length exceeds the capacity: %d\n", q.Size())
+		}
+		seen[v] = true
+	}
+	wg.Wait()
+
+	if len(seen) != 400 || !q.IsEmpty() {
+		t.Errorf("Unexpected result, taken: %d, length: %d\n", len(seen), q.Size())
+	}
+}
+
+func TestBlockingQueueContext(t *testing.T) {
+	q := queue.NewBlocking(1)
+	q.Add(5)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+	}()
+	if err := q.Put(ctx, 6); !errors.Is(err, context.Canceled) {
+		t.Errorf("The error isn't expected, expect: %v, actual: %v\n", context.Canceled, err)
+	}
+
+	q.Poll()
+	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := q.Take(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("The error isn't expected, expect: %v, actual: %v\n", context.DeadlineExceeded, err)
+	}
+}
+
+func TestBlockingQueueClose(t *testing.T) {
+	q := queue.NewBlocking(2)
+	q.Add(5)
+
+	done := make(chan error)
+	go func() {
+		q.Add(6)
+		done <- q.Put(context.Background(), 7)
+	}()
+	time.Sleep(10 * time.Millisecond)
+	q.Close()
+
+	if err := <-done; !errors.Is(err, queue.ErrClosed) {
+		t.Errorf("The error isn't expected, expect: %v, actual: %v\n", queue.ErrClosed, err)
+	}
+	if q.Offer(8, 0) {
+		t.Errorf("Offered an element to a closed queue\n")
+	}
+
+	// the remaining elements can still be retrieved after the queue is closed
+	for _, expected := range []int{5, 6} {
+		if v, err := q.Take(context.Background()); err != nil || v != expected {
+			t.Errorf("The value taken isn't expected, expect: %d, actual: %v, error: %v\n", expected, v, err)
+		}
+	}
+	if _, err := q.Take(context.Background()); !errors.Is(err, queue.ErrClosed) {
+		t.Errorf("The error isn't expected, expect: %v, actual: %v\n", queue.ErrClosed, err)
+	}
+}
+
+func TestBlockingQueueDrainTo(t *testing.T) {
+	q := queue.NewBlocking(5)
+	q.Add(1, 2, 3, 4, 5)
+
+	l := list.NewArrayList()
+	if n := q.DrainTo(l, 2); n != 2 || l.Size() != 2 {
+		t.Errorf("Unexpected result, transferred: %d, list length: %d\n", n, l.Size())
+	}
+	if n := q.DrainTo(l, -1); n != 3 || l.Size() != 5 || !q.IsEmpty() {
+		t.Errorf("Unexpected result, transferred: %d, list length: %d, queue length: %d\n", n, l.Size(), q.Size())
+	}
+	for i := 0; i < 5; i++ {
+		if v, _ := l.Get(i); v != i+1 {
+			t.Errorf("The value isn't expected, expect: %d, actual: %v\n", i+1, v)
+		}
+	}
+}
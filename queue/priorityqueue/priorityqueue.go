@@ -3,9 +3,18 @@
 
 // Package priorityqueue implements an unbounded priority queue based on a priority heap.
 // The elements of the priority queue are ordered according to their natural ordering, or by a Comparator provided at PriorityQueue construction time.
+// New creates a priority queue of interface{} values, while NewOf creates the type-parameterized PriorityQueue[T].
+//
+// An element added by AddWithHandle can be updated or removed in O(log n) using the returned handle,
+// which is useful for algorithms like Dijkstra's shortest path:
+//	h := pq.AddWithHandle(v)
+//	pq.Update(h, newV) // re-establishes the ordering after the element's priority changes
+//	pq.RemoveHandle(h)
 package priorityqueue
 
 import (
+	"iter"
+
 	"github.com/ahrtr/gocontainer/queue"
 	"github.com/ahrtr/gocontainer/utils"
 )
@@ -21,126 +30,296 @@ type Interface interface {
 	// If not configured, then it's min-heap by default.
 	WithMinHeap(isMinHeap bool) Interface
 
-	// Contains returns true if this queue contains the specified element.
+	// Contains returns true if this queue contains the specified element. The elements are compared with ==,
+	// which panics if they have identical dynamic types which aren't comparable; use ContainsFunc instead.
 	Contains(val interface{}) bool
+	// ContainsFunc returns true if this queue contains an element satisfying pred.
+	ContainsFunc(pred func(val interface{}) bool) bool
 	// Remove a single instance of the specified element from this queue, if it is present.
 	// It returns false if the target value isn't present, otherwise returns true.
+	// It panics on the non-comparable elements as Contains does; use RemoveFunc instead.
 	Remove(val interface{}) bool
+	// RemoveFunc removes a single element satisfying pred from this queue, if any.
+	// It returns false if no element satisfies pred, otherwise returns true.
+	RemoveFunc(pred func(val interface{}) bool) bool
+
+	// AddWithHandle inserts the specified element into this queue, and returns a handle of the element,
+	// which can be used to update or remove the element in O(log n).
+	AddWithHandle(val interface{}) *Handle
+	// Update replaces the element referenced by the handle with the specified value, and re-establishes the ordering.
+	// It returns false if the element isn't in this queue anymore.
+	Update(h *Handle, val interface{}) bool
+	// RemoveHandle removes the element referenced by the handle from this queue.
+	// It returns false if the element isn't in this queue anymore.
+	RemoveHandle(h *Handle) bool
+	// ContainsHandle returns true if the element referenced by the handle is still in this queue.
+	ContainsHandle(h *Handle) bool
 }
 
-// priorityQueue represents an unbounded priority queue based on a priority heap.
-// It implements heap.Interface.
-type priorityQueue struct {
-	items     []interface{}
-	cmp       utils.Comparator
+// Handle references an element added into a priority queue by AddWithHandle. It stays valid until
+// the element is removed from the queue, no matter how the element is moved inside the heap.
+type Handle struct {
+	// index is the index of the element in the heap, or -1 if the element was removed.
+	index int
+	pq    interface{}
+}
+
+// PriorityQueue represents an unbounded priority queue based on a priority heap, whose elements are of type T.
+type PriorityQueue[T any] struct {
+	items     []T
+	cmp       func(v1, v2 T) int
 	isMinHeap bool
+	// handles is parallel to items, it's nil until the first handle is created.
+	// The elements which were added without a handle have nil entries.
+	handles []*Handle
+}
+
+// priorityQueue is the priority queue returned by New, it implements the Interface.
+type priorityQueue struct {
+	*PriorityQueue[interface{}]
 }
 
 // New initializes and returns an priorityQueue.
 func New() Interface {
-	return &priorityQueue{
-		items:     []interface{}{},
-		cmp:       nil,
+	return &priorityQueue{NewOf[interface{}]()}
+}
+
+// NewOf initializes and returns a PriorityQueue, whose elements are of type T.
+// The elements are ordered according to their natural ordering until a comparison function is provided by WithComparator.
+func NewOf[T any]() *PriorityQueue[T] {
+	return &PriorityQueue[T]{
+		items:     []T{},
+		cmp:       utils.CompareFunc[T](nil),
 		isMinHeap: true,
 	}
 }
 
 func (pq *priorityQueue) WithComparator(c utils.Comparator) Interface {
-	pq.cmp = c
+	pq.PriorityQueue.WithComparator(utils.CompareFunc[interface{}](c))
 	return pq
 }
 
 func (pq *priorityQueue) WithMinHeap(isMinHeap bool) Interface {
+	pq.PriorityQueue.WithMinHeap(isMinHeap)
+	return pq
+}
+
+// WithComparator sets the comparison function for the queue, which is used to impose a total ordering on the elements in the queue.
+// The elements are ordered according to their natural ordering if cmp is nil.
+func (pq *PriorityQueue[T]) WithComparator(cmp func(v1, v2 T) int) *PriorityQueue[T] {
+	if cmp == nil {
+		cmp = utils.CompareFunc[T](nil)
+	}
+	pq.cmp = cmp
+	return pq
+}
+
+// WithMinHeap configures whether or not using min-heap.
+// If not configured, then it's min-heap by default.
+func (pq *PriorityQueue[T]) WithMinHeap(isMinHeap bool) *PriorityQueue[T] {
 	pq.isMinHeap = isMinHeap
 	return pq
 }
 
 // Size returns the length of this priority queue.
-func (pq *priorityQueue) Size() int { return len(pq.items) }
+func (pq *PriorityQueue[T]) Size() int { return len(pq.items) }
 
 // IsEmpty returns true if this list contains no elements.
-func (pq *priorityQueue) IsEmpty() bool {
+func (pq *PriorityQueue[T]) IsEmpty() bool {
 	return pq.Size() == 0
 }
 
 // Clear removes all of the elements from this priority queue.
-func (pq *priorityQueue) Clear() {
+func (pq *PriorityQueue[T]) Clear() {
+	var zero T
 	size := pq.Size()
 	for i := 0; i < size; i++ {
-		pq.items[i] = nil
+		pq.items[i] = zero
+	}
+	pq.items = []T{}
+
+	for _, h := range pq.handles {
+		if h != nil {
+			h.index = -1
+		}
 	}
-	pq.items = []interface{}{}
+	pq.handles = nil
 }
 
 // Add inserts the specified element into this priority queue.
-func (pq *priorityQueue) Add(vals ...interface{}) {
+func (pq *PriorityQueue[T]) Add(vals ...T) {
 	for _, v := range vals {
 		pq.push(v)
-		utils.HeapPostPush(pq.items, pq.isMinHeap, pq.cmp)
+		utils.HeapPostPushIndexed(pq.items, pq.isMinHeap, pq.cmp, pq.swapFunc())
 	}
 }
 
-// Peek retrieves, but does not remove, the head of this queue, or return nil if this queue is empty.
-func (pq *priorityQueue) Peek() interface{} {
+// Peek retrieves, but does not remove, the head of this queue, or returns the zero value of T if this queue is empty.
+func (pq *PriorityQueue[T]) Peek() T {
 	if pq.Size() > 0 {
 		return pq.items[0]
 	}
-	return nil
+	var zero T
+	return zero
 }
 
-// Poll retrieves and removes the head of the this queue, or return nil if this queue is empty.
-func (pq *priorityQueue) Poll() interface{} {
+// Poll retrieves and removes the head of the this queue, or returns the zero value of T if this queue is empty.
+func (pq *PriorityQueue[T]) Poll() T {
 	if pq.Size() > 0 {
-		utils.HeapPrePop(pq.items, pq.isMinHeap, pq.cmp)
+		utils.HeapPrePopIndexed(pq.items, pq.isMinHeap, pq.cmp, pq.swapFunc())
 		return pq.pop()
 	}
-	return nil
+	var zero T
+	return zero
 }
 
 func (pq *priorityQueue) Contains(val interface{}) bool {
-	return pq.indexOf(val) >= 0
+	return nil != val && pq.ContainsFunc(func(v interface{}) bool { return v == val })
 }
 
 func (pq *priorityQueue) Remove(val interface{}) bool {
+	return nil != val && pq.RemoveFunc(func(v interface{}) bool { return v == val })
+}
+
+// Contains returns true if the queue contains the specified element. Unlike the Contains method of the queues
+// created by New, it only accepts the queues whose elements are comparable, which is checked at compile time,
+// otherwise the elements are looked up by ContainsFunc.
+func Contains[T comparable](pq *PriorityQueue[T], val T) bool {
+	return pq.ContainsFunc(func(v T) bool { return v == val })
+}
+
+// Remove removes a single instance of the specified element from the queue, if it is present. It returns false
+// if the target value isn't present, otherwise returns true. The elements must be comparable, see Contains.
+func Remove[T comparable](pq *PriorityQueue[T], val T) bool {
+	return pq.RemoveFunc(func(v T) bool { return v == val })
+}
+
+// ContainsFunc returns true if this queue contains an element satisfying pred.
+func (pq *PriorityQueue[T]) ContainsFunc(pred func(val T) bool) bool {
+	return pq.indexFunc(pred) >= 0
+}
+
+// RemoveFunc removes a single element satisfying pred from this queue, if any.
+// It returns false if no element satisfies pred, otherwise returns true.
+func (pq *PriorityQueue[T]) RemoveFunc(pred func(val T) bool) bool {
 	if pq.Size() == 0 {
 		return false
 	}
 
-	i := pq.indexOf(val)
+	i := pq.indexFunc(pred)
 	if i < 0 {
 		return false
 	}
 
-	utils.HeapPreRemove(pq.items, i, pq.isMinHeap, pq.cmp)
+	utils.HeapPreRemoveIndexed(pq.items, i, pq.isMinHeap, pq.cmp, pq.swapFunc())
 	pq.pop()
 
 	return true
 }
 
+// AddWithHandle inserts the specified element into this queue, and returns a handle of the element,
+// which can be used to update or remove the element in O(log n).
+func (pq *PriorityQueue[T]) AddWithHandle(val T) *Handle {
+	if pq.handles == nil {
+		pq.handles = make([]*Handle, len(pq.items), cap(pq.items))
+	}
+	h := &Handle{index: len(pq.items), pq: pq}
+	pq.push(val)
+	pq.handles[h.index] = h
+	utils.HeapPostPushIndexed(pq.items, pq.isMinHeap, pq.cmp, pq.swapFunc())
+	return h
+}
+
+// Update replaces the element referenced by the handle with the specified value, and re-establishes the ordering.
+// It returns false if the element isn't in this queue anymore.
+func (pq *PriorityQueue[T]) Update(h *Handle, val T) bool {
+	if !pq.ContainsHandle(h) {
+		return false
+	}
+	pq.items[h.index] = val
+	utils.HeapPostUpdateIndexed(pq.items, h.index, pq.isMinHeap, pq.cmp, pq.swapFunc())
+	return true
+}
+
+// RemoveHandle removes the element referenced by the handle from this queue.
+// It returns false if the element isn't in this queue anymore.
+func (pq *PriorityQueue[T]) RemoveHandle(h *Handle) bool {
+	if !pq.ContainsHandle(h) {
+		return false
+	}
+	utils.HeapPreRemoveIndexed(pq.items, h.index, pq.isMinHeap, pq.cmp, pq.swapFunc())
+	pq.pop()
+	return true
+}
+
+// ContainsHandle returns true if the element referenced by the handle is still in this queue.
+func (pq *PriorityQueue[T]) ContainsHandle(h *Handle) bool {
+	return h != nil && h.pq == pq && h.index >= 0
+}
+
+// All returns an iterator over the elements in this queue. The elements are yielded in the heap's internal
+// order, which is not the priority order except for the first one. Poll the queue to retrieve the elements in priority order.
+func (pq *PriorityQueue[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, v := range pq.items {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
 // push appends the provided value to the end.
-func (pq *priorityQueue) push(val interface{}) {
+func (pq *PriorityQueue[T]) push(val T) {
 	pq.items = append(pq.items, val)
+	if pq.handles != nil {
+		pq.handles = append(pq.handles, nil)
+	}
 }
 
 // pop removes and returns the last element.
-func (pq *priorityQueue) pop() interface{} {
+func (pq *PriorityQueue[T]) pop() T {
+	var zero T
 	size := pq.Size()
 
 	if size > 0 {
 		val := pq.items[size-1]
+		pq.items[size-1] = zero
 		pq.items = pq.items[:(size - 1)]
+		if pq.handles != nil {
+			if h := pq.handles[size-1]; h != nil {
+				h.index = -1
+			}
+			pq.handles[size-1] = nil
+			pq.handles = pq.handles[:(size - 1)]
+		}
 		return val
 	}
-	return nil
+	return zero
 }
 
-func (pq *priorityQueue) indexOf(val interface{}) int {
+// swapFunc returns the function which keeps the handles in sync with the elements when the heap swaps two elements,
+// or nil if there is no handle.
+func (pq *PriorityQueue[T]) swapFunc() func(i, j int) {
+	if pq.handles == nil {
+		return nil
+	}
+	return func(i, j int) {
+		pq.handles[i], pq.handles[j] = pq.handles[j], pq.handles[i]
+		if pq.handles[i] != nil {
+			pq.handles[i].index = i
+		}
+		if pq.handles[j] != nil {
+			pq.handles[j].index = j
+		}
+	}
+}
+
+func (pq *PriorityQueue[T]) indexFunc(pred func(val T) bool) int {
 	size := pq.Size()
-	if nil != val {
-		for i := 0; i < size; i++ {
-			if val == pq.items[i] {
-				return i
-			}
+	for i := 0; i < size; i++ {
+		if pred(pq.items[i]) {
+			return i
 		}
 	}
 	return -1
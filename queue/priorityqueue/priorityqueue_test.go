@@ -5,6 +5,7 @@ package priorityqueue_test
 
 import (
 	"reflect"
+	"slices"
 	"testing"
 
 	"github.com/ahrtr/gocontainer/queue/priorityqueue"
@@ -293,3 +294,141 @@ func (s *student) Compare(v1, v2 interface{}) (int, error) {
 	}
 	return 0, nil
 }
+
+func TestPriorityQueueOf(t *testing.T) {
+	type task struct {
+		name     string
+		priority int
+	}
+
+	pq := priorityqueue.NewOf[task]().WithComparator(func(v1, v2 task) int {
+		return v1.priority - v2.priority
+	}).WithMinHeap(false)
+	pq.Add(task{"a", 3}, task{"b", 9}, task{"c", 1}, task{"d", 5})
+
+	if !priorityqueue.Contains(pq, task{"d", 5}) || priorityqueue.Contains(pq, task{"d", 6}) {
+		t.Error("Unexpected result of Contains")
+	}
+	if !priorityqueue.Remove(pq, task{"a", 3}) || pq.ContainsFunc(func(v task) bool { return v.name == "a" }) {
+		t.Error("Failed to remove the task a")
+	}
+	pq.Add(task{"a", 3})
+
+	expected := []string{"b", "d", "a", "c"}
+	for _, e := range expected {
+		if v := pq.Poll(); v.name != e {
+			t.Errorf("The value polled isn't expected, expect: %s, actual: %s\n", e, v.name)
+		}
+	}
+	if !pq.IsEmpty() {
+		t.Error("The queue should be empty")
+	}
+}
+
+func TestPriorityQueueOfNonComparable(t *testing.T) {
+	// The slices aren't comparable, so they can only be looked up by a predicate.
+	pq := priorityqueue.NewOf[[]int]().WithComparator(func(v1, v2 []int) int { return v1[0] - v2[0] })
+	pq.Add([]int{3}, []int{1}, []int{2})
+	is := func(n int) func(v []int) bool {
+		return func(v []int) bool { return v[0] == n }
+	}
+
+	if !pq.ContainsFunc(is(2)) || pq.ContainsFunc(is(4)) {
+		t.Error("Unexpected result of ContainsFunc")
+	}
+	if !pq.RemoveFunc(is(1)) || pq.RemoveFunc(is(1)) {
+		t.Error("Unexpected result of RemoveFunc")
+	}
+	if v := pq.Poll(); v[0] != 2 {
+		t.Errorf("The value polled isn't expected, expect: 2, actual: %d\n", v[0])
+	}
+}
+
+func TestPriorityQueueAll(t *testing.T) {
+	pq := priorityqueue.NewOf[int]()
+	pq.Add(15, 19, 12, 8, 13)
+
+	got := slices.Sorted(pq.All())
+	if !slices.Equal(got, []int{8, 12, 13, 15, 19}) {
+		t.Errorf("The values aren't expected, expect: [8 12 13 15 19], actual: %v\n", got)
+	}
+}
+
+func TestPriorityQueueHandle(t *testing.T) {
+	pq := priorityqueue.NewOf[int]()
+	pq.Add(50, 40)
+
+	handles := make(map[int]*priorityqueue.Handle)
+	for _, v := range []int{30, 10, 20, 60} {
+		handles[v] = pq.AddWithHandle(v)
+	}
+	pq.Add(35)
+
+	// decrease the key of 60 to 5, and increase the key of 10 to 45
+	if !pq.Update(handles[60], 5) || !pq.Update(handles[10], 45) {
+		t.Errorf("Failed to update the elements\n")
+	}
+	if !pq.RemoveHandle(handles[20]) || pq.ContainsHandle(handles[20]) || pq.RemoveHandle(handles[20]) {
+		t.Errorf("Failed to remove the element by handle\n")
+	}
+	if !priorityqueue.Remove(pq, 35) {
+		t.Errorf("Failed to remove the element 35\n")
+	}
+
+	expected := []int{5, 30, 40, 45, 50}
+	for _, e := range expected {
+		if v := pq.Poll(); v != e {
+			t.Errorf("The value polled isn't expected, expect: %d, actual: %d\n", e, v)
+		}
+	}
+	for v, h := range handles {
+		if pq.ContainsHandle(h) || pq.Update(h, v) {
+			t.Errorf("The handle of %d should be invalid after the element is polled\n", v)
+		}
+	}
+
+	// a handle of another queue is never valid
+	other := priorityqueue.NewOf[int]()
+	h := other.AddWithHandle(1)
+	if pq.ContainsHandle(h) || pq.RemoveHandle(h) || !other.ContainsHandle(h) {
+		t.Errorf("The handle should only be valid for the queue which created it\n")
+	}
+	other.Clear()
+	if other.ContainsHandle(h) {
+		t.Errorf("The handle should be invalid after the queue is cleared\n")
+	}
+}
+
+func TestPriorityQueueHandleRandom(t *testing.T) {
+	pq := priorityqueue.New().WithMinHeap(false)
+	values := make(map[*priorityqueue.Handle]int)
+	for i := 0; i < 200; i++ {
+		v := (i * 7919) % 1000
+		values[pq.AddWithHandle(v)] = v
+	}
+	i := 0
+	for h := range values {
+		if i%3 == 0 {
+			values[h] = (i * 104729) % 1000
+			pq.Update(h, values[h])
+		} else if i%5 == 0 {
+			pq.RemoveHandle(h)
+			delete(values, h)
+		}
+		i++
+	}
+
+	prev := 1000
+	for !pq.IsEmpty() {
+		v := pq.Poll().(int)
+		if v > prev {
+			t.Fatalf("The values aren't polled in descending order, %d > %d\n", v, prev)
+		}
+		prev = v
+	}
+	for h := range values {
+		if pq.ContainsHandle(h) {
+			t.Fatalf("The handle should be invalid after the element is polled\n")
+		}
+	}
+}
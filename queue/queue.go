@@ -2,9 +2,13 @@
 // Licensed under the MIT license that can be found in the LICENSE file.
 
 // Package queue implements a queue, which orders elements in a FIFO (first-in-first-out) manner.
+// New creates a queue of interface{} values, while NewOf creates the type-parameterized Queue[T].
+// NewBlocking creates a bounded blocking queue, which is safe for concurrent use by multiple goroutines.
 package queue
 
 import (
+	"iter"
+
 	"github.com/ahrtr/gocontainer/collection"
 )
 
@@ -18,43 +22,51 @@ type Interface interface {
 	Peek() interface{}
 	// Poll retrieves and removes the head of the this queue, or return nil if this queue is empty.
 	Poll() interface{}
+	// All returns an iterator over the elements in this queue, from the head to the tail.
+	All() iter.Seq[interface{}]
 }
 
 // element is an element of the queue.
-type element struct {
-	next  *element
-	value interface{}
+type element[T any] struct {
+	next  *element[T]
+	value T
 }
 
-// queue represents a singly linked list.
-type queue struct {
-	head   *element
-	tail   *element
+// Queue represents a singly linked list, whose elements are of type T.
+// Queue[interface{}] implements the Interface.
+type Queue[T any] struct {
+	head   *element[T]
+	tail   *element[T]
 	length int
 }
 
 // New creates a queue.
 func New() Interface {
-	return &queue{
+	return NewOf[interface{}]()
+}
+
+// NewOf creates a queue, whose elements are of type T.
+func NewOf[T any]() *Queue[T] {
+	return &Queue[T]{
 		head:   nil,
 		tail:   nil,
 		length: 0,
 	}
 }
 
-func (q *queue) Size() int {
+func (q *Queue[T]) Size() int {
 	return q.length
 }
 
 // IsEmpty returns true if this queue contains no elements.
-func (q *queue) IsEmpty() bool {
+func (q *Queue[T]) IsEmpty() bool {
 	return q.Size() == 0
 }
 
-// Add (todo): add a capacity for the queue, and return an error when this queue is full.
-func (q *queue) Add(vals ...interface{}) {
+// Add inserts the elements into the tail of this queue. The queue is unbounded, use NewBlocking for a bounded queue.
+func (q *Queue[T]) Add(vals ...T) {
 	for _, v := range vals {
-		e := element{
+		e := element[T]{
 			next:  nil,
 			value: v,
 		}
@@ -70,14 +82,17 @@ func (q *queue) Add(vals ...interface{}) {
 	}
 }
 
-func (q *queue) Peek() interface{} {
+// Peek retrieves, but does not remove, the head of this queue, or returns the zero value of T if this queue is empty.
+func (q *Queue[T]) Peek() T {
 	if q.head != nil {
 		return q.head.value
 	}
-	return nil
+	var zero T
+	return zero
 }
 
-func (q *queue) Poll() interface{} {
+// Poll retrieves and removes the head of this queue, or returns the zero value of T if this queue is empty.
+func (q *Queue[T]) Poll() T {
 	if q.head != nil {
 		val := q.head.value
 
@@ -90,15 +105,28 @@ func (q *queue) Poll() interface{} {
 		return val
 	}
 
-	return nil
+	var zero T
+	return zero
 }
 
 // Clear removes all the elements from this queue.
-func (q *queue) Clear() {
+func (q *Queue[T]) Clear() {
+	var zero T
 	for e := q.head; e != nil; {
 		next := e.next
-		e.next, e.value = nil, nil
+		e.next, e.value = nil, zero
 		e = next
 	}
 	q.head, q.tail, q.length = nil, nil, 0
 }
+
+// All returns an iterator over the elements in this queue, from the head to the tail.
+func (q *Queue[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for e := q.head; e != nil; e = e.next {
+			if !yield(e.value) {
+				return
+			}
+		}
+	}
+}
@@ -93,3 +93,38 @@ func TestQueueClear(t *testing.T) {
 		t.Errorf("The length isn't expected, expect: 0, actual: %d\n", q.Size())
 	}
 }
+
+func TestQueueOf(t *testing.T) {
+	q := queue.NewOf[string]()
+
+	q.Add("benjamin", "alice")
+	if v := q.Peek(); v != "benjamin" {
+		t.Errorf("The value peeked from queue isn't expected, expect: benjamin, actual: %s\n", v)
+	}
+	if v := q.Poll(); v != "benjamin" {
+		t.Errorf("The value polled from queue isn't expected, expect: benjamin, actual: %s\n", v)
+	}
+	if v := q.Poll(); v != "alice" {
+		t.Errorf("The value polled from queue isn't expected, expect: alice, actual: %s\n", v)
+	}
+	if v := q.Poll(); v != "" {
+		t.Errorf("The zero value is expected for an empty queue, actual: %s\n", v)
+	}
+}
+
+func TestQueueAll(t *testing.T) {
+	q := queue.New()
+	q.Add(5, 6, 7)
+
+	expected := []interface{}{5, 6, 7}
+	i := 0
+	for v := range q.All() {
+		if v != expected[i] {
+			t.Errorf("The value isn't expected, index: %d, expect: %v, actual: %v\n", i, expected[i], v)
+		}
+		i++
+	}
+	if q.Size() != 3 {
+		t.Errorf("All shouldn't remove any element, expected length: 3, actual: %d\n", q.Size())
+	}
+}
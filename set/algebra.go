@@ -0,0 +1,295 @@
+// Copyright (c) 2019, Benjamin Wang (benjamin_wang@aliyun.com). All rights reserved.
+// Licensed under the MIT license that can be found in the LICENSE file.
+
+package set
+
+import (
+	"iter"
+	"maps"
+)
+
+// readable is the read-only part of a set, both *Set[T] and Interface (where T is interface{}) implement it.
+// The set algebra is implemented on it, so that it works with any implementation of Interface.
+type readable[T comparable] interface {
+	Size() int
+	Contains(val T) bool
+	All() iter.Seq[T]
+}
+
+func (s *set) Clone() Interface {
+	return &set{s.Set.Clone()}
+}
+
+func (s *set) Union(other Interface) Interface {
+	return &set{union[interface{}](s.Set, other)}
+}
+
+func (s *set) Intersection(other Interface) Interface {
+	return &set{intersection[interface{}](s.Set, other)}
+}
+
+func (s *set) Difference(other Interface) Interface {
+	return &set{difference[interface{}](s.Set, other)}
+}
+
+func (s *set) SymmetricDifference(other Interface) Interface {
+	return &set{symmetricDifference[interface{}](s.Set, other)}
+}
+
+func (s *set) UnionWith(other Interface) {
+	s.Set.unionWith(other)
+}
+
+func (s *set) IntersectWith(other Interface) {
+	s.Set.intersectWith(other)
+}
+
+func (s *set) DifferenceWith(other Interface) {
+	s.Set.differenceWith(other)
+}
+
+func (s *set) SymmetricDifferenceWith(other Interface) {
+	s.Set.symmetricDifferenceWith(other)
+}
+
+func (s *set) IsSubsetOf(other Interface) bool {
+	return isSubset[interface{}](s.Set, other)
+}
+
+func (s *set) IsSupersetOf(other Interface) bool {
+	return isSubset[interface{}](other, s.Set)
+}
+
+func (s *set) IsDisjoint(other Interface) bool {
+	return isDisjoint[interface{}](s.Set, other)
+}
+
+func (s *set) Equal(other Interface) bool {
+	return s.Size() == other.Size() && isSubset[interface{}](s.Set, other)
+}
+
+// ToSlice returns a slice containing all the elements in this set. The order is not specified.
+func (s *Set[T]) ToSlice() []T {
+	vals := make([]T, 0, len(s.items))
+	for k := range s.items {
+		vals = append(vals, k)
+	}
+	return vals
+}
+
+// Clone returns a shallow copy of this set.
+func (s *Set[T]) Clone() *Set[T] {
+	return &Set[T]{items: maps.Clone(s.items)}
+}
+
+// Pop removes and returns an arbitrary element from this set.
+// It returns (element, true), or (zero value of T, false) if this set is empty.
+func (s *Set[T]) Pop() (T, bool) {
+	for k := range s.items {
+		delete(s.items, k)
+		return k, true
+	}
+	var zero T
+	return zero, false
+}
+
+// RemoveIf removes all the elements which satisfy the given predicate, and returns the number of elements removed.
+func (s *Set[T]) RemoveIf(f func(T) bool) int {
+	n := 0
+	for k := range s.items {
+		if f(k) {
+			delete(s.items, k)
+			n++
+		}
+	}
+	return n
+}
+
+// Union returns a new set containing the elements in either this set or the other set.
+func (s *Set[T]) Union(other *Set[T]) *Set[T] {
+	return union[T](s, other)
+}
+
+// Intersection returns a new set containing the elements in both this set and the other set.
+func (s *Set[T]) Intersection(other *Set[T]) *Set[T] {
+	return intersection[T](s, other)
+}
+
+// Difference returns a new set containing the elements in this set but not in the other set.
+func (s *Set[T]) Difference(other *Set[T]) *Set[T] {
+	return difference[T](s, other)
+}
+
+// SymmetricDifference returns a new set containing the elements in either this set or the other set, but not in both.
+func (s *Set[T]) SymmetricDifference(other *Set[T]) *Set[T] {
+	return symmetricDifference[T](s, other)
+}
+
+// UnionWith adds all the elements in the other set to this set.
+func (s *Set[T]) UnionWith(other *Set[T]) {
+	s.unionWith(other)
+}
+
+// IntersectWith removes the elements which aren't in the other set from this set.
+func (s *Set[T]) IntersectWith(other *Set[T]) {
+	s.intersectWith(other)
+}
+
+// DifferenceWith removes the elements which are in the other set from this set.
+func (s *Set[T]) DifferenceWith(other *Set[T]) {
+	s.differenceWith(other)
+}
+
+// SymmetricDifferenceWith modifies this set to contain the elements in either this set or the other set, but not in both.
+func (s *Set[T]) SymmetricDifferenceWith(other *Set[T]) {
+	s.symmetricDifferenceWith(other)
+}
+
+// IsSubsetOf returns true if every element in this set is also in the other set.
+func (s *Set[T]) IsSubsetOf(other *Set[T]) bool {
+	return isSubset[T](s, other)
+}
+
+// IsSupersetOf returns true if every element in the other set is also in this set.
+func (s *Set[T]) IsSupersetOf(other *Set[T]) bool {
+	return isSubset[T](other, s)
+}
+
+// IsDisjoint returns true if this set and the other set have no elements in common.
+func (s *Set[T]) IsDisjoint(other *Set[T]) bool {
+	return isDisjoint[T](s, other)
+}
+
+// Equal returns true if this set and the other set contain the same elements.
+func (s *Set[T]) Equal(other *Set[T]) bool {
+	return s.Size() == other.Size() && isSubset[T](s, other)
+}
+
+func (s *Set[T]) unionWith(other readable[T]) {
+	for v := range other.All() {
+		s.items[v] = struct{}{}
+	}
+}
+
+func (s *Set[T]) intersectWith(other readable[T]) {
+	if s.Size() <= other.Size() {
+		for k := range s.items {
+			if !other.Contains(k) {
+				delete(s.items, k)
+			}
+		}
+		return
+	}
+
+	// the other set is smaller, so build the result by iterating it.
+	items := make(map[T]struct{})
+	for v := range other.All() {
+		if _, ok := s.items[v]; ok {
+			items[v] = struct{}{}
+		}
+	}
+	s.items = items
+}
+
+func (s *Set[T]) differenceWith(other readable[T]) {
+	if other.Size() <= s.Size() {
+		for v := range other.All() {
+			delete(s.items, v)
+		}
+		return
+	}
+
+	for k := range s.items {
+		if other.Contains(k) {
+			delete(s.items, k)
+		}
+	}
+}
+
+func (s *Set[T]) symmetricDifferenceWith(other readable[T]) {
+	// collect the other set's elements first, in case other is s itself.
+	for _, v := range collect(other) {
+		if _, ok := s.items[v]; ok {
+			delete(s.items, v)
+		} else {
+			s.items[v] = struct{}{}
+		}
+	}
+}
+
+func union[T comparable](s *Set[T], other readable[T]) *Set[T] {
+	ret := s.Clone()
+	ret.unionWith(other)
+	return ret
+}
+
+func intersection[T comparable](s *Set[T], other readable[T]) *Set[T] {
+	ret := NewOf[T]()
+	small, large := smallerFirst(s, other)
+	for v := range small.All() {
+		if large.Contains(v) {
+			ret.items[v] = struct{}{}
+		}
+	}
+	return ret
+}
+
+func difference[T comparable](s *Set[T], other readable[T]) *Set[T] {
+	ret := NewOf[T]()
+	for k := range s.items {
+		if !other.Contains(k) {
+			ret.items[k] = struct{}{}
+		}
+	}
+	return ret
+}
+
+func symmetricDifference[T comparable](s *Set[T], other readable[T]) *Set[T] {
+	ret := difference(s, other)
+	for v := range other.All() {
+		if _, ok := s.items[v]; !ok {
+			ret.items[v] = struct{}{}
+		}
+	}
+	return ret
+}
+
+// isSubset returns true if every element in s1 is also in s2.
+func isSubset[T comparable](s1, s2 readable[T]) bool {
+	if s1.Size() > s2.Size() {
+		return false
+	}
+	for v := range s1.All() {
+		if !s2.Contains(v) {
+			return false
+		}
+	}
+	return true
+}
+
+func isDisjoint[T comparable](s1, s2 readable[T]) bool {
+	small, large := smallerFirst(s1, s2)
+	for v := range small.All() {
+		if large.Contains(v) {
+			return false
+		}
+	}
+	return true
+}
+
+// smallerFirst returns the two sets, the smaller one first.
+func smallerFirst[T comparable](s1, s2 readable[T]) (readable[T], readable[T]) {
+	if s1.Size() <= s2.Size() {
+		return s1, s2
+	}
+	return s2, s1
+}
+
+// collect copies all the elements of s into a slice.
+func collect[T comparable](s readable[T]) []T {
+	vals := make([]T, 0, s.Size())
+	for v := range s.All() {
+		vals = append(vals, v)
+	}
+	return vals
+}
@@ -0,0 +1,126 @@
+// Copyright (c) 2019, Benjamin Wang (benjamin_wang@aliyun.com). All rights reserved.
+// Licensed under the MIT license that can be found in the LICENSE file.
+
+package set_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/ahrtr/gocontainer/set"
+)
+
+func newSet(vals ...interface{}) set.Interface {
+	s := set.New()
+	s.Add(vals...)
+	return s
+}
+
+func checkSet(t *testing.T, name string, s set.Interface, expected ...interface{}) {
+	t.Helper()
+	if !s.Equal(newSet(expected...)) {
+		t.Errorf("%s isn't expected, expect: %v, actual: %v\n", name, expected, s.ToSlice())
+	}
+}
+
+func TestSetAlgebra(t *testing.T) {
+	s1 := newSet(1, 2, 3, 4)
+	s2 := newSet(3, 4, 5)
+
+	checkSet(t, "Union", s1.Union(s2), 1, 2, 3, 4, 5)
+	checkSet(t, "Intersection", s1.Intersection(s2), 3, 4)
+	checkSet(t, "Intersection", s2.Intersection(s1), 3, 4)
+	checkSet(t, "Difference", s1.Difference(s2), 1, 2)
+	checkSet(t, "Difference", s2.Difference(s1), 5)
+	checkSet(t, "SymmetricDifference", s1.SymmetricDifference(s2), 1, 2, 5)
+
+	// the operands aren't modified
+	checkSet(t, "s1", s1, 1, 2, 3, 4)
+	checkSet(t, "s2", s2, 3, 4, 5)
+}
+
+func TestSetAlgebraInPlace(t *testing.T) {
+	s := newSet(1, 2, 3, 4)
+	s.UnionWith(newSet(4, 5))
+	checkSet(t, "UnionWith", s, 1, 2, 3, 4, 5)
+
+	s.IntersectWith(newSet(2, 3, 4, 5, 6, 7, 8))
+	checkSet(t, "IntersectWith", s, 2, 3, 4, 5)
+	s.IntersectWith(newSet(3, 5, 9))
+	checkSet(t, "IntersectWith", s, 3, 5)
+
+	s = newSet(1, 2, 3, 4)
+	s.DifferenceWith(newSet(1, 9))
+	checkSet(t, "DifferenceWith", s, 2, 3, 4)
+	s.DifferenceWith(newSet(2, 5, 6, 7, 8, 9))
+	checkSet(t, "DifferenceWith", s, 3, 4)
+
+	s.SymmetricDifferenceWith(newSet(4, 5))
+	checkSet(t, "SymmetricDifferenceWith", s, 3, 5)
+	s.SymmetricDifferenceWith(s)
+	checkSet(t, "SymmetricDifferenceWith", s)
+}
+
+func TestSetRelations(t *testing.T) {
+	s1 := newSet(1, 2)
+	s2 := newSet(1, 2, 3)
+	s3 := newSet(4, 5)
+
+	if !s1.IsSubsetOf(s2) || s2.IsSubsetOf(s1) || !s1.IsSubsetOf(s1) {
+		t.Errorf("IsSubsetOf returns unexpected results\n")
+	}
+	if !s2.IsSupersetOf(s1) || s1.IsSupersetOf(s2) {
+		t.Errorf("IsSupersetOf returns unexpected results\n")
+	}
+	if !s1.IsDisjoint(s3) || s1.IsDisjoint(s2) || !set.New().IsDisjoint(s1) {
+		t.Errorf("IsDisjoint returns unexpected results\n")
+	}
+	if !s1.Equal(newSet(2, 1)) || s1.Equal(s2) || s1.Equal(newSet(1, 3)) {
+		t.Errorf("Equal returns unexpected results\n")
+	}
+}
+
+func TestSetCloneAndRemove(t *testing.T) {
+	s := newSet(1, 2, 3, 4, 5, 6)
+
+	c := s.Clone()
+	c.Remove(1)
+	if !s.Contains(1) || c.Size() != 5 {
+		t.Errorf("The clone isn't independent of the original set\n")
+	}
+
+	if n := s.RemoveIf(func(v interface{}) bool { return v.(int)%2 == 0 }); n != 3 {
+		t.Errorf("The number of removed elements isn't expected, expect: 3, actual: %d\n", n)
+	}
+	vals := s.ToSlice()
+	slices.SortFunc(vals, func(a, b interface{}) int { return a.(int) - b.(int) })
+	if !slices.Equal(vals, []interface{}{1, 3, 5}) {
+		t.Errorf("The elements aren't expected, expect: [1 3 5], actual: %v\n", vals)
+	}
+
+	for i := 0; i < 3; i++ {
+		v, ok := s.Pop()
+		if !ok || s.Contains(v) {
+			t.Errorf("Failed to pop an element, value: %v, success: %t\n", v, ok)
+		}
+	}
+	if v, ok := s.Pop(); ok || v != nil || !s.IsEmpty() {
+		t.Errorf("Popped an element from an empty set, value: %v, success: %t\n", v, ok)
+	}
+}
+
+func TestSetOfAlgebra(t *testing.T) {
+	s1 := set.NewOf[string]()
+	s1.Add("a", "b", "c")
+	s2 := set.NewOf[string]()
+	s2.Add("b", "c", "d")
+
+	vals := s1.Intersection(s2).ToSlice()
+	slices.Sort(vals)
+	if !slices.Equal(vals, []string{"b", "c"}) {
+		t.Errorf("The intersection isn't expected, expect: [b c], actual: %v\n", vals)
+	}
+	if s1.Union(s2).Size() != 4 || !s1.Union(s2).IsSupersetOf(s1) {
+		t.Errorf("The union isn't expected\n")
+	}
+}
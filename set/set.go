@@ -6,6 +6,8 @@
 // and interface types, and structs or arrays that contains only those types. Notably absent from the list are slices, maps, and functions;
 // these types cannot be compared using ==, and may not be contained in a set.
 //
+// New creates a set of interface{} values, while NewOf creates the type-parameterized Set[T].
+//
 // To iterate over a set (where s is a *set):
 //   s.Iterate(func(v interface{}) bool {
 //       // do something with v
@@ -13,9 +15,16 @@
 //   })
 // Returning false in the callback function will break the iterating.
 //
+// Or range over a set:
+//   for v := range s.All() {
+//       // do something with v
+//   }
+//
 package set
 
 import (
+	"iter"
+
 	"github.com/ahrtr/gocontainer/collection"
 )
 
@@ -33,34 +42,83 @@ type Interface interface {
 	Remove(val interface{}) bool
 	// Iterate iterates all the elements in this set.
 	Iterate(cb IterateCallback)
+	// All returns an iterator over all the elements in this set. The iteration order is not specified.
+	All() iter.Seq[interface{}]
+
+	// ToSlice returns a slice containing all the elements in this set. The order is not specified.
+	ToSlice() []interface{}
+	// Clone returns a shallow copy of this set.
+	Clone() Interface
+	// Pop removes and returns an arbitrary element from this set.
+	// It returns (element, true), or (nil, false) if this set is empty.
+	Pop() (interface{}, bool)
+	// RemoveIf removes all the elements which satisfy the given predicate, and returns the number of elements removed.
+	RemoveIf(f func(interface{}) bool) int
+
+	// Union returns a new set containing the elements in either this set or the other set.
+	Union(other Interface) Interface
+	// Intersection returns a new set containing the elements in both this set and the other set.
+	Intersection(other Interface) Interface
+	// Difference returns a new set containing the elements in this set but not in the other set.
+	Difference(other Interface) Interface
+	// SymmetricDifference returns a new set containing the elements in either this set or the other set, but not in both.
+	SymmetricDifference(other Interface) Interface
+
+	// UnionWith adds all the elements in the other set to this set.
+	UnionWith(other Interface)
+	// IntersectWith removes the elements which aren't in the other set from this set.
+	IntersectWith(other Interface)
+	// DifferenceWith removes the elements which are in the other set from this set.
+	DifferenceWith(other Interface)
+	// SymmetricDifferenceWith modifies this set to contain the elements in either this set or the other set, but not in both.
+	SymmetricDifferenceWith(other Interface)
+
+	// IsSubsetOf returns true if every element in this set is also in the other set.
+	IsSubsetOf(other Interface) bool
+	// IsSupersetOf returns true if every element in the other set is also in this set.
+	IsSupersetOf(other Interface) bool
+	// IsDisjoint returns true if this set and the other set have no elements in common.
+	IsDisjoint(other Interface) bool
+	// Equal returns true if this set and the other set contain the same elements.
+	Equal(other Interface) bool
 }
 
 // IterateCallback is the signature of the callback function called by Iterate.
 // If the callback function returns false, then the iteration breaks.
 type IterateCallback func(interface{}) bool
 
-// set is the definition of a set data structure, which contains no duplicate elements.
+// Set is the definition of a set data structure, which contains no duplicate elements of type T.
+type Set[T comparable] struct {
+	items map[T]struct{}
+}
+
+// set is the set returned by New, it implements the Interface.
 type set struct {
-	items map[interface{}]struct{}
+	*Set[interface{}]
 }
 
 // New creates a set.
 func New() Interface {
-	return &set{
-		items: map[interface{}]struct{}{},
+	return &set{NewOf[interface{}]()}
+}
+
+// NewOf creates a set, whose elements are of type T.
+func NewOf[T comparable]() *Set[T] {
+	return &Set[T]{
+		items: map[T]struct{}{},
 	}
 }
 
-func (s *set) Size() int {
+func (s *Set[T]) Size() int {
 	return len(s.items)
 }
 
 // IsEmpty returns true if this set contains no elements.
-func (s *set) IsEmpty() bool {
+func (s *Set[T]) IsEmpty() bool {
 	return s.Size() == 0
 }
 
-func (s *set) Add(vals ...interface{}) bool {
+func (s *Set[T]) Add(vals ...T) bool {
 	ret := true
 
 	for _, v := range vals {
@@ -73,14 +131,14 @@ func (s *set) Add(vals ...interface{}) bool {
 	return ret
 }
 
-func (s *set) Contains(val interface{}) bool {
+func (s *Set[T]) Contains(val T) bool {
 	if _, ok := s.items[val]; ok {
 		return true
 	}
 	return false
 }
 
-func (s *set) Remove(val interface{}) bool {
+func (s *Set[T]) Remove(val T) bool {
 	if _, ok := s.items[val]; ok {
 		delete(s.items, val)
 		return true
@@ -89,14 +147,31 @@ func (s *set) Remove(val interface{}) bool {
 }
 
 // Clear removes all the elements from this set.
-func (s *set) Clear() {
-	s.items = map[interface{}]struct{}{}
+func (s *Set[T]) Clear() {
+	s.items = map[T]struct{}{}
 }
 
-func (s *set) Iterate(cb IterateCallback) {
+// Iterate iterates all the elements in this set.
+// If the callback function returns false, then the iteration breaks.
+func (s *Set[T]) Iterate(cb func(T) bool) {
 	for k := range s.items {
 		if !cb(k) {
 			break
 		}
 	}
 }
+
+// All returns an iterator over all the elements in this set. The iteration order is not specified.
+func (s *Set[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for k := range s.items {
+			if !yield(k) {
+				return
+			}
+		}
+	}
+}
+
+func (s *set) Iterate(cb IterateCallback) {
+	s.Set.Iterate(cb)
+}
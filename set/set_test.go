@@ -85,3 +85,42 @@ func TestSetIterate(t *testing.T) {
 		return true
 	})
 }
+
+func TestSetOf(t *testing.T) {
+	s := set.NewOf[string]()
+
+	if !s.Add("hello", "world") {
+		t.Error("Failed to add values into this set")
+	}
+	if s.Add("hello") {
+		t.Error("The value 'hello' is already present in this set")
+	}
+	if s.Size() != 2 {
+		t.Errorf("The length isn't expected, expect: 2, actual: %d", s.Size())
+	}
+
+	count := 0
+	s.Iterate(func(v string) bool {
+		if v != "hello" && v != "world" {
+			t.Errorf("Unexpected value %s\n", v)
+		}
+		count++
+		return true
+	})
+	if count != 2 {
+		t.Errorf("The count of iterated values isn't expected, expect: 2, actual: %d", count)
+	}
+}
+
+func TestSetAll(t *testing.T) {
+	s := set.New()
+	s.Add(5, 6, 7)
+
+	sum := 0
+	for v := range s.All() {
+		sum += v.(int)
+	}
+	if sum != 18 {
+		t.Errorf("The sum isn't expected, expect: 18, actual: %d\n", sum)
+	}
+}
@@ -0,0 +1,252 @@
+// Copyright (c) 2019, Benjamin Wang (benjamin_wang@aliyun.com). All rights reserved.
+// Licensed under the MIT license that can be found in the LICENSE file.
+
+// Package treeset implements a sorted set based on a treemap. The elements are ordered according to their natural ordering,
+// or by a utils.Comparator provided by WithComparator, which should be called before any element is added into the set.
+//
+// Besides the common set operations, a treeSet supports the navigation methods (Floor, Ceiling, Lower, Higher,
+// First, Last, PollFirst and PollLast), and views (HeadSet, TailSet, SubSet and DescendingSet). A view is backed
+// by the set, so changes to the set are reflected in the view, and vice-versa. Adding an element out of the range of
+// a view panics. The navigation methods of a descending view work on the reversed order.
+//
+// New creates a sorted set of interface{} values, while NewOf creates the type-parameterized TreeSet[T].
+package treeset
+
+import (
+	"iter"
+
+	"github.com/ahrtr/gocontainer/collection"
+	"github.com/ahrtr/gocontainer/map/treemap"
+	"github.com/ahrtr/gocontainer/utils"
+)
+
+// Interface is a type of sorted set, and treeSet implements this interface.
+type Interface interface {
+	collection.Interface
+
+	// WithComparator sets an utils.Comparator instance for the set, which is used to impose a total ordering on the elements.
+	// It should be called before any element is added into the set.
+	WithComparator(c utils.Comparator) Interface
+
+	// Add adds the specified values to this set if they are not already present.
+	// It returns false if any value is already present. It panics if any value is out of the range of a view.
+	Add(vals ...interface{}) bool
+	// Contains returns true if this set contains the specified element.
+	Contains(val interface{}) bool
+	// Remove removes the specified element from this set if it is present.
+	// It returns false if the target value isn't present, otherwise returns true.
+	Remove(val interface{}) bool
+
+	// Floor returns the greatest element less than or equal to the given element, and true,
+	// or (nil, false) if there is no such element.
+	Floor(val interface{}) (interface{}, bool)
+	// Ceiling returns the least element greater than or equal to the given element, and true,
+	// or (nil, false) if there is no such element.
+	Ceiling(val interface{}) (interface{}, bool)
+	// Lower returns the greatest element strictly less than the given element, and true,
+	// or (nil, false) if there is no such element.
+	Lower(val interface{}) (interface{}, bool)
+	// Higher returns the least element strictly greater than the given element, and true,
+	// or (nil, false) if there is no such element.
+	Higher(val interface{}) (interface{}, bool)
+	// First returns the first (lowest) element in this set, and true, or (nil, false) if this set is empty.
+	First() (interface{}, bool)
+	// Last returns the last (highest) element in this set, and true, or (nil, false) if this set is empty.
+	Last() (interface{}, bool)
+	// PollFirst removes and returns the first (lowest) element in this set, and true, or (nil, false) if this set is empty.
+	PollFirst() (interface{}, bool)
+	// PollLast removes and returns the last (highest) element in this set, and true, or (nil, false) if this set is empty.
+	PollLast() (interface{}, bool)
+
+	// HeadSet returns a view of the portion of this set whose elements are less than (or equal to, if inclusive is true) to.
+	HeadSet(to interface{}, inclusive bool) Interface
+	// TailSet returns a view of the portion of this set whose elements are greater than (or equal to, if inclusive is true) from.
+	TailSet(from interface{}, inclusive bool) Interface
+	// SubSet returns a view of the portion of this set whose elements range from from to to.
+	SubSet(from interface{}, fromInclusive bool, to interface{}, toInclusive bool) Interface
+	// DescendingSet returns a reverse order view of this set.
+	DescendingSet() Interface
+
+	// All returns an iterator over the elements in this set in ascending order.
+	All() iter.Seq[interface{}]
+	// Backward returns an iterator over the elements in this set in descending order.
+	Backward() iter.Seq[interface{}]
+}
+
+// TreeSet is a sorted set, whose elements are of type T.
+type TreeSet[T any] struct {
+	m *treemap.TreeMap[T, struct{}]
+}
+
+// treeSet is the sorted set returned by New, it implements the Interface.
+type treeSet struct {
+	*TreeSet[interface{}]
+}
+
+// New creates a treeSet.
+func New() Interface {
+	return &treeSet{NewOf[interface{}](nil)}
+}
+
+// NewOf creates a TreeSet, whose elements are of type T.
+// The elements are ordered by cmp, or according to their natural ordering if cmp is nil.
+func NewOf[T any](cmp func(a, b T) int) *TreeSet[T] {
+	return &TreeSet[T]{treemap.NewOf[T, struct{}](cmp)}
+}
+
+func (ts *treeSet) WithComparator(c utils.Comparator) Interface {
+	ts.TreeSet.WithComparator(utils.CompareFunc[interface{}](c))
+	return ts
+}
+
+func (ts *treeSet) HeadSet(to interface{}, inclusive bool) Interface {
+	return &treeSet{ts.TreeSet.HeadSet(to, inclusive)}
+}
+
+func (ts *treeSet) TailSet(from interface{}, inclusive bool) Interface {
+	return &treeSet{ts.TreeSet.TailSet(from, inclusive)}
+}
+
+func (ts *treeSet) SubSet(from interface{}, fromInclusive bool, to interface{}, toInclusive bool) Interface {
+	return &treeSet{ts.TreeSet.SubSet(from, fromInclusive, to, toInclusive)}
+}
+
+func (ts *treeSet) DescendingSet() Interface {
+	return &treeSet{ts.TreeSet.DescendingSet()}
+}
+
+// WithComparator sets the comparison function for the set, which is used to impose a total ordering on the elements.
+// The elements are ordered according to their natural ordering if cmp is nil. It should be called before any element is added into the set.
+func (ts *TreeSet[T]) WithComparator(cmp func(a, b T) int) *TreeSet[T] {
+	ts.m.WithComparator(cmp)
+	return ts
+}
+
+// Size returns the number of elements in this set. It takes O(n) for a view, where n is the number of elements in the view.
+func (ts *TreeSet[T]) Size() int {
+	return ts.m.Size()
+}
+
+// IsEmpty returns true if this set contains no elements.
+func (ts *TreeSet[T]) IsEmpty() bool {
+	return ts.m.IsEmpty()
+}
+
+// Clear removes all the elements from this set.
+func (ts *TreeSet[T]) Clear() {
+	ts.m.Clear()
+}
+
+// Add adds the specified values to this set if they are not already present.
+// It returns false if any value is already present. It panics if any value is out of the range of a view.
+func (ts *TreeSet[T]) Add(vals ...T) bool {
+	ret := true
+	for _, v := range vals {
+		if ts.m.ContainsKey(v) {
+			ret = false
+			continue
+		}
+		ts.m.Put(v, struct{}{})
+	}
+	return ret
+}
+
+// Contains returns true if this set contains the specified element.
+func (ts *TreeSet[T]) Contains(val T) bool {
+	return ts.m.ContainsKey(val)
+}
+
+// Remove removes the specified element from this set if it is present.
+// It returns false if the target value isn't present, otherwise returns true.
+func (ts *TreeSet[T]) Remove(val T) bool {
+	_, ok := ts.m.Remove(val)
+	return ok
+}
+
+// Floor returns the greatest element less than or equal to the given element, and true,
+// or (zero value of T, false) if there is no such element.
+func (ts *TreeSet[T]) Floor(val T) (T, bool) {
+	return key(ts.m.Floor(val))
+}
+
+// Ceiling returns the least element greater than or equal to the given element, and true,
+// or (zero value of T, false) if there is no such element.
+func (ts *TreeSet[T]) Ceiling(val T) (T, bool) {
+	return key(ts.m.Ceiling(val))
+}
+
+// Lower returns the greatest element strictly less than the given element, and true,
+// or (zero value of T, false) if there is no such element.
+func (ts *TreeSet[T]) Lower(val T) (T, bool) {
+	return key(ts.m.Lower(val))
+}
+
+// Higher returns the least element strictly greater than the given element, and true,
+// or (zero value of T, false) if there is no such element.
+func (ts *TreeSet[T]) Higher(val T) (T, bool) {
+	return key(ts.m.Higher(val))
+}
+
+// First returns the first (lowest) element in this set, and true, or (zero value of T, false) if this set is empty.
+func (ts *TreeSet[T]) First() (T, bool) {
+	return ts.m.FirstKey()
+}
+
+// Last returns the last (highest) element in this set, and true, or (zero value of T, false) if this set is empty.
+func (ts *TreeSet[T]) Last() (T, bool) {
+	return ts.m.LastKey()
+}
+
+// PollFirst removes and returns the first (lowest) element in this set, and true, or (zero value of T, false) if this set is empty.
+func (ts *TreeSet[T]) PollFirst() (T, bool) {
+	return key(ts.m.PollFirst())
+}
+
+// PollLast removes and returns the last (highest) element in this set, and true, or (zero value of T, false) if this set is empty.
+func (ts *TreeSet[T]) PollLast() (T, bool) {
+	return key(ts.m.PollLast())
+}
+
+// HeadSet returns a view of the portion of this set whose elements are less than (or equal to, if inclusive is true) to.
+func (ts *TreeSet[T]) HeadSet(to T, inclusive bool) *TreeSet[T] {
+	return &TreeSet[T]{ts.m.HeadMap(to, inclusive)}
+}
+
+// TailSet returns a view of the portion of this set whose elements are greater than (or equal to, if inclusive is true) from.
+func (ts *TreeSet[T]) TailSet(from T, inclusive bool) *TreeSet[T] {
+	return &TreeSet[T]{ts.m.TailMap(from, inclusive)}
+}
+
+// SubSet returns a view of the portion of this set whose elements range from from to to.
+func (ts *TreeSet[T]) SubSet(from T, fromInclusive bool, to T, toInclusive bool) *TreeSet[T] {
+	return &TreeSet[T]{ts.m.SubMap(from, fromInclusive, to, toInclusive)}
+}
+
+// DescendingSet returns a reverse order view of this set.
+func (ts *TreeSet[T]) DescendingSet() *TreeSet[T] {
+	return &TreeSet[T]{ts.m.DescendingMap()}
+}
+
+// All returns an iterator over the elements in this set in ascending order.
+func (ts *TreeSet[T]) All() iter.Seq[T] {
+	return keys(ts.m.All())
+}
+
+// Backward returns an iterator over the elements in this set in descending order.
+func (ts *TreeSet[T]) Backward() iter.Seq[T] {
+	return keys(ts.m.Backward())
+}
+
+func key[T any](k T, _ struct{}, ok bool) (T, bool) {
+	return k, ok
+}
+
+func keys[T any](seq iter.Seq2[T, struct{}]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for k := range seq {
+			if !yield(k) {
+				return
+			}
+		}
+	}
+}
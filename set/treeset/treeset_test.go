@@ -0,0 +1,78 @@
+// Copyright (c) 2019, Benjamin Wang (benjamin_wang@aliyun.com). All rights reserved.
+// Licensed under the MIT license that can be found in the LICENSE file.
+
+package treeset_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/ahrtr/gocontainer/set/treeset"
+	"github.com/ahrtr/gocontainer/utils"
+)
+
+type reverseInt struct{}
+
+func (reverseInt) Compare(v1, v2 interface{}) (int, error) {
+	return v2.(int) - v1.(int), nil
+}
+
+func TestTreeSet(t *testing.T) {
+	ts := treeset.New()
+
+	if !ts.Add(30, 10, 20) || ts.Add(20) {
+		t.Errorf("Add returns unexpected results\n")
+	}
+	if !ts.Contains(10) || ts.Contains(15) || ts.Size() != 3 {
+		t.Errorf("Unexpected result, length: %d\n", ts.Size())
+	}
+	if vals := slices.Collect(ts.All()); !slices.Equal(vals, []interface{}{10, 20, 30}) {
+		t.Errorf("The elements aren't expected, expect: [10 20 30], actual: %v\n", vals)
+	}
+
+	if v, ok := ts.Floor(15); v != 10 || !ok {
+		t.Errorf("Floor(15) isn't expected, expect: 10, actual: %v\n", v)
+	}
+	if v, ok := ts.Ceiling(15); v != 20 || !ok {
+		t.Errorf("Ceiling(15) isn't expected, expect: 20, actual: %v\n", v)
+	}
+	if v, ok := ts.Lower(10); v != nil || ok {
+		t.Errorf("Lower(10) should be absent, actual: %v\n", v)
+	}
+	if v, ok := ts.Higher(20); v != 30 || !ok {
+		t.Errorf("Higher(20) isn't expected, expect: 30, actual: %v\n", v)
+	}
+
+	head := ts.HeadSet(20, true)
+	if vals := slices.Collect(head.Backward()); !slices.Equal(vals, []interface{}{20, 10}) {
+		t.Errorf("The elements of HeadSet aren't expected, expect: [20 10], actual: %v\n", vals)
+	}
+	desc := ts.DescendingSet()
+	if v, _ := desc.First(); v != 30 {
+		t.Errorf("First of DescendingSet isn't expected, expect: 30, actual: %v\n", v)
+	}
+	if v, _ := desc.PollLast(); v != 10 {
+		t.Errorf("PollLast of DescendingSet isn't expected, expect: 10, actual: %v\n", v)
+	}
+	if v, _ := ts.PollFirst(); v != 20 {
+		t.Errorf("PollFirst isn't expected, expect: 20, actual: %v\n", v)
+	}
+	if !ts.Remove(30) || !ts.IsEmpty() {
+		t.Errorf("The set should be empty, length: %d\n", ts.Size())
+	}
+}
+
+func TestTreeSetWithComparator(t *testing.T) {
+	ts := treeset.New().WithComparator(reverseInt{})
+	ts.Add(1, 3, 2)
+
+	if vals := slices.Collect(ts.All()); !slices.Equal(vals, []interface{}{3, 2, 1}) {
+		t.Errorf("The elements aren't expected, expect: [3 2 1], actual: %v\n", vals)
+	}
+
+	ts2 := treeset.NewOf(utils.CompareFunc[string](nil))
+	ts2.Add("b", "c", "a")
+	if vals := slices.Collect(ts2.SubSet("a", false, "c", true).All()); !slices.Equal(vals, []string{"b", "c"}) {
+		t.Errorf("The elements of SubSet aren't expected, expect: [b c], actual: %v\n", vals)
+	}
+}
@@ -2,9 +2,12 @@
 // Licensed under the MIT license that can be found in the LICENSE file.
 
 // Package stack implements a stack, which orders elements in a LIFO (last-in-first-out) manner.
+// New creates a stack of interface{} values, while NewOf creates the type-parameterized Stack[T].
 package stack
 
 import (
+	"iter"
+
 	"github.com/ahrtr/gocontainer/collection"
 	"github.com/ahrtr/gocontainer/list"
 )
@@ -19,55 +22,92 @@ type Interface interface {
 	Pop() interface{}
 	// Peek retrieves, but does not remove, the element on the top of this stack, or return nil if this stack is empty.
 	Peek() interface{}
+
+	// All returns an iterator over the elements in this stack, from the top to the bottom.
+	All() iter.Seq[interface{}]
+	// Backward returns an iterator over the elements in this stack, from the bottom to the top.
+	Backward() iter.Seq[interface{}]
 }
 
-// stack is a LIFO data structure.
-type stack struct {
-	l list.Interface
+// Stack is a LIFO data structure, whose elements are of type T.
+// Stack[interface{}] implements the Interface.
+type Stack[T any] struct {
+	l *list.ArrayList[T]
 }
 
 // New creates a stack.
 func New() Interface {
-	return &stack{list.NewArrayList()}
+	return NewOf[interface{}]()
+}
+
+// NewOf creates a stack, whose elements are of type T.
+func NewOf[T any]() *Stack[T] {
+	return &Stack[T]{list.NewArrayListOf[T]()}
 }
 
-func (s *stack) Size() int {
+func (s *Stack[T]) Size() int {
 	return s.l.Size()
 }
 
 // IsEmpty returns true if this stack contains no elements.
-func (s *stack) IsEmpty() bool {
+func (s *Stack[T]) IsEmpty() bool {
 	return s.l.Size() == 0
 }
 
-func (s *stack) Push(val interface{}) {
+func (s *Stack[T]) Push(val T) {
 	s.l.Add(val)
 }
 
-func (s *stack) Pop() interface{} {
+// Pop pops the element on the top of this stack, or returns the zero value of T if this stack is empty.
+func (s *Stack[T]) Pop() T {
+	var zero T
 	size := s.l.Size()
 	if size > 0 {
 		val, _ := s.l.Get(size - 1)
 		if _, err := s.l.Remove(size - 1); err != nil {
 			//todo: what should we do if failing to remove the element?
-			return nil
+			return zero
 		}
 
 		return val
 	}
-	return nil
+	return zero
 }
 
-func (s *stack) Peek() interface{} {
+// Peek retrieves, but does not remove, the element on the top of this stack, or returns the zero value of T if this stack is empty.
+func (s *Stack[T]) Peek() T {
+	var zero T
 	size := s.l.Size()
 	if size > 0 {
 		val, _ := s.l.Get(size - 1)
 		return val
 	}
-	return nil
+	return zero
 }
 
 // Clear removes all the elements from this stack.
-func (s *stack) Clear() {
+func (s *Stack[T]) Clear() {
 	s.l.Clear()
 }
+
+// All returns an iterator over the elements in this stack, from the top to the bottom.
+func (s *Stack[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, v := range s.l.Backward() {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Backward returns an iterator over the elements in this stack, from the bottom to the top.
+func (s *Stack[T]) Backward() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, v := range s.l.All() {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
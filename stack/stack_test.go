@@ -5,6 +5,7 @@ package stack_test
 
 import (
 	"reflect"
+	"slices"
 	"testing"
 
 	"github.com/ahrtr/gocontainer/stack"
@@ -95,3 +96,37 @@ func TestStackClear(t *testing.T) {
 		t.Errorf("The length isn't expected, expect: 0, actual: %d", s.Size())
 	}
 }
+
+func TestStackOf(t *testing.T) {
+	s := stack.NewOf[int]()
+
+	if v := s.Pop(); v != 0 {
+		t.Errorf("The zero value is expected for an empty stack, actual: %d\n", v)
+	}
+
+	s.Push(5)
+	s.Push(6)
+	if v := s.Peek(); v != 6 {
+		t.Errorf("The value peeked isn't expected, expect: 6, actual: %d\n", v)
+	}
+	if v := s.Pop(); v != 6 {
+		t.Errorf("The value popped isn't expected, expect: 6, actual: %d\n", v)
+	}
+	if s.Size() != 1 {
+		t.Errorf("The length isn't expected, expect: 1, actual: %d\n", s.Size())
+	}
+}
+
+func TestStackAll(t *testing.T) {
+	s := stack.NewOf[int]()
+	s.Push(5)
+	s.Push(6)
+	s.Push(7)
+
+	if got := slices.Collect(s.All()); !slices.Equal(got, []int{7, 6, 5}) {
+		t.Errorf("The values aren't expected, expect: [7 6 5], actual: %v\n", got)
+	}
+	if got := slices.Collect(s.Backward()); !slices.Equal(got, []int{5, 6, 7}) {
+		t.Errorf("The values aren't expected, expect: [5 6 7], actual: %v\n", got)
+	}
+}
@@ -0,0 +1,361 @@
+// Copyright (c) 2019, Benjamin Wang (benjamin_wang@aliyun.com). All rights reserved.
+// Licensed under the MIT license that can be found in the LICENSE file.
+
+// Package stream implements lazy pipelines over the elements of the containers.
+//
+// A stream is created from a container or a sequence, then transformed by the intermediate operations,
+// such as Filter, Map and Sorted, and finally consumed by a terminal operation, such as ToList, Count and Min.
+// The intermediate operations are lazy, nothing is evaluated until a terminal operation is called, and the
+// elements are pulled through the whole pipeline one by one. For example:
+//	total := stream.FromList(l).
+//		Filter(func(v interface{}) bool { return v.(int) > 0 }).
+//		Map(func(v interface{}) interface{} { return v.(int) * 2 }).
+//		Limit(10).
+//		Reduce(0, func(acc, v interface{}) interface{} { return acc.(int) + v.(int) })
+//
+// A stream should be consumed only once, since some sources, e.g. Drain, can't be traversed twice.
+// The elements are compared using utils.Compare, so the natural ordering is the same as in the containers.
+package stream
+
+import (
+	"iter"
+
+	"github.com/ahrtr/gocontainer/btree"
+	"github.com/ahrtr/gocontainer/list"
+	"github.com/ahrtr/gocontainer/map/linkedmap"
+	"github.com/ahrtr/gocontainer/queue"
+	"github.com/ahrtr/gocontainer/set"
+	"github.com/ahrtr/gocontainer/utils"
+)
+
+// Stream is a lazy sequence of elements, which supports the pipeline operations.
+type Stream struct {
+	seq iter.Seq[interface{}]
+}
+
+// Entry is a key-value pair of a map, which is the element of the streams created by FromLinkedMap.
+type Entry struct {
+	Key   interface{}
+	Value interface{}
+}
+
+// FromSeq returns a stream of the elements in the sequence.
+func FromSeq(seq iter.Seq[interface{}]) *Stream {
+	return &Stream{seq: seq}
+}
+
+// FromSeqOf returns a stream of the elements in the sequence of type T, e.g. the iterators of the
+// generic containers.
+func FromSeqOf[T any](seq iter.Seq[T]) *Stream {
+	return FromSeq(func(yield func(interface{}) bool) {
+		for v := range seq {
+			if !yield(v) {
+				return
+			}
+		}
+	})
+}
+
+// Of returns a stream of the specified values.
+func Of(vals ...interface{}) *Stream {
+	return FromSeq(func(yield func(interface{}) bool) {
+		for _, v := range vals {
+			if !yield(v) {
+				return
+			}
+		}
+	})
+}
+
+// FromList returns a stream of the elements in the list in proper sequence.
+func FromList(l list.Interface) *Stream {
+	return FromSeq(func(yield func(interface{}) bool) {
+		for _, v := range l.All() {
+			if !yield(v) {
+				return
+			}
+		}
+	})
+}
+
+// FromSet returns a stream of the elements in the set. The order is not specified.
+func FromSet(s set.Interface) *Stream {
+	return FromSeq(func(yield func(interface{}) bool) {
+		s.Iterate(func(v interface{}) bool {
+			return yield(v)
+		})
+	})
+}
+
+// FromLinkedMap returns a stream of the entries in the linked map in proper sequence.
+func FromLinkedMap(m linkedmap.Interface) *Stream {
+	return FromSeq(func(yield func(interface{}) bool) {
+		for k, v := range m.All() {
+			if !yield(Entry{Key: k, Value: v}) {
+				return
+			}
+		}
+	})
+}
+
+// FromBTree returns a stream of the items in the btree in ascending order.
+func FromBTree(t btree.Interface) *Stream {
+	return FromSeq(func(yield func(interface{}) bool) {
+		t.Ascend(func(item interface{}) bool {
+			return yield(item)
+		})
+	})
+}
+
+// Drain returns a stream which polls the elements from the queue until it's empty, so the elements
+// of a priority queue are in the order of their priorities. The elements are removed from the queue
+// lazily, only when they are pulled by the terminal operation.
+func Drain(q queue.Interface) *Stream {
+	return FromSeq(func(yield func(interface{}) bool) {
+		for !q.IsEmpty() {
+			if !yield(q.Poll()) {
+				return
+			}
+		}
+	})
+}
+
+// All returns an iterator over the elements in the stream.
+func (s *Stream) All() iter.Seq[interface{}] {
+	return s.seq
+}
+
+// Filter returns a stream of the elements which satisfy the predicate.
+func (s *Stream) Filter(pred func(val interface{}) bool) *Stream {
+	return FromSeq(func(yield func(interface{}) bool) {
+		for v := range s.seq {
+			if pred(v) && !yield(v) {
+				return
+			}
+		}
+	})
+}
+
+// Map returns a stream of the results of applying fn to the elements.
+func (s *Stream) Map(fn func(val interface{}) interface{}) *Stream {
+	return FromSeq(func(yield func(interface{}) bool) {
+		for v := range s.seq {
+			if !yield(fn(v)) {
+				return
+			}
+		}
+	})
+}
+
+// FlatMap returns a stream of the elements of the streams returned by fn for each element.
+// A nil stream returned by fn is treated as an empty stream.
+func (s *Stream) FlatMap(fn func(val interface{}) *Stream) *Stream {
+	return FromSeq(func(yield func(interface{}) bool) {
+		for v := range s.seq {
+			inner := fn(v)
+			if inner == nil {
+				continue
+			}
+			for iv := range inner.seq {
+				if !yield(iv) {
+					return
+				}
+			}
+		}
+	})
+}
+
+// Distinct returns a stream of the distinct elements, in the order of their first occurrences.
+// The elements must be comparable.
+func (s *Stream) Distinct() *Stream {
+	return FromSeq(func(yield func(interface{}) bool) {
+		seen := make(map[interface{}]struct{})
+		for v := range s.seq {
+			if _, ok := seen[v]; ok {
+				continue
+			}
+			seen[v] = struct{}{}
+			if !yield(v) {
+				return
+			}
+		}
+	})
+}
+
+// Sorted returns a stream of the elements sorted into ascending sequence according to their natural
+// ordering, or according to the provided comparator. All the elements are buffered once the first one
+// is pulled.
+func (s *Stream) Sorted(c utils.Comparator) *Stream {
+	return FromSeq(func(yield func(interface{}) bool) {
+		vals := s.ToSlice()
+		utils.Sort(vals, c)
+		for _, v := range vals {
+			if !yield(v) {
+				return
+			}
+		}
+	})
+}
+
+// Limit returns a stream of at most the first n elements.
+func (s *Stream) Limit(n int) *Stream {
+	return FromSeq(func(yield func(interface{}) bool) {
+		if n <= 0 {
+			return
+		}
+		i := 0
+		for v := range s.seq {
+			if !yield(v) {
+				return
+			}
+			if i++; i >= n {
+				return
+			}
+		}
+	})
+}
+
+// Skip returns a stream of the remaining elements after discarding the first n elements.
+func (s *Stream) Skip(n int) *Stream {
+	return FromSeq(func(yield func(interface{}) bool) {
+		i := 0
+		for v := range s.seq {
+			if i < n {
+				i++
+				continue
+			}
+			if !yield(v) {
+				return
+			}
+		}
+	})
+}
+
+// TakeWhile returns a stream of the longest prefix of the elements which satisfy the predicate.
+func (s *Stream) TakeWhile(pred func(val interface{}) bool) *Stream {
+	return FromSeq(func(yield func(interface{}) bool) {
+		for v := range s.seq {
+			if !pred(v) || !yield(v) {
+				return
+			}
+		}
+	})
+}
+
+// ForEach calls fn for each element in the stream.
+func (s *Stream) ForEach(fn func(val interface{})) {
+	for v := range s.seq {
+		fn(v)
+	}
+}
+
+// Reduce combines the elements by calling fn with the accumulated value, which starts from
+// initial, and each element. It returns initial if the stream is empty.
+func (s *Stream) Reduce(initial interface{}, fn func(acc, val interface{}) interface{}) interface{} {
+	acc := initial
+	for v := range s.seq {
+		acc = fn(acc, v)
+	}
+	return acc
+}
+
+// Count returns the number of elements in the stream.
+func (s *Stream) Count() int {
+	n := 0
+	for range s.seq {
+		n++
+	}
+	return n
+}
+
+// Min returns the minimum element according to their natural ordering, or according to the provided
+// comparator, and true. It returns (nil, false) if the stream is empty. If there are multiple minimum
+// elements, the first one is returned.
+func (s *Stream) Min(c utils.Comparator) (interface{}, bool) {
+	return s.extreme(c, -1)
+}
+
+// Max returns the maximum element according to their natural ordering, or according to the provided
+// comparator, and true. It returns (nil, false) if the stream is empty. If there are multiple maximum
+// elements, the first one is returned.
+func (s *Stream) Max(c utils.Comparator) (interface{}, bool) {
+	return s.extreme(c, 1)
+}
+
+// extreme returns the first element e, such that sign * cmp(v, e) <= 0 for every element v.
+func (s *Stream) extreme(c utils.Comparator, sign int) (interface{}, bool) {
+	cmp := utils.CompareFunc[interface{}](c)
+	var ret interface{}
+	found := false
+	for v := range s.seq {
+		if !found || sign*cmp(v, ret) > 0 {
+			ret, found = v, true
+		}
+	}
+	return ret, found
+}
+
+// ToSlice returns a slice containing the elements in the stream.
+func (s *Stream) ToSlice() []interface{} {
+	var vals []interface{}
+	for v := range s.seq {
+		vals = append(vals, v)
+	}
+	return vals
+}
+
+// ToList returns an arrayList containing the elements in the stream.
+func (s *Stream) ToList() list.Interface {
+	l := list.NewArrayList()
+	for v := range s.seq {
+		l.Add(v)
+	}
+	return l
+}
+
+// ToSet returns a set containing the distinct elements in the stream.
+func (s *Stream) ToSet() set.Interface {
+	ret := set.New()
+	for v := range s.seq {
+		ret.Add(v)
+	}
+	return ret
+}
+
+// ToLinkedMap returns a linked map, which maps the key of each element to its value, in the order of
+// the elements. If multiple elements have the same key, the value of the last one is kept, but the
+// position of the key is decided by the first one.
+func (s *Stream) ToLinkedMap(key, value func(val interface{}) interface{}) linkedmap.Interface {
+	m := linkedmap.New()
+	for v := range s.seq {
+		m.Put(key(v), value(v))
+	}
+	return m
+}
+
+// ToBTree returns a btree with the given degree containing the elements in the stream, which are ordered
+// according to their natural ordering, or according to the provided comparator. If multiple elements are
+// equal, the last one is kept.
+func (s *Stream) ToBTree(degree int, c utils.Comparator) btree.Interface {
+	t := btree.New(degree).WithComparator(c)
+	for v := range s.seq {
+		t.ReplaceOrInsert(v)
+	}
+	return t
+}
+
+// GroupingBy groups the elements by the keys returned by key, which must be comparable. Each group is
+// an arrayList containing the elements in the order of the stream.
+func (s *Stream) GroupingBy(key func(val interface{}) interface{}) map[interface{}]list.Interface {
+	groups := make(map[interface{}]list.Interface)
+	for v := range s.seq {
+		k := key(v)
+		g, ok := groups[k]
+		if !ok {
+			g = list.NewArrayList()
+			groups[k] = g
+		}
+		g.Add(v)
+	}
+	return groups
+}
@@ -0,0 +1,164 @@
+// Copyright (c) 2019, Benjamin Wang (benjamin_wang@aliyun.com). All rights reserved.
+// Licensed under the MIT license that can be found in the LICENSE file.
+
+package stream_test
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/ahrtr/gocontainer/btree"
+	"github.com/ahrtr/gocontainer/list"
+	"github.com/ahrtr/gocontainer/map/linkedmap"
+	"github.com/ahrtr/gocontainer/queue"
+	"github.com/ahrtr/gocontainer/queue/priorityqueue"
+	"github.com/ahrtr/gocontainer/set"
+	"github.com/ahrtr/gocontainer/stream"
+)
+
+// descending orders the integers in descending order.
+type descending struct{}
+
+func (descending) Compare(v1, v2 interface{}) (int, error) {
+	return v2.(int) - v1.(int), nil
+}
+
+func isEven(v interface{}) bool { return v.(int)%2 == 0 }
+
+func double(v interface{}) interface{} { return v.(int) * 2 }
+
+func check(t *testing.T, s *stream.Stream, want ...interface{}) {
+	t.Helper()
+	if got := s.ToSlice(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected elements, expect: %v, actual: %v", want, got)
+	}
+}
+
+func TestSources(t *testing.T) {
+	l := list.NewLinkedList()
+	l.Add(3, 1, 2)
+	check(t, stream.FromList(l), 3, 1, 2)
+
+	s := set.New()
+	s.Add(3, 1, 2)
+	got := stream.FromSet(s).ToSlice()
+	slices.SortFunc(got, func(a, b interface{}) int { return a.(int) - b.(int) })
+	if !reflect.DeepEqual(got, []interface{}{1, 2, 3}) {
+		t.Fatalf("unexpected elements: %v", got)
+	}
+
+	m := linkedmap.New()
+	m.Put("b", 2)
+	m.Put("a", 1)
+	check(t, stream.FromLinkedMap(m), stream.Entry{Key: "b", Value: 2}, stream.Entry{Key: "a", Value: 1})
+
+	bt := btree.New(2)
+	for _, v := range []int{5, 3, 4, 1, 2} {
+		bt.ReplaceOrInsert(v)
+	}
+	check(t, stream.FromBTree(bt).Limit(3), 1, 2, 3)
+
+	bto := btree.NewOf[int](2, nil)
+	bto.ReplaceOrInsert(2)
+	bto.ReplaceOrInsert(1)
+	check(t, stream.FromSeqOf(bto.All()), 1, 2)
+
+	// Draining a queue removes only the elements pulled.
+	q := queue.New()
+	q.Add(1, 2, 3, 4)
+	check(t, stream.Drain(q).Limit(2), 1, 2)
+	if q.Size() != 2 {
+		t.Fatalf("expect 2 elements left in the queue, actual: %d", q.Size())
+	}
+	pq := priorityqueue.New()
+	pq.Add(3, 1, 4, 2)
+	check(t, stream.Drain(pq), 1, 2, 3, 4)
+	if !pq.IsEmpty() {
+		t.Fatal("the priority queue should be drained")
+	}
+}
+
+func TestIntermediateOperations(t *testing.T) {
+	check(t, stream.Of(1, 2, 3, 4, 5, 6).Filter(isEven).Map(double), 4, 8, 12)
+	check(t, stream.Of(1, 2, 3).FlatMap(func(v interface{}) *stream.Stream {
+		if v == 2 {
+			return nil
+		}
+		return stream.Of(v, v)
+	}), 1, 1, 3, 3)
+	check(t, stream.Of(3, 1, 3, 2, 1).Distinct(), 3, 1, 2)
+	check(t, stream.Of(3, 1, 2).Sorted(nil), 1, 2, 3)
+	check(t, stream.Of(3, 1, 2).Sorted(descending{}), 3, 2, 1)
+	check(t, stream.Of("b", "c", "a").Sorted(nil), "a", "b", "c")
+	check(t, stream.Of(1, 2, 3).Limit(0))
+	check(t, stream.Of(1, 2, 3).Limit(5), 1, 2, 3)
+	check(t, stream.Of(1, 2, 3, 4).Skip(1).Limit(2), 2, 3)
+	check(t, stream.Of(1, 2, 3).Skip(5))
+	check(t, stream.Of(2, 4, 5, 6).TakeWhile(isEven), 2, 4)
+
+	// The operations are lazy, only the elements needed by the terminal operation are evaluated.
+	var mapped []interface{}
+	s := stream.Of(1, 2, 3, 4, 5).Map(func(v interface{}) interface{} {
+		mapped = append(mapped, v)
+		return v
+	}).Filter(isEven).Limit(1)
+	if mapped != nil {
+		t.Fatalf("nothing should be evaluated before the terminal operation, actual: %v", mapped)
+	}
+	check(t, s, 2)
+	if !reflect.DeepEqual(mapped, []interface{}{1, 2}) {
+		t.Fatalf("unexpected evaluated elements: %v", mapped)
+	}
+}
+
+func TestTerminalOperations(t *testing.T) {
+	if n := stream.Of(1, 2, 3).Filter(isEven).Count(); n != 1 {
+		t.Fatalf("Count, expect: 1, actual: %d", n)
+	}
+	if sum := stream.Of(1, 2, 3).Reduce(0, func(acc, v interface{}) interface{} { return acc.(int) + v.(int) }); sum != 6 {
+		t.Fatalf("Reduce, expect: 6, actual: %v", sum)
+	}
+	if v, ok := stream.Of(3, 1, 2).Min(nil); !ok || v != 1 {
+		t.Fatalf("Min, expect: 1, actual: %v", v)
+	}
+	if v, ok := stream.Of(3, 1, 2).Max(nil); !ok || v != 3 {
+		t.Fatalf("Max, expect: 3, actual: %v", v)
+	}
+	if v, ok := stream.Of(3, 1, 2).Max(descending{}); !ok || v != 1 {
+		t.Fatalf("Max with a comparator, expect: 1, actual: %v", v)
+	}
+	if v, ok := stream.Of().Min(nil); ok || v != nil {
+		t.Fatalf("Min of an empty stream, expect: nil, actual: %v", v)
+	}
+
+	l := stream.Of(1, 2, 3).ToList()
+	if l.Size() != 3 {
+		t.Fatalf("ToList, expect 3 elements, actual: %d", l.Size())
+	}
+	s := stream.Of(1, 2, 2, 3).ToSet()
+	if s.Size() != 3 || !s.Contains(2) {
+		t.Fatalf("ToSet, unexpected set: %v", s.ToSlice())
+	}
+
+	m := stream.Of("apple", "banana", "avocado").ToLinkedMap(
+		func(v interface{}) interface{} { return v.(string)[:1] },
+		func(v interface{}) interface{} { return v },
+	)
+	check(t, stream.FromLinkedMap(m), stream.Entry{Key: "a", Value: "avocado"}, stream.Entry{Key: "b", Value: "banana"})
+
+	bt := stream.Of(3, 1, 2, 1).ToBTree(2, nil)
+	check(t, stream.FromBTree(bt), 1, 2, 3)
+	bt = stream.Of(3, 1, 2).ToBTree(2, descending{})
+	check(t, stream.FromBTree(bt), 3, 2, 1)
+
+	groups := stream.Of(1, 2, 3, 4, 5).GroupingBy(func(v interface{}) interface{} { return isEven(v) })
+	check(t, stream.FromList(groups[true]), 2, 4)
+	check(t, stream.FromList(groups[false]), 1, 3, 5)
+
+	var got []interface{}
+	stream.Of(1, 2).ForEach(func(v interface{}) { got = append(got, v) })
+	if !reflect.DeepEqual(got, []interface{}{1, 2}) {
+		t.Fatalf("ForEach, unexpected elements: %v", got)
+	}
+}
@@ -169,3 +169,17 @@ func CompareTime(v1 interface{}, v2 interface{}) (bool, int) {
 	}
 	return false, 0
 }
+
+// CompareFunc returns a function which compares two values of type T using Compare with the given Comparator.
+// If the Comparator isn't provided, then the two values are compared according to their natural ordering.
+// The returned function panics if the two values can't be compared, which is the same behavior as the containers
+// in this repository.
+func CompareFunc[T any](cmp Comparator) func(v1, v2 T) int {
+	return func(v1, v2 T) int {
+		cmpRet, err := Compare(v1, v2, cmp)
+		if err != nil {
+			panic(err)
+		}
+		return cmpRet
+	}
+}
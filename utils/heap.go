@@ -26,7 +26,18 @@ func Init(h Interface) {
 //     isMinHeap: true for min-hap, false for max-heap
 //     c:         an utils.Comparator instance
 func HeapInit(values []interface{}, isMinHeap bool, c Comparator) {
-	sc := constructHeapContainer(values, isMinHeap, c)
+	HeapInitFunc(values, isMinHeap, CompareFunc[interface{}](c))
+}
+
+// HeapInitFunc is the same as HeapInit, but the elements are compared using the provided comparison function.
+func HeapInitFunc[T any](values []T, isMinHeap bool, cmp func(v1, v2 T) int) {
+	HeapInitIndexed(values, isMinHeap, cmp, nil)
+}
+
+// HeapInitIndexed is the same as HeapInitFunc, but swapped is called each time two elements are swapped,
+// so that the caller can track the index of each element in the heap. swapped may be nil.
+func HeapInitIndexed[T any](values []T, isMinHeap bool, cmp func(v1, v2 T) int, swapped func(i, j int)) {
+	sc := constructHeapContainer(values, isMinHeap, cmp, swapped)
 	n := sc.Len()
 	for i := n/2 - 1; i >= 0; i-- {
 		down(sc, i, n)
@@ -52,7 +63,18 @@ func Push(h Interface, x interface{}) {
 //     isMinHeap: true for min-hap, false for max-heap
 //     c:         an utils.Comparator instance
 func HeapPostPush(values []interface{}, isMinHeap bool, c Comparator) {
-	sc := constructHeapContainer(values, isMinHeap, c)
+	HeapPostPushFunc(values, isMinHeap, CompareFunc[interface{}](c))
+}
+
+// HeapPostPushFunc is the same as HeapPostPush, but the elements are compared using the provided comparison function.
+func HeapPostPushFunc[T any](values []T, isMinHeap bool, cmp func(v1, v2 T) int) {
+	HeapPostPushIndexed(values, isMinHeap, cmp, nil)
+}
+
+// HeapPostPushIndexed is the same as HeapPostPushFunc, but swapped is called each time two elements are swapped,
+// so that the caller can track the index of each element in the heap. swapped may be nil.
+func HeapPostPushIndexed[T any](values []T, isMinHeap bool, cmp func(v1, v2 T) int, swapped func(i, j int)) {
+	sc := constructHeapContainer(values, isMinHeap, cmp, swapped)
 	up(sc, sc.Len()-1)
 }
 
@@ -79,11 +101,21 @@ func Pop(h Interface) interface{} {
 //     isMinHeap: true for min-hap, false for max-heap
 //     c:         an utils.Comparator instance
 func HeapPrePop(values []interface{}, isMinHeap bool, c Comparator) {
+	HeapPrePopFunc(values, isMinHeap, CompareFunc[interface{}](c))
+}
+
+// HeapPrePopFunc is the same as HeapPrePop, but the elements are compared using the provided comparison function.
+func HeapPrePopFunc[T any](values []T, isMinHeap bool, cmp func(v1, v2 T) int) {
+	HeapPrePopIndexed(values, isMinHeap, cmp, nil)
+}
+
+// HeapPrePopIndexed is the same as HeapPrePopFunc, but swapped is called each time two elements are swapped,
+// so that the caller can track the index of each element in the heap. swapped may be nil.
+func HeapPrePopIndexed[T any](values []T, isMinHeap bool, cmp func(v1, v2 T) int, swapped func(i, j int)) {
 	// swap the first element (values[0]) and the last element (values[n])
 	n := len(values) - 1
-	values[0], values[n] = values[n], values[0]
-
-	sc := constructHeapContainer(values, isMinHeap, c)
+	sc := constructHeapContainer(values, isMinHeap, cmp, swapped)
+	sc.Swap(0, n)
 	down(sc, 0, n)
 }
 
@@ -114,11 +146,21 @@ func Remove(h Interface, i int) interface{} {
 //     isMinHeap: true for min-hap, false for max-heap
 //     c:         an utils.Comparator instance
 func HeapPreRemove(values []interface{}, index int, isMinHeap bool, c Comparator) {
+	HeapPreRemoveFunc(values, index, isMinHeap, CompareFunc[interface{}](c))
+}
+
+// HeapPreRemoveFunc is the same as HeapPreRemove, but the elements are compared using the provided comparison function.
+func HeapPreRemoveFunc[T any](values []T, index int, isMinHeap bool, cmp func(v1, v2 T) int) {
+	HeapPreRemoveIndexed(values, index, isMinHeap, cmp, nil)
+}
+
+// HeapPreRemoveIndexed is the same as HeapPreRemoveFunc, but swapped is called each time two elements are swapped,
+// so that the caller can track the index of each element in the heap. swapped may be nil.
+func HeapPreRemoveIndexed[T any](values []T, index int, isMinHeap bool, cmp func(v1, v2 T) int, swapped func(i, j int)) {
 	n := len(values) - 1
 	if n != index {
-		values[index], values[n] = values[n], values[index]
-
-		sc := constructHeapContainer(values, isMinHeap, c)
+		sc := constructHeapContainer(values, isMinHeap, cmp, swapped)
+		sc.Swap(index, n)
 		if !down(sc, index, n) {
 			up(sc, index)
 		}
@@ -148,17 +190,29 @@ func Fix(h Interface, i int) {
 //     isMinHeap: true for min-hap, false for max-heap
 //     c:         an utils.Comparator instance
 func HeapPostUpdate(values []interface{}, index int, isMinHeap bool, c Comparator) {
-	sc := constructHeapContainer(values, isMinHeap, c)
+	HeapPostUpdateFunc(values, index, isMinHeap, CompareFunc[interface{}](c))
+}
+
+// HeapPostUpdateFunc is the same as HeapPostUpdate, but the elements are compared using the provided comparison function.
+func HeapPostUpdateFunc[T any](values []T, index int, isMinHeap bool, cmp func(v1, v2 T) int) {
+	HeapPostUpdateIndexed(values, index, isMinHeap, cmp, nil)
+}
+
+// HeapPostUpdateIndexed is the same as HeapPostUpdateFunc, but swapped is called each time two elements are swapped,
+// so that the caller can track the index of each element in the heap. swapped may be nil.
+func HeapPostUpdateIndexed[T any](values []T, index int, isMinHeap bool, cmp func(v1, v2 T) int, swapped func(i, j int)) {
+	sc := constructHeapContainer(values, isMinHeap, cmp, swapped)
 	if !down(sc, index, sc.Len()) {
 		up(sc, index)
 	}
 }
 
-func constructHeapContainer(values []interface{}, isMinHeap bool, c Comparator) sort.Interface {
+func constructHeapContainer[T any](values []T, isMinHeap bool, cmp func(v1, v2 T) int, swapped func(i, j int)) sort.Interface {
+	sc := &sortableContainer[T]{items: values, cmp: cmp, swapped: swapped}
 	if isMinHeap {
-		return &sortableContainer{values, c}
+		return sc
 	}
-	return &reverseSortableContainer{&sortableContainer{values, c}}
+	return &reverseSortableContainer[T]{sc}
 }
 
 // copied from Go's package container/heap, but changed the first parameter from heap.Interface to sort.Interface.
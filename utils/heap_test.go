@@ -232,3 +232,59 @@ func heapFixTestImpl(t *testing.T, input []interface{}, expected []interface{},
 		t.Errorf("len(input) should be 0, but actual: %d\n", len(input))
 	}
 }
+
+/*-----------------------------------------------------------------------------
+// Test: the Indexed heap functions
+-----------------------------------------------------------------------------*/
+func TestHeapIndexed(t *testing.T) {
+	// positions[v] is the index of the value v in the heap
+	values := []int{9, 4, 7, 1, 8, 2}
+	positions := map[int]int{}
+	for i, v := range values {
+		positions[v] = i
+	}
+	swapped := func(i, j int) {
+		positions[values[i]], positions[values[j]] = i, j
+	}
+	checkPositions := func() {
+		t.Helper()
+		for v, i := range positions {
+			if values[i] != v {
+				t.Fatalf("The position of %d isn't tracked correctly, index: %d, values: %v\n", v, i, values)
+			}
+		}
+	}
+	cmp := utils.CompareFunc[int](nil)
+
+	utils.HeapInitIndexed(values, true, cmp, swapped)
+	checkPositions()
+
+	values = append(values, 0)
+	positions[0] = len(values) - 1
+	utils.HeapPostPushIndexed(values, true, cmp, swapped)
+	checkPositions()
+
+	i := positions[8]
+	values[i] = -1
+	delete(positions, 8)
+	positions[-1] = i
+	utils.HeapPostUpdateIndexed(values, i, true, cmp, swapped)
+	checkPositions()
+
+	utils.HeapPreRemoveIndexed(values, positions[4], true, cmp, swapped)
+	delete(positions, values[len(values)-1])
+	values = values[:len(values)-1]
+	checkPositions()
+
+	expected := []int{-1, 0, 1, 2, 7, 9}
+	for _, e := range expected {
+		utils.HeapPrePopIndexed(values, true, cmp, swapped)
+		v := values[len(values)-1]
+		if v != e {
+			t.Errorf("The value popped isn't expected, expect: %d, actual: %d\n", e, v)
+		}
+		delete(positions, v)
+		values = values[:len(values)-1]
+		checkPositions()
+	}
+}
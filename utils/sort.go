@@ -7,42 +7,53 @@ import (
 	"sort"
 )
 
-type sortableContainer struct {
-	items []interface{}
-	cmp   Comparator
+type sortableContainer[T any] struct {
+	items []T
+	cmp   func(v1, v2 T) int
+	// swapped is called after two elements are swapped if it isn't nil.
+	swapped func(i, j int)
 }
 
-type reverseSortableContainer struct {
-	*sortableContainer
+type reverseSortableContainer[T any] struct {
+	*sortableContainer[T]
 }
 
 // Sort sorts values into ascending sequence according to their natural ordering, or according to the provided comparator.
 func Sort(values []interface{}, c Comparator) {
-	sort.Sort(&sortableContainer{values, c})
+	SortFunc(values, CompareFunc[interface{}](c))
 }
 
 // ReverseSort sorts the values into opposite ordering to Sort.
 func ReverseSort(values []interface{}, c Comparator) {
-	sort.Sort(&reverseSortableContainer{&sortableContainer{values, c}})
+	ReverseSortFunc(values, CompareFunc[interface{}](c))
 }
 
-func (sc *sortableContainer) Len() int {
+// SortFunc sorts values into ascending sequence according to the provided comparison function.
+// The comparison function returns a negative integer, zero, or a positive integer as the first argument
+// is less than, equal to, or greater than the second.
+func SortFunc[T any](values []T, cmp func(v1, v2 T) int) {
+	sort.Sort(&sortableContainer[T]{items: values, cmp: cmp})
+}
+
+// ReverseSortFunc sorts the values into opposite ordering to SortFunc.
+func ReverseSortFunc[T any](values []T, cmp func(v1, v2 T) int) {
+	sort.Sort(&reverseSortableContainer[T]{&sortableContainer[T]{items: values, cmp: cmp}})
+}
+
+func (sc *sortableContainer[T]) Len() int {
 	return len(sc.items)
 }
-func (sc *sortableContainer) Swap(i, j int) {
+func (sc *sortableContainer[T]) Swap(i, j int) {
 	sc.items[i], sc.items[j] = sc.items[j], sc.items[i]
-}
-func (sc *sortableContainer) Less(i, j int) bool {
-	var cmpRet int
-	var err error
-	if cmpRet, err = Compare(sc.items[i], sc.items[j], sc.cmp); err != nil {
-		panic(err)
-	} else {
-		return cmpRet < 0
+	if sc.swapped != nil {
+		sc.swapped(i, j)
 	}
 }
+func (sc *sortableContainer[T]) Less(i, j int) bool {
+	return sc.cmp(sc.items[i], sc.items[j]) < 0
+}
 
 // Less returns the opposite of the embedded implementation's Less method.
-func (sc *reverseSortableContainer) Less(i, j int) bool {
+func (sc *reverseSortableContainer[T]) Less(i, j int) bool {
 	return sc.sortableContainer.Less(j, i)
 }
@@ -94,3 +94,22 @@ func (i reverseInt) Compare(v1, v2 interface{}) (int, error) {
 	}
 	return 0, nil
 }
+
+func TestSortFunc(t *testing.T) {
+	input := []int{6, 4, 9, 19, 15}
+	utils.SortFunc(input, func(v1, v2 int) int { return v1 - v2 })
+	expected := []int{4, 6, 9, 15, 19}
+	for i := 0; i < len(input); i++ {
+		if input[i] != expected[i] {
+			t.Errorf("Doesn't match, input[%d] = %v, expected[%d] = %v\n", i, input[i], i, expected[i])
+		}
+	}
+
+	utils.ReverseSortFunc(input, utils.CompareFunc[int](nil))
+	expected = []int{19, 15, 9, 6, 4}
+	for i := 0; i < len(input); i++ {
+		if input[i] != expected[i] {
+			t.Errorf("Doesn't match, input[%d] = %v, expected[%d] = %v\n", i, input[i], i, expected[i])
+		}
+	}
+}